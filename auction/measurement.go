@@ -0,0 +1,42 @@
+package auction
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+
+	auctionpb "github.com/radiusxyz/lightbulb-tdx/proto/auction"
+)
+
+const (
+	DefaultAuctionRtmrIndex = 3                // RTMR extended with the auction results
+	AuctionResultEventType  = "auction_result" // Event type of the auction results in the event log
+)
+
+// auctionRtmrIndex returns the RTMR set by AUCTION_RTMR_INDEX, or DefaultAuctionRtmrIndex.
+func auctionRtmrIndex() (int, error) {
+	value := os.Getenv("AUCTION_RTMR_INDEX")
+	if value == "" {
+		return DefaultAuctionRtmrIndex, nil
+	}
+	index, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid AUCTION_RTMR_INDEX '%s': %w", value, err)
+	}
+	return index, nil
+}
+
+// EncodeAuctionState deterministically encodes the auction info, the given bid list and the ordered tx list of an
+// ended auction. The auction results extended into the RTMR leave the bid list out: their SHA-384 digest is the
+// extended digest, and verifiers decode them as an AuctionState. Signed states include the bids in winning order.
+func EncodeAuctionState(state AuctionState, bidList []Bid) ([]byte, error) {
+	encoded := &auctionpb.AuctionState{
+		AuctionInfo:  ConvertDomainAuctionInfoToProtobuf(state.AuctionInfo),
+		BidList:      ConvertDomainBidsToProtobuf(bidList),
+		SortedTxList: ConvertDomainTxsToProtobuf(state.SortedTxList),
+		IsEnded:      state.IsEnded,
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(encoded)
+}
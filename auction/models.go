@@ -35,10 +35,11 @@ type AuctionInfo struct {
 
 // AuctionState represents the current state of an auction.
 type AuctionState struct {
-	AuctionInfo  AuctionInfo // Details of the auction.
-	BidList      []Bid       // List of all bids submitted.
-	SortedTxList []Tx        // List of all transactions sorted.
-	IsEnded      bool        // Indicates whether the auction has ended.
+	AuctionInfo  AuctionInfo     // Details of the auction.
+	BidList      []Bid           // List of all bids submitted.
+	SortedTxList []Tx            // List of all transactions sorted.
+	IsEnded      bool            // Indicates whether the auction has ended.
+	Signature    *StateSignature // Signature of the ended auction, nil until signed.
 }
 
 func ConvertProtobufTxToDomain(pbTx *auctionpb.Tx) Tx {
@@ -151,6 +152,7 @@ func ConvertProtobufAuctionStateToDomain(pbAuctionState *auctionpb.AuctionState)
 		BidList:      ConvertProtobufBidsToDomain(pbAuctionState.GetBidList()),
 		SortedTxList: ConvertProtobufTxsToDomain(pbAuctionState.GetSortedTxList()),
 		IsEnded:      pbAuctionState.GetIsEnded(),
+		Signature:    ConvertProtobufSignatureToDomain(pbAuctionState.GetSignature()),
 	}
 }
 
@@ -160,5 +162,40 @@ func ConvertDomainAuctionStateToProtobuf(domainAuctionState AuctionState) *aucti
 		BidList:      ConvertDomainBidsToProtobuf(domainAuctionState.BidList),
 		SortedTxList: ConvertDomainTxsToProtobuf(domainAuctionState.SortedTxList),
 		IsEnded:      domainAuctionState.IsEnded,
+		Signature:    ConvertDomainSignatureToProtobuf(domainAuctionState.Signature),
+	}
+}
+
+// ConvertProtobufSignatureToDomain converts a protobuf AuctionStateSignature to a domain StateSignature.
+func ConvertProtobufSignatureToDomain(pbSignature *auctionpb.AuctionStateSignature) *StateSignature {
+	if pbSignature == nil {
+		return nil
+	}
+	return &StateSignature{
+		KeyID:       pbSignature.GetKeyId(),
+		SignedState: pbSignature.GetSignedState(),
+		Signature:   pbSignature.GetSignature(),
+	}
+}
+
+// ConvertDomainSignatureToProtobuf converts a domain StateSignature to a protobuf AuctionStateSignature.
+func ConvertDomainSignatureToProtobuf(domainSignature *StateSignature) *auctionpb.AuctionStateSignature {
+	if domainSignature == nil {
+		return nil
+	}
+	return &auctionpb.AuctionStateSignature{
+		KeyId:       domainSignature.KeyID,
+		SignedState: domainSignature.SignedState,
+		Signature:   domainSignature.Signature,
+	}
+}
+
+// ConvertDomainSigningKeyToProtobuf converts a domain SigningKey to a protobuf SigningKey.
+func ConvertDomainSigningKeyToProtobuf(domainKey *SigningKey) *auctionpb.SigningKey {
+	return &auctionpb.SigningKey{
+		KeyId:     domainKey.KeyID,
+		PublicKey: domainKey.PublicKey,
+		Quote:     domainKey.Quote,
+		CreatedAt: domainKey.CreatedAt.UnixMilli(),
 	}
 }
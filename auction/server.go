@@ -2,9 +2,17 @@ package auction
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx"
+
 	auctionpb "github.com/radiusxyz/lightbulb-tdx/proto/auction"
 )
 
@@ -13,12 +21,29 @@ type Server struct {
 
 	workers      map[int64]*AuctionWorker    // Workers mapped by chain ID
 	mu           sync.RWMutex                // Mutex to ensure thread-safe access to the workers map.
+	tdxClient    tdx.TDXClientInterface      // TDX client shared by the workers.
+	signer       *Signer                     // Signer shared by the workers, nil if results are not signed.
 }
 
-// NewServer initializes a new gRPC server instance.
-func NewServer() *Server {
+// NewServer initializes a new gRPC server instance whose workers attest with the given TDX client and sign the
+// results of ended auctions with the signer, unless it is nil.
+func NewServer(tdxClient tdx.TDXClientInterface, signer *Signer) *Server {
 	return &Server{
-		workers: make(map[int64]*AuctionWorker),
+		workers:   make(map[int64]*AuctionWorker),
+		tdxClient: tdxClient,
+		signer:    signer,
+	}
+}
+
+// DisabledServiceHandler answers the calls of AuctionService with Unavailable, for a gRPC server that does not register
+// it, e.g. because the TD failed its self-attestation. Use it with grpc.UnknownServiceHandler.
+func DisabledServiceHandler(reason error) grpc.StreamHandler {
+	return func(srv interface{}, stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		if strings.HasPrefix(method, "/"+auctionpb.AuctionService_ServiceDesc.ServiceName+"/") {
+			return status.Errorf(codes.Unavailable, "AuctionService is disabled: %v", reason)
+		}
+		return status.Errorf(codes.Unimplemented, "unknown method %s", method)
 	}
 }
 
@@ -33,7 +58,7 @@ func (s *Server) AddAuction(ctx context.Context, req *auctionpb.AddAuctionReques
 	// Retrieve or create the worker for the chain
 	worker, exists := s.workers[info.ChainID]
 	if !exists {
-		worker = NewAuctionWorker(info.ChainID)
+		worker = NewAuctionWorker(info.ChainID, s.tdxClient, s.signer)
 		s.workers[info.ChainID] = worker
 	}
 
@@ -144,3 +169,22 @@ func (s *Server) GetAuctionState(ctx context.Context, req *auctionpb.GetAuctionS
 		State: ConvertDomainAuctionStateToProtobuf(state),
 	}, nil
 }
+
+// GetSigningKey retrieves a key signing the auction results, with a quote committing to it.
+func (s *Server) GetSigningKey(ctx context.Context, req *auctionpb.GetSigningKeyRequest) (*auctionpb.GetSigningKeyResponse, error) {
+	if s.signer == nil {
+		return nil, status.Error(codes.Unavailable, "auction results are not signed")
+	}
+
+	key, err := s.signer.Key(req.GetKeyId())
+	if errors.Is(err, ErrUnknownSigningKey) {
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get signing key: %v", err)
+	}
+
+	return &auctionpb.GetSigningKeyResponse{
+		SigningKey: ConvertDomainSigningKeyToProtobuf(key),
+	}, nil
+}
\ No newline at end of file
@@ -0,0 +1,43 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	auctionpb "github.com/radiusxyz/lightbulb-tdx/proto/auction"
+)
+
+func TestDisabledServiceHandler(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer(grpc.UnknownServiceHandler(DisabledServiceHandler(errors.New("self-attestation failed"))))
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient failed: %v", err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = auctionpb.NewAuctionServiceClient(conn).GetAuctionState(ctx, &auctionpb.GetAuctionStateRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("GetAuctionState = %v, want Unavailable", err)
+	}
+	err = conn.Invoke(ctx, "/other.Service/Method", &auctionpb.GetAuctionStateRequest{}, &auctionpb.GetAuctionStateResponse{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("call of an unknown service = %v, want Unimplemented", err)
+	}
+}
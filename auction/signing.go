@@ -0,0 +1,215 @@
+package auction
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+)
+
+const (
+	SigningKeyReportDataPrefix = "lightbulb-auction-signing-key" // Domain separator of the report data of signing keys
+	maxRetainedSigningKeys     = 16                              // Rotated keys kept to serve older signatures
+)
+
+var (
+	// ErrUnknownSigningKey is returned for a key ID that was never issued or is no longer retained.
+	ErrUnknownSigningKey = errors.New("unknown signing key")
+	// ErrInvalidSignature is returned when an auction state signature does not verify.
+	ErrInvalidSignature = errors.New("invalid auction state signature")
+)
+
+// SigningKey is a key generated in the TD to sign the auction results, with a quote committing to its public key.
+type SigningKey struct {
+	KeyID      uint64    // Identifier of the key, increasing with every rotation
+	PublicKey  []byte    // DER encoded SubjectPublicKeyInfo
+	Quote      []byte    // Raw quote whose report data is SigningKeyReportData(PublicKey)
+	CreatedAt  time.Time // Creation time of the key
+	privateKey *ecdsa.PrivateKey
+}
+
+// StateSignature is the signature of an ended auction.
+type StateSignature struct {
+	KeyID       uint64 // Identifier of the signing key
+	SignedState []byte // Encoding of the signed state, see EncodeAuctionState
+	Signature   []byte // ASN.1 ECDSA signature of the SHA-256 digest of SignedState
+}
+
+// Signer signs auction results with a key that only lives in the memory of the TD.
+type Signer struct {
+	tdxClient tdx.TDXClientInterface
+
+	mu   sync.RWMutex
+	keys []*SigningKey // Retained keys, the current one last
+}
+
+// NewSigner creates a Signer with a fresh key.
+func NewSigner(tdxClient tdx.TDXClientInterface) (*Signer, error) {
+	s := &Signer{tdxClient: tdxClient}
+	if err := s.Rotate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// DefaultSigningKeyRotationInterval returns the interval set by SIGNING_KEY_ROTATION_INTERVAL, or 0 if keys are not
+// rotated.
+func DefaultSigningKeyRotationInterval() time.Duration {
+	value := os.Getenv("SIGNING_KEY_ROTATION_INTERVAL")
+	if value == "" {
+		return 0
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		log.Printf("[Warning] Invalid SIGNING_KEY_ROTATION_INTERVAL '%s'. Defaulting to no rotation.", value)
+		return 0
+	}
+	return interval
+}
+
+// SigningKeyReportData returns the report data committing to a public key.
+func SigningKeyReportData(publicKey []byte) [tdx.ReportDataSize]byte {
+	hasher := sha512.New()
+	hasher.Write([]byte(SigningKeyReportDataPrefix))
+	hasher.Write(publicKey)
+	var reportData [tdx.ReportDataSize]byte
+	copy(reportData[:], hasher.Sum(nil))
+	return reportData
+}
+
+// Rotate generates a new key and a quote committing to it. Signatures of the previous keys stay verifiable as long
+// as the keys are retained.
+func (s *Signer) Rotate() error {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal signing key: %w", err)
+	}
+
+	quote, err := tdx.GetQuoteForReportData(s.tdxClient, SigningKeyReportData(publicKey))
+	if err != nil {
+		return err
+	}
+	rawQuote, err := utils.ConvertQuoteToRawQuote(quote)
+	if err != nil {
+		return fmt.Errorf("failed to serialize quote: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := &SigningKey{
+		KeyID:      1,
+		PublicKey:  publicKey,
+		Quote:      rawQuote,
+		CreatedAt:  time.Now(),
+		privateKey: privateKey,
+	}
+	if len(s.keys) > 0 {
+		key.KeyID = s.keys[len(s.keys)-1].KeyID + 1
+	}
+	s.keys = append(s.keys, key)
+	if len(s.keys) > maxRetainedSigningKeys {
+		s.keys = s.keys[len(s.keys)-maxRetainedSigningKeys:]
+	}
+	return nil
+}
+
+// RunRotation rotates the key at every interval. It blocks until the context is done.
+func (s *Signer) RunRotation(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Rotate(); err != nil {
+				log.Printf("[Error] Failed to rotate signing key: %v", err)
+				continue
+			}
+			log.Printf("Rotated signing key to key %d", s.CurrentKey().KeyID)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// CurrentKey returns the key used for new signatures.
+func (s *Signer) CurrentKey() *SigningKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keys[len(s.keys)-1]
+}
+
+// Key returns the retained key with the given ID, or the current key for ID 0.
+func (s *Signer) Key(keyID uint64) (*SigningKey, error) {
+	if keyID == 0 {
+		return s.CurrentKey(), nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, key := range s.keys {
+		if key.KeyID == keyID {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d", ErrUnknownSigningKey, keyID)
+}
+
+// SignState signs the auction info, the bids in winning order and the ordered tx list of an ended auction.
+func (s *Signer) SignState(state AuctionState) (*StateSignature, error) {
+	if !state.IsEnded {
+		return nil, fmt.Errorf("auction %s has not ended", state.AuctionInfo.AuctionID)
+	}
+	signedState, err := EncodeAuctionState(state, state.BidList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode auction state: %w", err)
+	}
+
+	key := s.CurrentKey()
+	digest := sha256.Sum256(signedState)
+	signature, err := ecdsa.SignASN1(rand.Reader, key.privateKey, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign auction state: %w", err)
+	}
+
+	return &StateSignature{
+		KeyID:       key.KeyID,
+		SignedState: signedState,
+		Signature:   signature,
+	}, nil
+}
+
+// VerifyStateSignature checks a signature against the DER encoded public key of the signing key. Callers must check
+// the quote of the signing key separately, and compare SignedState with the state they expect.
+func VerifyStateSignature(publicKey []byte, signature *StateSignature) error {
+	key, err := x509.ParsePKIXPublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse signing key: %w", err)
+	}
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("unexpected signing key type: %T", key)
+	}
+	digest := sha256.Sum256(signature.SignedState)
+	if !ecdsa.VerifyASN1(ecdsaKey, digest[:], signature.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package auction
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx"
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+
+	auctionpb "github.com/radiusxyz/lightbulb-tdx/proto/auction"
+)
+
+func newTestSigner(t *testing.T) *Signer {
+	tdxtest.ClearEnv(t)
+	signer, err := NewSigner(tdx.NewMockTDXClient())
+	if err != nil {
+		t.Fatalf("NewSigner failed: %v", err)
+	}
+	return signer
+}
+
+func endedState() AuctionState {
+	return AuctionState{
+		AuctionInfo: AuctionInfo{
+			AuctionID: "auction-1",
+			ChainID:   1,
+			StartTime: time.UnixMilli(1000),
+			EndTime:   time.UnixMilli(2000),
+		},
+		BidList: []Bid{
+			{BidderAddr: "0xb", BidAmount: 20, TxList: []Tx{{TxData: "tx-b"}}},
+			{BidderAddr: "0xa", BidAmount: 10, TxList: []Tx{{TxData: "tx-a"}}},
+		},
+		SortedTxList: []Tx{{TxData: "tx-b"}, {TxData: "tx-a"}},
+		IsEnded:      true,
+	}
+}
+
+func TestSigningKeyQuote(t *testing.T) {
+	signer := newTestSigner(t)
+	key := signer.CurrentKey()
+
+	quote, err := utils.ConvertRawQuoteToQuote(key.Quote)
+	if err != nil {
+		t.Fatalf("ConvertRawQuoteToQuote failed: %v", err)
+	}
+	reportData := SigningKeyReportData(key.PublicKey)
+	if !bytes.Equal(utils.GetTDQuoteBody(quote).GetReportData(), reportData[:]) {
+		t.Errorf("REPORTDATA = %x, want %x", utils.GetTDQuoteBody(quote).GetReportData(), reportData)
+	}
+}
+
+func TestSignState(t *testing.T) {
+	signer := newTestSigner(t)
+	state := endedState()
+
+	signature, err := signer.SignState(state)
+	if err != nil {
+		t.Fatalf("SignState failed: %v", err)
+	}
+	if err := VerifyStateSignature(signer.CurrentKey().PublicKey, signature); err != nil {
+		t.Errorf("VerifyStateSignature failed: %v", err)
+	}
+
+	// The signed state covers the info, the bids and the ordering
+	var signed auctionpb.AuctionState
+	if err := proto.Unmarshal(signature.SignedState, &signed); err != nil {
+		t.Fatalf("failed to decode signed state: %v", err)
+	}
+	if signed.GetAuctionInfo().GetAuctionId() != "auction-1" || len(signed.GetBidList()) != 2 || signed.GetSortedTxList()[0].GetTxData() != "tx-b" {
+		t.Errorf("signed state = %v, want the ended auction", &signed)
+	}
+
+	tampered := *signature
+	tampered.SignedState = append([]byte{}, signature.SignedState...)
+	tampered.SignedState[len(tampered.SignedState)-1] ^= 1
+	if err := VerifyStateSignature(signer.CurrentKey().PublicKey, &tampered); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyStateSignature of a tampered state = %v, want ErrInvalidSignature", err)
+	}
+
+	state.IsEnded = false
+	if _, err := signer.SignState(state); err == nil {
+		t.Error("SignState of a running auction succeeded")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	signer := newTestSigner(t)
+	first := signer.CurrentKey()
+	signature, err := signer.SignState(endedState())
+	if err != nil {
+		t.Fatalf("SignState failed: %v", err)
+	}
+
+	if err := signer.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	second := signer.CurrentKey()
+	if second.KeyID != first.KeyID+1 {
+		t.Errorf("key ID after rotation = %d, want %d", second.KeyID, first.KeyID+1)
+	}
+	if bytes.Equal(second.PublicKey, first.PublicKey) || bytes.Equal(second.Quote, first.Quote) {
+		t.Error("rotation kept the key or the quote")
+	}
+
+	// Signatures of the previous key still verify with it
+	key, err := signer.Key(signature.KeyID)
+	if err != nil {
+		t.Fatalf("Key failed: %v", err)
+	}
+	if err := VerifyStateSignature(key.PublicKey, signature); err != nil {
+		t.Errorf("VerifyStateSignature with the previous key failed: %v", err)
+	}
+	if err := VerifyStateSignature(second.PublicKey, signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyStateSignature with the new key = %v, want ErrInvalidSignature", err)
+	}
+
+	server := NewServer(nil, signer)
+	resp, err := server.GetSigningKey(context.Background(), &auctionpb.GetSigningKeyRequest{})
+	if err != nil {
+		t.Fatalf("GetSigningKey failed: %v", err)
+	}
+	if resp.GetSigningKey().GetKeyId() != second.KeyID {
+		t.Errorf("current key ID = %d, want %d", resp.GetSigningKey().GetKeyId(), second.KeyID)
+	}
+	_, err = server.GetSigningKey(context.Background(), &auctionpb.GetSigningKeyRequest{KeyId: 42})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetSigningKey of an unknown key = %v, want NotFound", err)
+	}
+}
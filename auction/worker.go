@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
 	"sort"
 	"sync"
 	"time"
@@ -25,21 +24,17 @@ type AuctionWorker struct {
 	auctionQueue []AuctionInfo               // Queue of auctions sorted by StartTime.
 	interruptCh  chan struct{}   		     // Channel to interrupt waiting when queue changes.
 	tdxClient    tdx.TDXClientInterface      // TDX client for quote generation.
+	rtmrIndex    int                         // RTMR extended with the results of ended auctions.
+	signer       *Signer                     // Signs the results of ended auctions, if set.
 }
 
-// NewAuctionWorker initializes a new AuctionWorker and starts its queue processor.
-func NewAuctionWorker(chainID int64) *AuctionWorker {
-	var tdxClient tdx.TDXClientInterface
-	
-	env := os.Getenv("ENV")
-
-	if env == "TDX" {
-		tdxClient = tdx.NewTDXClient()
-	} else if env == "MOCK_TDX" {
-		tdxClient = tdx.NewMockTDXClient()
-	} else {
-		log.Printf("[Warning] Unknown environment '%s'. Defaulting to MockTDXClient.", env)
-		tdxClient = tdx.NewMockTDXClient()
+// NewAuctionWorker initializes a new AuctionWorker and starts its queue processor. Ended auctions are signed by the
+// signer, unless it is nil.
+func NewAuctionWorker(chainID int64, tdxClient tdx.TDXClientInterface, signer *Signer) *AuctionWorker {
+	rtmrIndex, err := auctionRtmrIndex()
+	if err != nil {
+		log.Printf("[Worker %d] %v. Defaulting to RTMR[%d].\n", chainID, err, DefaultAuctionRtmrIndex)
+		rtmrIndex = DefaultAuctionRtmrIndex
 	}
 
 	worker := &AuctionWorker{
@@ -47,6 +42,8 @@ func NewAuctionWorker(chainID int64) *AuctionWorker {
 		state:        &AuctionState{},
 		tdxClient:    tdxClient,
 		interruptCh:  make(chan struct{}, 1),
+		rtmrIndex:    rtmrIndex,
+		signer:       signer,
 	}
 	worker.queueCond = sync.NewCond(&worker.mu)
 
@@ -67,6 +64,7 @@ func (w *AuctionWorker) initializeAuction(info AuctionInfo) error {
 	w.state.AuctionInfo = info
 	w.state.BidList = []Bid{}
 	w.state.IsEnded = false
+	w.state.Signature = nil
 
 	log.Printf("[Worker %d] Initializing auction (ID: %s)\n", w.chainID, info.AuctionID)
 	return nil
@@ -233,16 +231,6 @@ func (w *AuctionWorker) runAuction(ctx context.Context, info AuctionInfo) {
 		return
 	}
 
-	// Defer getting a quote after the auction ends.
-	defer func() {
-		quote, err := tdx.GetQuote(w.tdxClient)
-		if err != nil {
-			log.Printf("[Worker %d] Failed to get quote: %v\n", w.chainID, err)
-		} else {
-			log.Printf("[Worker %d] Quote: %v\n", w.chainID, quote)
-		}
-	}()
-
 	subCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -255,11 +243,55 @@ func (w *AuctionWorker) runAuction(ctx context.Context, info AuctionInfo) {
 	select {
 	case <-done:
 		log.Printf("[Worker %d] Auction (ID: %s) completed.\n", w.chainID, info.AuctionID)
+		if err := w.measureAuctionResult(); err != nil {
+			log.Printf("[Worker %d] Failed to measure auction result: %v\n", w.chainID, err)
+		}
+		if err := w.signAuctionResult(); err != nil {
+			log.Printf("[Worker %d] Failed to sign auction result: %v\n", w.chainID, err)
+		}
 	case <-ctx.Done():
 		log.Printf("[Worker %d] Context canceled. Stopping auction (ID: %s).\n", w.chainID, info.AuctionID)
 	}
 }
 
+// measureAuctionResult extends the RTMR with the result of the ended auction, so that later quotes commit to it.
+func (w *AuctionWorker) measureAuctionResult() error {
+	state := w.GetAuctionState()
+	if !state.IsEnded {
+		return fmt.Errorf("auction %s has not ended", state.AuctionInfo.AuctionID)
+	}
+
+	result, err := EncodeAuctionState(state, nil)
+	if err != nil {
+		return fmt.Errorf("failed to encode auction result: %w", err)
+	}
+	if err := w.tdxClient.ExtendRtmr(w.rtmrIndex, AuctionResultEventType, result); err != nil {
+		return err
+	}
+
+	log.Printf("[Worker %d] Extended RTMR[%d] with the result of auction %s.\n", w.chainID, w.rtmrIndex, state.AuctionInfo.AuctionID)
+	return nil
+}
+
+// signAuctionResult signs the state of the ended auction with the TD-bound signing key.
+func (w *AuctionWorker) signAuctionResult() error {
+	if w.signer == nil {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	signature, err := w.signer.SignState(*w.state)
+	if err != nil {
+		return err
+	}
+	w.state.Signature = signature
+
+	log.Printf("[Worker %d] Signed the result of auction %s with key %d.\n", w.chainID, w.state.AuctionInfo.AuctionID, signature.KeyID)
+	return nil
+}
+
 // GetAuctionInfo retrieves the current auction info.
 func (w *AuctionWorker) GetAuctionInfo() AuctionInfo {
 	w.mu.RLock()
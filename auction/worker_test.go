@@ -0,0 +1,45 @@
+package auction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx"
+)
+
+func TestRunAuctionWithFaultyTDX(t *testing.T) {
+	signer := newTestSigner(t)
+	client, err := tdx.NewFaultInjectingTDXClient(tdx.NewMockTDXClient(), tdx.MockFaultConfig{
+		RtmrExtendErrorRate: 1,
+		RtmrLatency:         tdx.Latency{Distribution: tdx.LatencyConstant, Mean: 20 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("NewFaultInjectingTDXClient failed: %v", err)
+	}
+	worker := NewAuctionWorker(1, client, signer)
+
+	now := time.Now()
+	info := AuctionInfo{AuctionID: "auction-1", ChainID: 1, StartTime: now, EndTime: now.Add(50 * time.Millisecond)}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	worker.runAuction(ctx, info)
+
+	// The auction ends and is signed even though its result could not be measured
+	state := worker.GetAuctionState()
+	if !state.IsEnded {
+		t.Fatal("auction did not end")
+	}
+	if state.Signature == nil {
+		t.Error("auction was not signed")
+	}
+	page, err := client.GetEventLog(0, 0)
+	if err != nil {
+		t.Fatalf("GetEventLog failed: %v", err)
+	}
+	for _, event := range page.Events {
+		if event.EventType == AuctionResultEventType {
+			t.Errorf("auction result was measured despite the injected failures: %+v", event)
+		}
+	}
+}
@@ -4,7 +4,8 @@ import (
 	"context"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/radiusxyz/lightbulb-tdx/ratls"
 
 	benchpb "github.com/radiusxyz/lightbulb-tdx/proto/benchmark"
 )
@@ -17,9 +18,13 @@ type Client struct {
 
 // NewClient initializes a new Client instance using grpc.NewClientConn.
 func NewClient(serverAddr string) (*Client, error) {
-	// Set up connection options.
+	// Set up connection options, checking the RA-TLS certificate of the server if enabled.
+	creds, err := ratls.DefaultTransportCredentials()
+	if err != nil {
+		return nil, err
+	}
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
 	}
 
 	// Use grpc.NewClientConn to establish the connection.
@@ -0,0 +1,237 @@
+package collateral
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/go-tdx-guest/verify/trust"
+)
+
+// DefaultCacheTTL is how long fetched collateral is served from the cache before it is fetched again, unless it
+// expires earlier.
+const DefaultCacheTTL = 24 * time.Hour
+
+// ErrNotCached is returned by a Cache without source for collateral it does not hold.
+var ErrNotCached = errors.New("collateral not cached")
+
+// Config configures the collateral source.
+type Config struct {
+	PCCSURL  string        // Base URL of the PCCS; empty serves the cache only
+	CacheDir string        // Directory of the cache; empty uses DefaultCacheDir
+	CacheTTL time.Duration // Maximum age of cached collateral
+}
+
+// DefaultCacheDir returns the directory collateral is cached in if CacheDir is not set.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "lightbulb-tdx", "collateral")
+}
+
+// DefaultConfig reads the collateral configuration from COLLATERAL_PCCS_URL, COLLATERAL_CACHE_DIR and
+// COLLATERAL_CACHE_TTL.
+func DefaultConfig() Config {
+	config := Config{
+		PCCSURL:  os.Getenv("COLLATERAL_PCCS_URL"),
+		CacheDir: os.Getenv("COLLATERAL_CACHE_DIR"),
+		CacheTTL: DefaultCacheTTL,
+	}
+
+	if value := os.Getenv("COLLATERAL_CACHE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			log.Printf("[Warning] Invalid COLLATERAL_CACHE_TTL '%s'. Defaulting to %v.", value, DefaultCacheTTL)
+		} else {
+			config.CacheTTL = ttl
+		}
+	}
+	return config
+}
+
+// NewSource creates a cache in front of the PCCS of the configuration. It returns nil if neither a PCCS nor a cache
+// directory is configured, in which case collateral is not checked.
+func NewSource(config Config) (*Cache, error) {
+	if config.PCCSURL == "" && config.CacheDir == "" {
+		return nil, nil
+	}
+	if config.CacheDir == "" {
+		config.CacheDir = DefaultCacheDir()
+	}
+
+	var source trust.HTTPSGetter
+	if config.PCCSURL != "" {
+		source = NewPCCSClient(config.PCCSURL)
+	}
+	return NewCache(config.CacheDir, config.CacheTTL, source)
+}
+
+// DefaultSource creates the collateral source configured by DefaultConfig, or nil if none is configured.
+func DefaultSource() (*Cache, error) {
+	return NewSource(DefaultConfig())
+}
+
+// cacheEntry is a response stored in the cache directory.
+type cacheEntry struct {
+	URL       string              `json:"url"`
+	Header    map[string][]string `json:"header"`
+	Body      []byte              `json:"body"`
+	FetchedAt time.Time           `json:"fetched_at"`
+	ExpiresAt time.Time           `json:"expires_at"`
+}
+
+// Cache keeps collateral on disk and implements trust.HTTPSGetter. Collateral is fetched from the source when it is
+// missing or expired; expired collateral is still served while the source is unreachable, so that the verifier can
+// decide whether it is acceptable. Without source the cache only serves what it holds.
+type Cache struct {
+	dir    string
+	ttl    time.Duration
+	source trust.HTTPSGetter
+	now    func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewCache creates a cache in dir, fetching from source collateral older than ttl. source may be nil.
+func NewCache(dir string, ttl time.Duration, source trust.HTTPSGetter) (*Cache, error) {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create collateral cache directory: %w", err)
+	}
+	return &Cache{
+		dir:    dir,
+		ttl:    ttl,
+		source: source,
+		now:    time.Now,
+	}, nil
+}
+
+// Get serves the collateral at rawURL from the cache, fetching it from the source if it is missing or expired.
+func (c *Cache) Get(rawURL string) (map[string][]string, []byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.load(rawURL)
+	if err != nil {
+		log.Printf("[Warning] Ignoring cached collateral of %s: %v", rawURL, err)
+		entry = nil
+	}
+	now := c.now()
+	if entry != nil && now.Before(entry.ExpiresAt) {
+		return entry.Header, entry.Body, nil
+	}
+
+	if c.source == nil {
+		if entry == nil {
+			return nil, nil, fmt.Errorf("%w: %s", ErrNotCached, rawURL)
+		}
+		log.Printf("[Warning] Serving collateral of %s expired at %v", rawURL, entry.ExpiresAt)
+		return entry.Header, entry.Body, nil
+	}
+
+	header, body, err := c.source.Get(rawURL)
+	if err != nil {
+		if entry == nil {
+			return nil, nil, err
+		}
+		log.Printf("[Warning] Failed to refresh collateral of %s, serving the copy expired at %v: %v", rawURL, entry.ExpiresAt, err)
+		return entry.Header, entry.Body, nil
+	}
+
+	entry = &cacheEntry{
+		URL:       rawURL,
+		Header:    header,
+		Body:      body,
+		FetchedAt: now,
+		ExpiresAt: now.Add(c.ttl),
+	}
+	if nextUpdate, ok := nextUpdate(body); ok && nextUpdate.Before(entry.ExpiresAt) {
+		entry.ExpiresAt = nextUpdate
+	}
+	if err := c.store(entry); err != nil {
+		log.Printf("[Warning] Failed to cache collateral of %s: %v", rawURL, err)
+	}
+	return header, body, nil
+}
+
+// path returns the file of the entry of a URL.
+func (c *Cache) path(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load reads the entry of a URL, or returns nil if there is none.
+func (c *Cache) load(rawURL string) (*cacheEntry, error) {
+	data, err := os.ReadFile(c.path(rawURL))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	if entry.URL != rawURL {
+		return nil, fmt.Errorf("entry is for %s", entry.URL)
+	}
+	return &entry, nil
+}
+
+// store writes an entry, replacing the previous one atomically.
+func (c *Cache) store(entry *cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(entry.URL))
+}
+
+// nextUpdate returns when the issuer of the collateral publishes its next version, for TCB info, QE identities and
+// CRLs.
+func nextUpdate(body []byte) (time.Time, bool) {
+	var signed struct {
+		TcbInfo         *struct{ NextUpdate time.Time } `json:"tcbInfo"`
+		EnclaveIdentity *struct{ NextUpdate time.Time } `json:"enclaveIdentity"`
+	}
+	if err := json.Unmarshal(body, &signed); err == nil {
+		switch {
+		case signed.TcbInfo != nil && !signed.TcbInfo.NextUpdate.IsZero():
+			return signed.TcbInfo.NextUpdate, true
+		case signed.EnclaveIdentity != nil && !signed.EnclaveIdentity.NextUpdate.IsZero():
+			return signed.EnclaveIdentity.NextUpdate, true
+		}
+		return time.Time{}, false
+	}
+
+	if crl, err := x509.ParseRevocationList(body); err == nil && !crl.NextUpdate.IsZero() {
+		return crl.NextUpdate, true
+	}
+	return time.Time{}, false
+}
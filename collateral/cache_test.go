@@ -0,0 +1,103 @@
+package collateral
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-tdx-guest/pcs"
+)
+
+func TestCache(t *testing.T) {
+	pccs := newTestPCCS(t)
+	dir := t.TempDir()
+	cache, err := NewCache(dir, 30*24*time.Hour, NewPCCSClient(pccs.URL))
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+	now := sampleQuoteTime
+	cache.now = func() time.Time { return now }
+
+	tcbInfoURL := pcs.TcbInfoURL("50806f000000")
+	const tcbInfoPath = "/tdx/certification/v4/tcb"
+	get := func(wantRequests int) {
+		t.Helper()
+		header, body, err := cache.Get(tcbInfoURL)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if len(body) == 0 || len(header[pcs.TcbInfoIssuerChainPhrase]) != 1 {
+			t.Errorf("Get returned an incomplete response")
+		}
+		if got := pccs.requestCount(tcbInfoPath); got != wantRequests {
+			t.Errorf("PCCS served %d requests, want %d", got, wantRequests)
+		}
+	}
+
+	get(1)
+	get(1)
+
+	// The TCB info is next updated on 2023-07-18, before the TTL elapses
+	now = time.Date(2023, time.July, 18, 9, 0, 0, 0, time.UTC)
+	get(2)
+
+	// Expired collateral is served while the PCCS is down
+	now = now.Add(31 * 24 * time.Hour)
+	pccs.setDown(true)
+	get(3)
+	if _, _, err := cache.Get(pcs.QeIdentityURL()); err == nil {
+		t.Error("Get of uncached collateral succeeded while the PCCS is down")
+	}
+
+	// A cache without source serves what is on disk
+	offline, err := NewCache(dir, time.Hour, nil)
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+	if _, _, err := offline.Get(tcbInfoURL); err != nil {
+		t.Errorf("offline Get failed: %v", err)
+	}
+	if _, _, err := offline.Get(pcs.QeIdentityURL()); !errors.Is(err, ErrNotCached) {
+		t.Errorf("offline Get of uncached collateral = %v, want ErrNotCached", err)
+	}
+
+	// Corrupt entries are fetched again
+	pccs.setDown(false)
+	if err := os.WriteFile(cache.path(tcbInfoURL), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	get(4)
+}
+
+func TestCacheVerifiesQuote(t *testing.T) {
+	pccs := newTestPCCS(t)
+	cache, err := NewCache(t.TempDir(), time.Hour, NewPCCSClient(pccs.URL))
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+	cache.now = func() time.Time { return sampleQuoteTime }
+	quote := sampleQuote(t)
+
+	checkCollateralAccepted(t, quote, cache)
+	// The second verification is served from disk
+	pccs.setDown(true)
+	checkCollateralAccepted(t, quote, cache)
+}
+
+func TestNewSource(t *testing.T) {
+	source, err := NewSource(Config{})
+	if err != nil || source != nil {
+		t.Errorf("NewSource without configuration = %v, %v, want nil", source, err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "collateral")
+	source, err = NewSource(Config{PCCSURL: "https://localhost:8081", CacheDir: dir})
+	if err != nil || source == nil {
+		t.Fatalf("NewSource failed: %v", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("cache directory not created: %v", err)
+	}
+}
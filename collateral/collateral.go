@@ -0,0 +1,225 @@
+package collateral
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/google/go-tdx-guest/pcs"
+	"github.com/google/go-tdx-guest/verify/trust"
+
+	tdxpb "github.com/google/go-tdx-guest/proto/tdx"
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+// Issuer CAs of PCK certificates, as named by the PCS API.
+const (
+	CaPlatform  = "platform"
+	CaProcessor = "processor"
+)
+
+var (
+	// ErrNoPckCertificate is returned when a quote does not embed a PCK certificate with SGX extensions.
+	ErrNoPckCertificate = errors.New("no PCK certificate in quote")
+	// ErrNotInBundle is returned by Bundle.Get for URLs the bundle has no response for.
+	ErrNotInBundle = errors.New("collateral not in bundle")
+)
+
+// Bundle holds the collateral needed to verify the quotes of a platform. Issuer chains are PEM encoded, CRLs are DER
+// encoded.
+type Bundle struct {
+	Fmspc                 string // Hex encoded FMSPC of the platform
+	Ca                    string // CaPlatform or CaProcessor
+	TcbInfo               []byte // Signed TCB info JSON
+	TcbInfoIssuerChain    []byte
+	QeIdentity            []byte // Signed QE identity JSON
+	QeIdentityIssuerChain []byte
+	PckCrl                []byte
+	PckCrlIssuerChain     []byte
+	RootCaCrl             []byte
+}
+
+// Fetch gets the collateral of a platform from the getter, addressed by the URLs of the Intel PCS.
+func Fetch(getter trust.HTTPSGetter, fmspc, ca string) (*Bundle, error) {
+	bundle := &Bundle{Fmspc: fmspc, Ca: ca}
+
+	var err error
+	if bundle.TcbInfo, bundle.TcbInfoIssuerChain, err = getWithIssuerChain(getter, pcs.TcbInfoURL(fmspc), pcs.TcbInfoIssuerChainPhrase); err != nil {
+		return nil, fmt.Errorf("failed to get TCB info: %w", err)
+	}
+	if bundle.QeIdentity, bundle.QeIdentityIssuerChain, err = getWithIssuerChain(getter, pcs.QeIdentityURL(), pcs.SgxQeIdentityIssuerChainPhrase); err != nil {
+		return nil, fmt.Errorf("failed to get QE identity: %w", err)
+	}
+	if bundle.PckCrl, bundle.PckCrlIssuerChain, err = getWithIssuerChain(getter, pcs.PckCrlURL(ca), pcs.SgxPckCrlIssuerChainPhrase); err != nil {
+		return nil, fmt.Errorf("failed to get PCK CRL: %w", err)
+	}
+
+	// The root CA CRL is published at the distribution points of the root certificate
+	rootCrlURLs, err := rootCrlURLs(bundle.QeIdentityIssuerChain)
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, rootCrlURL := range rootCrlURLs {
+		_, body, err := getter.Get(rootCrlURL)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		bundle.RootCaCrl = body
+		return bundle, nil
+	}
+	return nil, fmt.Errorf("failed to get root CA CRL: %w", errors.Join(errs...))
+}
+
+// ForQuote gets the collateral of the platform that produced the quote. The FMSPC and the CA are read from the PCK
+// certificate embedded in the quote.
+func ForQuote(getter trust.HTTPSGetter, quote *tdxpb.QuoteV4) (*Bundle, error) {
+	fmspc, ca, err := QuotePlatform(quote)
+	if err != nil {
+		return nil, err
+	}
+	return Fetch(getter, fmspc, ca)
+}
+
+// QuotePlatform returns the FMSPC and the issuer CA of the PCK certificate embedded in the quote.
+func QuotePlatform(quote *tdxpb.QuoteV4) (string, string, error) {
+	chain := quote.GetSignedData().GetCertificationData().GetQeReportCertificationData().GetPckCertificateChainData().GetPckCertChain()
+	block, _ := pem.Decode(chain)
+	if block == nil {
+		return "", "", ErrNoPckCertificate
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrNoPckCertificate, err)
+	}
+	ext, err := pcs.PckCertificateExtensions(cert)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrNoPckCertificate, err)
+	}
+
+	switch cert.Issuer.CommonName {
+	case "Intel SGX PCK Platform CA":
+		return ext.FMSPC, CaPlatform, nil
+	case "Intel SGX PCK Processor CA":
+		return ext.FMSPC, CaProcessor, nil
+	default:
+		return "", "", fmt.Errorf("%w: unknown PCK issuer %q", ErrNoPckCertificate, cert.Issuer.CommonName)
+	}
+}
+
+// Get serves the collateral of the bundle at the URLs of the Intel PCS, so that quotes can be verified offline.
+func (b *Bundle) Get(rawURL string) (map[string][]string, []byte, error) {
+	switch rawURL {
+	case pcs.TcbInfoURL(b.Fmspc):
+		return issuerChainHeader(pcs.TcbInfoIssuerChainPhrase, b.TcbInfoIssuerChain), b.TcbInfo, nil
+	case pcs.QeIdentityURL():
+		return issuerChainHeader(pcs.SgxQeIdentityIssuerChainPhrase, b.QeIdentityIssuerChain), b.QeIdentity, nil
+	case pcs.PckCrlURL(b.Ca):
+		return issuerChainHeader(pcs.SgxPckCrlIssuerChainPhrase, b.PckCrlIssuerChain), b.PckCrl, nil
+	}
+
+	rootCrlURLs, err := rootCrlURLs(b.QeIdentityIssuerChain)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, rootCrlURL := range rootCrlURLs {
+		if rawURL == rootCrlURL {
+			return nil, b.RootCaCrl, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: %s", ErrNotInBundle, rawURL)
+}
+
+// getWithIssuerChain gets a response and the PEM issuer chain from its header.
+func getWithIssuerChain(getter trust.HTTPSGetter, rawURL, phrase string) ([]byte, []byte, error) {
+	header, body, err := getter.Get(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	chain, err := issuerChain(header, phrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, chain, nil
+}
+
+// issuerChain decodes the PEM issuer chain of a PCS response header.
+func issuerChain(header map[string][]string, phrase string) ([]byte, error) {
+	values := header[phrase]
+	if len(values) != 1 || values[0] == "" {
+		return nil, fmt.Errorf("missing %s header", phrase)
+	}
+	chain, err := url.QueryUnescape(values[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s header: %v", phrase, err)
+	}
+	return []byte(chain), nil
+}
+
+// issuerChainHeader encodes a PEM issuer chain as the header of a PCS response.
+func issuerChainHeader(phrase string, chain []byte) map[string][]string {
+	return map[string][]string{phrase: {url.QueryEscape(string(chain))}}
+}
+
+// rootCrlURLs returns the CRL distribution points of the root certificate at the end of a PEM issuer chain.
+func rootCrlURLs(chain []byte) ([]string, error) {
+	var root *x509.Certificate
+	for rest := chain; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid QE identity issuer chain: %v", err)
+		}
+		root = cert
+	}
+	if root == nil {
+		return nil, errors.New("invalid QE identity issuer chain: no certificate")
+	}
+	if len(root.CRLDistributionPoints) == 0 {
+		return nil, errors.New("root certificate has no CRL distribution point")
+	}
+	return root.CRLDistributionPoints, nil
+}
+
+// ConvertBundleToProtobuf converts a Bundle to a Collateral message.
+func ConvertBundleToProtobuf(bundle *Bundle) *attestpb.Collateral {
+	if bundle == nil {
+		return nil
+	}
+	return &attestpb.Collateral{
+		Fmspc:                 bundle.Fmspc,
+		Ca:                    bundle.Ca,
+		TcbInfo:               bundle.TcbInfo,
+		TcbInfoIssuerChain:    bundle.TcbInfoIssuerChain,
+		QeIdentity:            bundle.QeIdentity,
+		QeIdentityIssuerChain: bundle.QeIdentityIssuerChain,
+		PckCrl:                bundle.PckCrl,
+		PckCrlIssuerChain:     bundle.PckCrlIssuerChain,
+		RootCaCrl:             bundle.RootCaCrl,
+	}
+}
+
+// ConvertProtobufToBundle converts a Collateral message to a Bundle.
+func ConvertProtobufToBundle(collateral *attestpb.Collateral) *Bundle {
+	if collateral == nil {
+		return nil
+	}
+	return &Bundle{
+		Fmspc:                 collateral.GetFmspc(),
+		Ca:                    collateral.GetCa(),
+		TcbInfo:               collateral.GetTcbInfo(),
+		TcbInfoIssuerChain:    collateral.GetTcbInfoIssuerChain(),
+		QeIdentity:            collateral.GetQeIdentity(),
+		QeIdentityIssuerChain: collateral.GetQeIdentityIssuerChain(),
+		PckCrl:                collateral.GetPckCrl(),
+		PckCrlIssuerChain:     collateral.GetPckCrlIssuerChain(),
+		RootCaCrl:             collateral.GetRootCaCrl(),
+	}
+}
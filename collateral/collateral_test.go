@@ -0,0 +1,84 @@
+package collateral
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-tdx-guest/verify"
+	"github.com/google/go-tdx-guest/verify/trust"
+	"google.golang.org/protobuf/proto"
+
+	tdxpb "github.com/google/go-tdx-guest/proto/tdx"
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+// errSampleTcbStatus is what go-tdx-guest reports when fully verifying its sample quote, whose TCB is older than every
+// level of the sample TCB info. The TCB status is checked after the signatures, the validity and the revocation of
+// the collateral, so reaching it shows the collateral was accepted.
+const errSampleTcbStatus = "TDX TCB info reported by Intel PCS failed TCB status check: no matching TCB level found"
+
+// verifyWithCollateral fully verifies the quote against the collateral served by the getter.
+func verifyWithCollateral(quote *tdxpb.QuoteV4, getter trust.HTTPSGetter) error {
+	return verify.TdxQuote(quote, &verify.Options{
+		GetCollateral:    true,
+		CheckRevocations: true,
+		Getter:           getter,
+		Now:              sampleQuoteTime,
+	})
+}
+
+// checkCollateralAccepted verifies the sample quote against the collateral served by the getter.
+func checkCollateralAccepted(t *testing.T, quote *tdxpb.QuoteV4, getter trust.HTTPSGetter) {
+	t.Helper()
+	if err := verifyWithCollateral(quote, getter); err == nil || err.Error() != errSampleTcbStatus {
+		t.Errorf("verification = %v, want the collateral accepted and %q", err, errSampleTcbStatus)
+	}
+}
+
+func TestForQuote(t *testing.T) {
+	pccs := newTestPCCS(t)
+	client := NewPCCSClient(pccs.URL)
+	quote := sampleQuote(t)
+
+	// The PCCS client serves go-tdx-guest directly
+	checkCollateralAccepted(t, quote, client)
+
+	bundle, err := ForQuote(client, quote)
+	if err != nil {
+		t.Fatalf("ForQuote failed: %v", err)
+	}
+	if bundle.Fmspc != "50806f000000" || bundle.Ca != CaPlatform {
+		t.Errorf("bundle is for FMSPC %s and CA %s, want 50806f000000 and %s", bundle.Fmspc, bundle.Ca, CaPlatform)
+	}
+
+	// The bundle verifies the quote offline, also after a round trip through its protobuf encoding
+	pccs.setDown(true)
+	checkCollateralAccepted(t, quote, bundle)
+	message, err := proto.Marshal(ConvertBundleToProtobuf(bundle))
+	if err != nil {
+		t.Fatalf("failed to marshal collateral: %v", err)
+	}
+	decoded := &attestpb.Collateral{}
+	if err := proto.Unmarshal(message, decoded); err != nil {
+		t.Fatalf("failed to unmarshal collateral: %v", err)
+	}
+	checkCollateralAccepted(t, quote, ConvertProtobufToBundle(decoded))
+
+	// Collateral of another platform does not verify the quote
+	bundle.Fmspc = "000000000000"
+	if err := verifyWithCollateral(quote, bundle); err == nil || err.Error() == errSampleTcbStatus {
+		t.Error("verification against the collateral of another platform succeeded")
+	}
+	if _, _, err := bundle.Get("https://example.com/tcb"); !errors.Is(err, ErrNotInBundle) {
+		t.Errorf("Get of an unknown URL = %v, want ErrNotInBundle", err)
+	}
+}
+
+func TestQuotePlatformWithoutPckCertificate(t *testing.T) {
+	quote := sampleQuote(t)
+	quote.GetSignedData().GetCertificationData().GetQeReportCertificationData().GetPckCertificateChainData().PckCertChain = nil
+
+	if _, _, err := QuotePlatform(quote); !errors.Is(err, ErrNoPckCertificate) {
+		t.Errorf("QuotePlatform = %v, want ErrNoPckCertificate", err)
+	}
+}
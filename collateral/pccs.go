@@ -0,0 +1,182 @@
+package collateral
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-tdx-guest/pcs"
+	"github.com/google/go-tdx-guest/verify/trust"
+)
+
+// DefaultPCCSTimeout bounds a request to the PCCS.
+const DefaultPCCSTimeout = 30 * time.Second
+
+// Hosts of the Intel PCS API and of the Intel root CA CRL, which the PCCS client redirects to the PCCS.
+const (
+	pcsURLPrefix       = "https://api.trustedservices.intel.com/"
+	intelCertURLPrefix = "https://certificates.trustedservices.intel.com/"
+	pccsRootCaCrlPath  = "/sgx/certification/v4/rootcacrl"
+)
+
+// Headers of PCS responses, named as the PCS documents them.
+const (
+	PckCertificateIssuerChainPhrase = "SGX-PCK-Certificate-Issuer-Chain"
+	PckCertificateTcbmPhrase        = "SGX-TCBm"
+	PckCertificateFmspcPhrase       = "SGX-FMSPC"
+	PckCertificateCaTypePhrase      = "SGX-PCK-Certificate-CA-Type"
+)
+
+// responseHeaders maps the header names expected by go-tdx-guest to the names a PCCS may answer with. HTTP header
+// names are case insensitive, but go-tdx-guest looks them up with their exact spelling.
+var responseHeaders = map[string][]string{
+	pcs.TcbInfoIssuerChainPhrase:       {pcs.TcbInfoIssuerChainPhrase, "SGX-TCB-Info-Issuer-Chain"},
+	pcs.SgxQeIdentityIssuerChainPhrase: {pcs.SgxQeIdentityIssuerChainPhrase, "Enclave-Identity-Issuer-Chain"},
+	pcs.SgxPckCrlIssuerChainPhrase:     {pcs.SgxPckCrlIssuerChainPhrase},
+	PckCertificateIssuerChainPhrase:    {PckCertificateIssuerChainPhrase},
+	PckCertificateTcbmPhrase:           {PckCertificateTcbmPhrase},
+	PckCertificateFmspcPhrase:          {PckCertificateFmspcPhrase},
+	PckCertificateCaTypePhrase:         {PckCertificateCaTypePhrase},
+}
+
+// PCCSClient gets collateral from a PCCS, or any server implementing the v4 PCS API. It implements trust.HTTPSGetter
+// for the URLs of the Intel PCS, which it redirects to the PCCS.
+type PCCSClient struct {
+	BaseURL    string       // Base URL of the PCCS, e.g. https://localhost:8081
+	HTTPClient *http.Client // Client for the requests; nil uses a client with DefaultPCCSTimeout
+}
+
+// NewPCCSClient creates a PCCSClient for the PCCS at baseURL.
+func NewPCCSClient(baseURL string) *PCCSClient {
+	return &PCCSClient{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: DefaultPCCSTimeout},
+	}
+}
+
+// Get fetches the collateral the Intel PCS serves at rawURL from the PCCS.
+func (c *PCCSClient) Get(rawURL string) (map[string][]string, []byte, error) {
+	pccsURL, err := c.pccsURL(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultPCCSTimeout}
+	}
+	resp, err := httpClient.Get(pccsURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get %s: %w", pccsURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response of %s: %w", pccsURL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("failed to get %s: status %d: %s", pccsURL, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	// Older PCCS versions serve the root CA CRL hex encoded
+	if strings.HasPrefix(rawURL, intelCertURLPrefix) {
+		if der, err := hex.DecodeString(strings.TrimSpace(string(body))); err == nil {
+			body = der
+		}
+	}
+	return normalizeHeader(resp.Header), body, nil
+}
+
+// pccsURL maps a URL of the Intel PCS to the same resource on the PCCS.
+func (c *PCCSClient) pccsURL(rawURL string) (string, error) {
+	switch {
+	case strings.HasPrefix(rawURL, pcsURLPrefix):
+		return c.BaseURL + "/" + strings.TrimPrefix(rawURL, pcsURLPrefix), nil
+	case strings.HasPrefix(rawURL, intelCertURLPrefix) && strings.HasSuffix(rawURL, ".der"):
+		return c.BaseURL + pccsRootCaCrlPath, nil
+	default:
+		return "", fmt.Errorf("no PCCS endpoint for %s", rawURL)
+	}
+}
+
+// normalizeHeader copies the header, adding the PCS headers under the exact names go-tdx-guest expects.
+func normalizeHeader(header http.Header) map[string][]string {
+	normalized := make(map[string][]string, len(header))
+	for name, values := range header {
+		normalized[name] = values
+	}
+	for phrase, aliases := range responseHeaders {
+		for _, alias := range aliases {
+			if values := header.Values(alias); len(values) > 0 {
+				normalized[phrase] = values
+				break
+			}
+		}
+	}
+	return normalized
+}
+
+// PckCertificateRequest identifies the PCK certificate of a platform at a TCB level. Fields are hex encoded, as in the
+// PCK certificate ID data of a quote.
+type PckCertificateRequest struct {
+	EncryptedPPID string
+	PceID         string
+	CPUSVN        string
+	PCESVN        string
+	QeID          string
+}
+
+// PckCertificate is a PCK certificate served by the PCS API.
+type PckCertificate struct {
+	Certificate []byte // PEM encoded PCK certificate
+	IssuerChain []byte // PEM encoded chain of the PCK CA and the root CA
+	Tcbm        string // Hex encoded CPUSVN and PCESVN of the TCB level the certificate was issued for
+	Fmspc       string // Hex encoded FMSPC of the platform
+	Ca          string // CaPlatform or CaProcessor
+}
+
+// PckCertificateURL returns the URL of the Intel PCS serving the PCK certificate. The QE ID is only used by PCCS.
+func PckCertificateURL(req PckCertificateRequest) string {
+	query := url.Values{}
+	query.Set("encrypted_ppid", req.EncryptedPPID)
+	query.Set("pceid", req.PceID)
+	query.Set("cpusvn", req.CPUSVN)
+	query.Set("pcesvn", req.PCESVN)
+	if req.QeID != "" {
+		query.Set("qeid", req.QeID)
+	}
+	return pcsURLPrefix + "sgx/certification/v4/pckcert?" + query.Encode()
+}
+
+// FetchPckCertificate gets a PCK certificate from the getter, e.g. a PCCSClient or a Cache in front of one.
+func FetchPckCertificate(getter trust.HTTPSGetter, req PckCertificateRequest) (*PckCertificate, error) {
+	header, body, err := getter.Get(PckCertificateURL(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PCK certificate: %w", err)
+	}
+	chain, err := issuerChain(header, PckCertificateIssuerChainPhrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PCK certificate: %w", err)
+	}
+
+	cert := &PckCertificate{
+		Certificate: body,
+		IssuerChain: chain,
+		Tcbm:        firstValue(header, PckCertificateTcbmPhrase),
+		Fmspc:       firstValue(header, PckCertificateFmspcPhrase),
+		Ca:          strings.ToLower(firstValue(header, PckCertificateCaTypePhrase)),
+	}
+	return cert, nil
+}
+
+func firstValue(header map[string][]string, name string) string {
+	if values := header[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+package collateral
+
+import (
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-tdx-guest/abi"
+	"github.com/google/go-tdx-guest/pcs"
+	"github.com/google/go-tdx-guest/testing/testdata"
+
+	tdxpb "github.com/google/go-tdx-guest/proto/tdx"
+	tdxtesting "github.com/google/go-tdx-guest/testing"
+)
+
+// sampleQuoteTime is a time at which the sample quote and its collateral are valid.
+var sampleQuoteTime = time.Date(2023, time.July, 1, 1, 0, 0, 0, time.UTC)
+
+// testPCCS is a PCCS stand-in serving the collateral of the sample quote of go-tdx-guest.
+type testPCCS struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	requests   map[string]int // Number of requests per path
+	down       bool           // Answers every request with an error
+	hexRootCrl bool           // Serves the root CA CRL hex encoded
+}
+
+func newTestPCCS(t *testing.T) *testPCCS {
+	t.Helper()
+	p := &testPCCS{requests: make(map[string]int)}
+	p.Server = httptest.NewServer(http.HandlerFunc(p.serve))
+	t.Cleanup(p.Close)
+	return p
+}
+
+func (p *testPCCS) serve(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	p.requests[r.URL.Path]++
+	down, hexRootCrl := p.down, p.hexRootCrl
+	p.mu.Unlock()
+	if down {
+		http.Error(w, "PCCS is down", http.StatusServiceUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+	switch {
+	case r.URL.Path == "/tdx/certification/v4/tcb" && query.Get("fmspc") == "50806f000000":
+		w.Header().Set(pcs.TcbInfoIssuerChainPhrase, tdxtesting.TcbInfoHeader[pcs.TcbInfoIssuerChainPhrase][0])
+		w.Write(testdata.TcbInfoBody)
+	case r.URL.Path == "/tdx/certification/v4/qe/identity":
+		w.Header().Set(pcs.SgxQeIdentityIssuerChainPhrase, tdxtesting.QeIdentityHeader[pcs.SgxQeIdentityIssuerChainPhrase][0])
+		w.Write(testdata.QeIdentityBody)
+	case r.URL.Path == "/sgx/certification/v4/pckcrl" && query.Get("ca") == CaPlatform:
+		w.Header().Set(pcs.SgxPckCrlIssuerChainPhrase, tdxtesting.PckCrlHeader[pcs.SgxPckCrlIssuerChainPhrase][0])
+		w.Write(testdata.PckCrlBody)
+	case r.URL.Path == pccsRootCaCrlPath:
+		if hexRootCrl {
+			w.Write([]byte(hex.EncodeToString(testdata.RootCrlBody)))
+		} else {
+			w.Write(testdata.RootCrlBody)
+		}
+	case r.URL.Path == "/sgx/certification/v4/pckcert" && query.Get("qeid") != "":
+		leaf, chain := samplePckChain()
+		w.Header().Set(PckCertificateIssuerChainPhrase, url.QueryEscape(string(chain)))
+		w.Header().Set(PckCertificateTcbmPhrase, query.Get("cpusvn")+query.Get("pcesvn"))
+		w.Header().Set(PckCertificateFmspcPhrase, "50806F000000")
+		w.Header().Set(PckCertificateCaTypePhrase, "Platform")
+		w.Write(leaf)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (p *testPCCS) requestCount(path string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.requests[path]
+}
+
+func (p *testPCCS) setDown(down bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.down = down
+}
+
+// sampleQuote parses the sample quote of go-tdx-guest.
+func sampleQuote(t *testing.T) *tdxpb.QuoteV4 {
+	t.Helper()
+	quote, err := abi.QuoteToProto(testdata.RawQuote)
+	if err != nil {
+		t.Fatalf("failed to parse sample quote: %v", err)
+	}
+	return quote.(*tdxpb.QuoteV4)
+}
+
+// samplePckChain returns the PEM PCK certificate of the sample quote and its issuer chain.
+func samplePckChain() ([]byte, []byte) {
+	quote, _ := abi.QuoteToProto(testdata.RawQuote)
+	chain := quote.(*tdxpb.QuoteV4).GetSignedData().GetCertificationData().GetQeReportCertificationData().GetPckCertificateChainData().GetPckCertChain()
+	block, rest := pem.Decode(chain)
+	return pem.EncodeToMemory(block), rest
+}
+
+func TestPCCSClient(t *testing.T) {
+	pccs := newTestPCCS(t)
+	pccs.hexRootCrl = true
+	client := NewPCCSClient(pccs.URL + "/")
+
+	// Headers are served under the names go-tdx-guest looks up, whatever their case on the wire
+	header, _, err := client.Get(pcs.TcbInfoURL("50806f000000"))
+	if err != nil {
+		t.Fatalf("Get of TCB info failed: %v", err)
+	}
+	if len(header[pcs.TcbInfoIssuerChainPhrase]) != 1 {
+		t.Errorf("header %v has no %s", header, pcs.TcbInfoIssuerChainPhrase)
+	}
+
+	// The Intel root CA CRL is served by the PCCS, and decoded if hex encoded
+	_, body, err := client.Get("https://certificates.trustedservices.intel.com/IntelSGXRootCA.der")
+	if err != nil {
+		t.Fatalf("Get of root CA CRL failed: %v", err)
+	}
+	if string(body) != string(testdata.RootCrlBody) {
+		t.Error("root CA CRL not decoded")
+	}
+
+	if _, _, err := client.Get(pcs.TcbInfoURL("000000000000")); err == nil {
+		t.Error("Get of unknown TCB info succeeded")
+	}
+	if _, _, err := client.Get("https://example.com/tcb"); err == nil {
+		t.Error("Get of a URL outside the PCS succeeded")
+	}
+}
+
+func TestFetchPckCertificate(t *testing.T) {
+	pccs := newTestPCCS(t)
+
+	cert, err := FetchPckCertificate(NewPCCSClient(pccs.URL), PckCertificateRequest{
+		EncryptedPPID: "00",
+		PceID:         "0000",
+		CPUSVN:        "0b0b0c0c0c0c00000000000000000000",
+		PCESVN:        "0d00",
+		QeID:          "0102",
+	})
+	if err != nil {
+		t.Fatalf("FetchPckCertificate failed: %v", err)
+	}
+	leaf, chain := samplePckChain()
+	if string(cert.Certificate) != string(leaf) || string(cert.IssuerChain) != string(chain) {
+		t.Error("PCK certificate or issuer chain differ from the served ones")
+	}
+	if cert.Fmspc != "50806F000000" || cert.Ca != CaPlatform || cert.Tcbm != "0b0b0c0c0c0c000000000000000000000d00" {
+		t.Errorf("PckCertificate = {Fmspc: %s, Ca: %s, Tcbm: %s}", cert.Fmspc, cert.Ca, cert.Tcbm)
+	}
+}
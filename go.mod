@@ -3,20 +3,20 @@ module github.com/radiusxyz/lightbulb-tdx
 go 1.23.4
 
 require (
+	github.com/google/go-configfs-tsm v0.3.2
 	github.com/google/go-tdx-guest v0.3.2-0.20250121170950-fcf4511ed94b
 	github.com/joho/godotenv v1.5.1
+	golang.org/x/sys v0.29.0
 	google.golang.org/grpc v1.69.4
 	google.golang.org/protobuf v1.36.3
 	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	github.com/google/go-configfs-tsm v0.3.2 // indirect
 	github.com/google/logger v1.1.1 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/crypto v0.32.0 // indirect
 	golang.org/x/net v0.34.0 // indirect
-	golang.org/x/sys v0.29.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250122153221-138b5a5a4fd4 // indirect
 )
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdvf"
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+)
+
+// Offline MRTD calculator. It replays the measurement of the TDVF firmware at TDVF_FIRMWARE_PATH and prints the
+// expected MRTD. If MRTD_REFERENCE_PATH is set, the MRTD is added to the mr_td values of the policy file at that path,
+// which is created if it does not exist, so that it can be used as SELF_ATTEST_POLICY_PATH or by relying parties.
+func main() {
+	// Load environment variables, the .env file is optional
+	if err := godotenv.Load(); err != nil {
+		log.Printf("[Info] No .env file loaded: %v", err)
+	}
+
+	firmwarePath := os.Getenv("TDVF_FIRMWARE_PATH")
+	if firmwarePath == "" {
+		log.Fatalf("TDVF_FIRMWARE_PATH is not set")
+	}
+	image, err := tdvf.LoadImage(firmwarePath)
+	if err != nil {
+		log.Fatalf("Failed to load firmware: %v", err)
+	}
+	for i, section := range image.Sections {
+		log.Printf("Section %d: %s", i, section)
+	}
+	mrtd := image.Mrtd()
+	fmt.Println(hex.EncodeToString(mrtd))
+
+	referencePath := os.Getenv("MRTD_REFERENCE_PATH")
+	if referencePath == "" {
+		return
+	}
+	var base *verifier.Policy
+	if _, err := os.Stat(referencePath); err == nil {
+		if base, err = verifier.LoadPolicy(referencePath); err != nil {
+			log.Fatalf("Failed to load reference values: %v", err)
+		}
+	} else if !os.IsNotExist(err) {
+		log.Fatalf("Failed to read reference values: %v", err)
+	}
+	policy, err := tdvf.ReferencePolicy(base, mrtd)
+	if err != nil {
+		log.Fatalf("Failed to build reference values: %v", err)
+	}
+	data, err := policy.Marshal()
+	if err != nil {
+		log.Fatalf("Failed to encode reference values: %v", err)
+	}
+	if err := os.WriteFile(referencePath, data, 0o644); err != nil {
+		log.Fatalf("Failed to write reference values: %v", err)
+	}
+	log.Printf("Wrote the reference values to %s", referencePath)
+}
@@ -20,28 +20,1398 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SelfAttestationState is the outcome of the check of the server's own quote
+// at startup.
+type SelfAttestationState int32
+
+const (
+	SelfAttestationState_SELF_ATTESTATION_STATE_UNSPECIFIED SelfAttestationState = 0
+	// Not checked, e.g. outside of a TD.
+	SelfAttestationState_SELF_ATTESTATION_STATE_SKIPPED SelfAttestationState = 1
+	// The quote verified and satisfied the startup policy.
+	SelfAttestationState_SELF_ATTESTATION_STATE_PASSED SelfAttestationState = 2
+	// The quote did not verify or violated the startup policy.
+	SelfAttestationState_SELF_ATTESTATION_STATE_FAILED SelfAttestationState = 3
+)
+
+// Enum value maps for SelfAttestationState.
+var (
+	SelfAttestationState_name = map[int32]string{
+		0: "SELF_ATTESTATION_STATE_UNSPECIFIED",
+		1: "SELF_ATTESTATION_STATE_SKIPPED",
+		2: "SELF_ATTESTATION_STATE_PASSED",
+		3: "SELF_ATTESTATION_STATE_FAILED",
+	}
+	SelfAttestationState_value = map[string]int32{
+		"SELF_ATTESTATION_STATE_UNSPECIFIED": 0,
+		"SELF_ATTESTATION_STATE_SKIPPED":     1,
+		"SELF_ATTESTATION_STATE_PASSED":      2,
+		"SELF_ATTESTATION_STATE_FAILED":      3,
+	}
+)
+
+func (x SelfAttestationState) Enum() *SelfAttestationState {
+	p := new(SelfAttestationState)
+	*p = x
+	return p
+}
+
+func (x SelfAttestationState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SelfAttestationState) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_attest_attest_proto_enumTypes[0].Descriptor()
+}
+
+func (SelfAttestationState) Type() protoreflect.EnumType {
+	return &file_proto_attest_attest_proto_enumTypes[0]
+}
+
+func (x SelfAttestationState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SelfAttestationState.Descriptor instead.
+func (SelfAttestationState) EnumDescriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{0}
+}
+
+// LaunchConfigBinding identifies where the digest of the launch configuration
+// is measured.
+type LaunchConfigBinding int32
+
+const (
+	LaunchConfigBinding_LAUNCH_CONFIG_BINDING_UNSPECIFIED LaunchConfigBinding = 0
+	// MRCONFIGID was set to the digest when the TD was created.
+	LaunchConfigBinding_LAUNCH_CONFIG_BINDING_MR_CONFIG_ID LaunchConfigBinding = 1
+	// The document was extended into an RTMR as a launch_config event.
+	LaunchConfigBinding_LAUNCH_CONFIG_BINDING_RTMR LaunchConfigBinding = 2
+)
+
+// Enum value maps for LaunchConfigBinding.
+var (
+	LaunchConfigBinding_name = map[int32]string{
+		0: "LAUNCH_CONFIG_BINDING_UNSPECIFIED",
+		1: "LAUNCH_CONFIG_BINDING_MR_CONFIG_ID",
+		2: "LAUNCH_CONFIG_BINDING_RTMR",
+	}
+	LaunchConfigBinding_value = map[string]int32{
+		"LAUNCH_CONFIG_BINDING_UNSPECIFIED":  0,
+		"LAUNCH_CONFIG_BINDING_MR_CONFIG_ID": 1,
+		"LAUNCH_CONFIG_BINDING_RTMR":         2,
+	}
+)
+
+func (x LaunchConfigBinding) Enum() *LaunchConfigBinding {
+	p := new(LaunchConfigBinding)
+	*p = x
+	return p
+}
+
+func (x LaunchConfigBinding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LaunchConfigBinding) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_attest_attest_proto_enumTypes[1].Descriptor()
+}
+
+func (LaunchConfigBinding) Type() protoreflect.EnumType {
+	return &file_proto_attest_attest_proto_enumTypes[1]
+}
+
+func (x LaunchConfigBinding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LaunchConfigBinding.Descriptor instead.
+func (LaunchConfigBinding) EnumDescriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{1}
+}
+
+// ReportDataScheme identifies how the REPORTDATA of a quote was composed.
+type ReportDataScheme int32
+
+const (
+	ReportDataScheme_REPORT_DATA_SCHEME_UNSPECIFIED ReportDataScheme = 0
+	// REPORTDATA is 64 zero bytes.
+	ReportDataScheme_REPORT_DATA_SCHEME_ZERO ReportDataScheme = 1
+	// REPORTDATA is the 48 byte RTMR digest followed by 16 zero bytes.
+	ReportDataScheme_REPORT_DATA_SCHEME_RTMR ReportDataScheme = 2
+	// REPORTDATA is SHA-512(user_data || rtmr_digest).
+	ReportDataScheme_REPORT_DATA_SCHEME_SHA512_USER_DATA_RTMR ReportDataScheme = 3
+	// REPORTDATA is SHA-512(challenge || user_data || rtmr_digest).
+	ReportDataScheme_REPORT_DATA_SCHEME_SHA512_CHALLENGE_USER_DATA_RTMR ReportDataScheme = 4
+)
+
+// Enum value maps for ReportDataScheme.
+var (
+	ReportDataScheme_name = map[int32]string{
+		0: "REPORT_DATA_SCHEME_UNSPECIFIED",
+		1: "REPORT_DATA_SCHEME_ZERO",
+		2: "REPORT_DATA_SCHEME_RTMR",
+		3: "REPORT_DATA_SCHEME_SHA512_USER_DATA_RTMR",
+		4: "REPORT_DATA_SCHEME_SHA512_CHALLENGE_USER_DATA_RTMR",
+	}
+	ReportDataScheme_value = map[string]int32{
+		"REPORT_DATA_SCHEME_UNSPECIFIED":                     0,
+		"REPORT_DATA_SCHEME_ZERO":                            1,
+		"REPORT_DATA_SCHEME_RTMR":                            2,
+		"REPORT_DATA_SCHEME_SHA512_USER_DATA_RTMR":           3,
+		"REPORT_DATA_SCHEME_SHA512_CHALLENGE_USER_DATA_RTMR": 4,
+	}
+)
+
+func (x ReportDataScheme) Enum() *ReportDataScheme {
+	p := new(ReportDataScheme)
+	*p = x
+	return p
+}
+
+func (x ReportDataScheme) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportDataScheme) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_attest_attest_proto_enumTypes[2].Descriptor()
+}
+
+func (ReportDataScheme) Type() protoreflect.EnumType {
+	return &file_proto_attest_attest_proto_enumTypes[2]
+}
+
+func (x ReportDataScheme) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportDataScheme.Descriptor instead.
+func (ReportDataScheme) EnumDescriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{2}
+}
+
+type GetChallengeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChallengeRequest) Reset() {
+	*x = GetChallengeRequest{}
+	mi := &file_proto_attest_attest_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChallengeRequest) ProtoMessage() {}
+
+func (x *GetChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChallengeRequest.ProtoReflect.Descriptor instead.
+func (*GetChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{0}
+}
+
+type GetChallengeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Random challenge to pass to GetQuote or GetRawQuote. It can be used once.
+	Challenge []byte `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"` // should be 32 bytes
+	// Expiry time of the challenge (Unix timestamp in milliseconds).
+	ExpiresAt     int64 `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChallengeResponse) Reset() {
+	*x = GetChallengeResponse{}
+	mi := &file_proto_attest_attest_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChallengeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChallengeResponse) ProtoMessage() {}
+
+func (x *GetChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChallengeResponse.ProtoReflect.Descriptor instead.
+func (*GetChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetChallengeResponse) GetChallenge() []byte {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+func (x *GetChallengeResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_proto_attest_attest_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{2}
+}
+
+type GetStatusResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SelfAttestation SelfAttestationState   `protobuf:"varint,1,opt,name=self_attestation,json=selfAttestation,proto3,enum=attest.SelfAttestationState" json:"self_attestation,omitempty"`
+	// When the self-attestation was checked, in Unix milliseconds.
+	CheckedAt int64 `protobuf:"varint,2,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	// Why the self-attestation failed, if it did.
+	FailureReason string   `protobuf:"bytes,3,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	Violations    []string `protobuf:"bytes,4,rep,name=violations,proto3" json:"violations,omitempty"`
+	// Whether the server runs degraded, with AuctionService disabled.
+	Degraded bool `protobuf:"varint,5,opt,name=degraded,proto3" json:"degraded,omitempty"`
+	// The quote checked at startup, if one was generated.
+	Quote *Quote `protobuf:"bytes,6,opt,name=quote,proto3" json:"quote,omitempty"`
+	// The launch configuration of the server.
+	LaunchConfig *LaunchConfig `protobuf:"bytes,7,opt,name=launch_config,json=launchConfig,proto3" json:"launch_config,omitempty"`
+	// Counters of the quote cache, unset if quotes are not cached.
+	QuoteCache    *QuoteCacheStats `protobuf:"bytes,8,opt,name=quote_cache,json=quoteCache,proto3" json:"quote_cache,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_proto_attest_attest_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetStatusResponse) GetSelfAttestation() SelfAttestationState {
+	if x != nil {
+		return x.SelfAttestation
+	}
+	return SelfAttestationState_SELF_ATTESTATION_STATE_UNSPECIFIED
+}
+
+func (x *GetStatusResponse) GetCheckedAt() int64 {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetViolations() []string {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetDegraded() bool {
+	if x != nil {
+		return x.Degraded
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetLaunchConfig() *LaunchConfig {
+	if x != nil {
+		return x.LaunchConfig
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetQuoteCache() *QuoteCacheStats {
+	if x != nil {
+		return x.QuoteCache
+	}
+	return nil
+}
+
+// QuoteCacheStats counts the quote requests served by the quote cache since
+// startup.
+type QuoteCacheStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Requests served from the cache.
+	Hits uint64 `protobuf:"varint,1,opt,name=hits,proto3" json:"hits,omitempty"`
+	// Requests that generated a quote.
+	Misses uint64 `protobuf:"varint,2,opt,name=misses,proto3" json:"misses,omitempty"`
+	// Requests that waited for an identical generation in flight.
+	Coalesced uint64 `protobuf:"varint,3,opt,name=coalesced,proto3" json:"coalesced,omitempty"`
+	// Quotes currently cached.
+	Entries       uint32 `protobuf:"varint,4,opt,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteCacheStats) Reset() {
+	*x = QuoteCacheStats{}
+	mi := &file_proto_attest_attest_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteCacheStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteCacheStats) ProtoMessage() {}
+
+func (x *QuoteCacheStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteCacheStats.ProtoReflect.Descriptor instead.
+func (*QuoteCacheStats) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *QuoteCacheStats) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *QuoteCacheStats) GetMisses() uint64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *QuoteCacheStats) GetCoalesced() uint64 {
+	if x != nil {
+		return x.Coalesced
+	}
+	return 0
+}
+
+func (x *QuoteCacheStats) GetEntries() uint32 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+// LaunchConfig is the configuration the server was started with.
+type LaunchConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Canonical JSON object of the configuration values: keys sorted, no
+	// whitespace.
+	Document []byte `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	// SHA-384 digest of document.
+	Digest  []byte              `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"` // should be 48 bytes
+	Binding LaunchConfigBinding `protobuf:"varint,3,opt,name=binding,proto3,enum=attest.LaunchConfigBinding" json:"binding,omitempty"`
+	// RTMR extended with the document, if binding is RTMR.
+	RtmrIndex     uint32 `protobuf:"varint,4,opt,name=rtmr_index,json=rtmrIndex,proto3" json:"rtmr_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LaunchConfig) Reset() {
+	*x = LaunchConfig{}
+	mi := &file_proto_attest_attest_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LaunchConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LaunchConfig) ProtoMessage() {}
+
+func (x *LaunchConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LaunchConfig.ProtoReflect.Descriptor instead.
+func (*LaunchConfig) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LaunchConfig) GetDocument() []byte {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *LaunchConfig) GetDigest() []byte {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+func (x *LaunchConfig) GetBinding() LaunchConfigBinding {
+	if x != nil {
+		return x.Binding
+	}
+	return LaunchConfigBinding_LAUNCH_CONFIG_BINDING_UNSPECIFIED
+}
+
+func (x *LaunchConfig) GetRtmrIndex() uint32 {
+	if x != nil {
+		return x.RtmrIndex
+	}
+	return 0
+}
+
 type GetQuoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Caller supplied data (e.g. a nonce or a public key hash) bound into the
+	// quote's REPORTDATA. At most 64 bytes.
+	ReportData []byte `protobuf:"bytes,1,opt,name=report_data,json=reportData,proto3" json:"report_data,omitempty"`
+	// Outstanding challenge issued by GetChallenge, bound into REPORTDATA
+	// together with report_data.
+	Challenge []byte `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	// Bundles the collateral needed to verify the quote into the response.
+	IncludeCollateral bool `protobuf:"varint,3,opt,name=include_collateral,json=includeCollateral,proto3" json:"include_collateral,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetQuoteRequest) Reset() {
+	*x = GetQuoteRequest{}
+	mi := &file_proto_attest_attest_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteRequest) ProtoMessage() {}
+
+func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetQuoteRequest) GetReportData() []byte {
+	if x != nil {
+		return x.ReportData
+	}
+	return nil
+}
+
+func (x *GetQuoteRequest) GetChallenge() []byte {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+func (x *GetQuoteRequest) GetIncludeCollateral() bool {
+	if x != nil {
+		return x.IncludeCollateral
+	}
+	return false
+}
+
+type GetQuoteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Quote *Quote                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	// Describes how the 64 bytes of REPORTDATA in the quote were built.
+	ReportDataBinding *ReportDataBinding `protobuf:"bytes,2,opt,name=report_data_binding,json=reportDataBinding,proto3" json:"report_data_binding,omitempty"`
+	// Collateral for the quote, if include_collateral was set.
+	Collateral *Collateral `protobuf:"bytes,3,opt,name=collateral,proto3" json:"collateral,omitempty"`
+	// The launch configuration of the server.
+	LaunchConfig  *LaunchConfig `protobuf:"bytes,4,opt,name=launch_config,json=launchConfig,proto3" json:"launch_config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteResponse) Reset() {
+	*x = GetQuoteResponse{}
+	mi := &file_proto_attest_attest_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteResponse) ProtoMessage() {}
+
+func (x *GetQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteResponse.ProtoReflect.Descriptor instead.
+func (*GetQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetQuoteResponse) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+func (x *GetQuoteResponse) GetReportDataBinding() *ReportDataBinding {
+	if x != nil {
+		return x.ReportDataBinding
+	}
+	return nil
+}
+
+func (x *GetQuoteResponse) GetCollateral() *Collateral {
+	if x != nil {
+		return x.Collateral
+	}
+	return nil
+}
+
+func (x *GetQuoteResponse) GetLaunchConfig() *LaunchConfig {
+	if x != nil {
+		return x.LaunchConfig
+	}
+	return nil
+}
+
+type GetRawQuoteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The quote in the wire format of Intel DCAP, as produced by the quoting
+	// enclave.
+	RawQuote []byte `protobuf:"bytes,1,opt,name=raw_quote,json=rawQuote,proto3" json:"raw_quote,omitempty"`
+	// Describes how the 64 bytes of REPORTDATA in the quote were built.
+	ReportDataBinding *ReportDataBinding `protobuf:"bytes,2,opt,name=report_data_binding,json=reportDataBinding,proto3" json:"report_data_binding,omitempty"`
+	// Collateral for the quote, if include_collateral was set.
+	Collateral *Collateral `protobuf:"bytes,3,opt,name=collateral,proto3" json:"collateral,omitempty"`
+	// The launch configuration of the server.
+	LaunchConfig  *LaunchConfig `protobuf:"bytes,4,opt,name=launch_config,json=launchConfig,proto3" json:"launch_config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRawQuoteResponse) Reset() {
+	*x = GetRawQuoteResponse{}
+	mi := &file_proto_attest_attest_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRawQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRawQuoteResponse) ProtoMessage() {}
+
+func (x *GetRawQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRawQuoteResponse.ProtoReflect.Descriptor instead.
+func (*GetRawQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetRawQuoteResponse) GetRawQuote() []byte {
+	if x != nil {
+		return x.RawQuote
+	}
+	return nil
+}
+
+func (x *GetRawQuoteResponse) GetReportDataBinding() *ReportDataBinding {
+	if x != nil {
+		return x.ReportDataBinding
+	}
+	return nil
+}
+
+func (x *GetRawQuoteResponse) GetCollateral() *Collateral {
+	if x != nil {
+		return x.Collateral
+	}
+	return nil
+}
+
+func (x *GetRawQuoteResponse) GetLaunchConfig() *LaunchConfig {
+	if x != nil {
+		return x.LaunchConfig
+	}
+	return nil
+}
+
+// Collateral holds the PCS artifacts a quote is checked against, as served by
+// a PCCS. Issuer chains are PEM encoded, CRLs are DER encoded.
+type Collateral struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Fmspc string                 `protobuf:"bytes,1,opt,name=fmspc,proto3" json:"fmspc,omitempty"` // hex encoded
+	Ca    string                 `protobuf:"bytes,2,opt,name=ca,proto3" json:"ca,omitempty"`       // "platform" or "processor"
+	// TCB info of the platform and its signed JSON body.
+	TcbInfo            []byte `protobuf:"bytes,3,opt,name=tcb_info,json=tcbInfo,proto3" json:"tcb_info,omitempty"`
+	TcbInfoIssuerChain []byte `protobuf:"bytes,4,opt,name=tcb_info_issuer_chain,json=tcbInfoIssuerChain,proto3" json:"tcb_info_issuer_chain,omitempty"`
+	// Identity of the quoting enclave and its signed JSON body.
+	QeIdentity            []byte `protobuf:"bytes,5,opt,name=qe_identity,json=qeIdentity,proto3" json:"qe_identity,omitempty"`
+	QeIdentityIssuerChain []byte `protobuf:"bytes,6,opt,name=qe_identity_issuer_chain,json=qeIdentityIssuerChain,proto3" json:"qe_identity_issuer_chain,omitempty"`
+	// CRL of the PCK CA that issued the PCK certificate.
+	PckCrl            []byte `protobuf:"bytes,7,opt,name=pck_crl,json=pckCrl,proto3" json:"pck_crl,omitempty"`
+	PckCrlIssuerChain []byte `protobuf:"bytes,8,opt,name=pck_crl_issuer_chain,json=pckCrlIssuerChain,proto3" json:"pck_crl_issuer_chain,omitempty"`
+	// CRL of the Intel SGX root CA.
+	RootCaCrl     []byte `protobuf:"bytes,9,opt,name=root_ca_crl,json=rootCaCrl,proto3" json:"root_ca_crl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Collateral) Reset() {
+	*x = Collateral{}
+	mi := &file_proto_attest_attest_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collateral) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collateral) ProtoMessage() {}
+
+func (x *Collateral) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collateral.ProtoReflect.Descriptor instead.
+func (*Collateral) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Collateral) GetFmspc() string {
+	if x != nil {
+		return x.Fmspc
+	}
+	return ""
+}
+
+func (x *Collateral) GetCa() string {
+	if x != nil {
+		return x.Ca
+	}
+	return ""
+}
+
+func (x *Collateral) GetTcbInfo() []byte {
+	if x != nil {
+		return x.TcbInfo
+	}
+	return nil
+}
+
+func (x *Collateral) GetTcbInfoIssuerChain() []byte {
+	if x != nil {
+		return x.TcbInfoIssuerChain
+	}
+	return nil
+}
+
+func (x *Collateral) GetQeIdentity() []byte {
+	if x != nil {
+		return x.QeIdentity
+	}
+	return nil
+}
+
+func (x *Collateral) GetQeIdentityIssuerChain() []byte {
+	if x != nil {
+		return x.QeIdentityIssuerChain
+	}
+	return nil
+}
+
+func (x *Collateral) GetPckCrl() []byte {
+	if x != nil {
+		return x.PckCrl
+	}
+	return nil
+}
+
+func (x *Collateral) GetPckCrlIssuerChain() []byte {
+	if x != nil {
+		return x.PckCrlIssuerChain
+	}
+	return nil
+}
+
+func (x *Collateral) GetRootCaCrl() []byte {
+	if x != nil {
+		return x.RootCaCrl
+	}
+	return nil
+}
+
+type ReportDataBinding struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Scheme ReportDataScheme       `protobuf:"varint,1,opt,name=scheme,proto3,enum=attest.ReportDataScheme" json:"scheme,omitempty"`
+	// The caller supplied data, as received in the request.
+	UserData []byte `protobuf:"bytes,2,opt,name=user_data,json=userData,proto3" json:"user_data,omitempty"`
+	// The RTMR digest used in the composition.
+	RtmrIndex  uint32 `protobuf:"varint,3,opt,name=rtmr_index,json=rtmrIndex,proto3" json:"rtmr_index,omitempty"`
+	RtmrDigest []byte `protobuf:"bytes,4,opt,name=rtmr_digest,json=rtmrDigest,proto3" json:"rtmr_digest,omitempty"` // should be 48 bytes
+	// The resulting REPORTDATA.
+	ReportData []byte `protobuf:"bytes,5,opt,name=report_data,json=reportData,proto3" json:"report_data,omitempty"` // should be 64 bytes
+	// The challenge issued by GetChallenge, if any.
+	Challenge     []byte `protobuf:"bytes,6,opt,name=challenge,proto3" json:"challenge,omitempty"` // should be 32 bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportDataBinding) Reset() {
+	*x = ReportDataBinding{}
+	mi := &file_proto_attest_attest_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportDataBinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportDataBinding) ProtoMessage() {}
+
+func (x *ReportDataBinding) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportDataBinding.ProtoReflect.Descriptor instead.
+func (*ReportDataBinding) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReportDataBinding) GetScheme() ReportDataScheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return ReportDataScheme_REPORT_DATA_SCHEME_UNSPECIFIED
+}
+
+func (x *ReportDataBinding) GetUserData() []byte {
+	if x != nil {
+		return x.UserData
+	}
+	return nil
+}
+
+func (x *ReportDataBinding) GetRtmrIndex() uint32 {
+	if x != nil {
+		return x.RtmrIndex
+	}
+	return 0
+}
+
+func (x *ReportDataBinding) GetRtmrDigest() []byte {
+	if x != nil {
+		return x.RtmrDigest
+	}
+	return nil
+}
+
+func (x *ReportDataBinding) GetReportData() []byte {
+	if x != nil {
+		return x.ReportData
+	}
+	return nil
+}
+
+func (x *ReportDataBinding) GetChallenge() []byte {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+type VerifyQuoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The quote to verify, either as a Quote message or as raw quote bytes.
+	//
+	// Types that are valid to be assigned to Evidence:
+	//
+	//	*VerifyQuoteRequest_Quote
+	//	*VerifyQuoteRequest_RawQuote
+	Evidence isVerifyQuoteRequest_Evidence `protobuf_oneof:"evidence"`
+	// PEM encoded root certificate(s) trusted for the PCK certificate chain.
+	// If empty, the server's configured root is used.
+	TrustedRoot []byte `protobuf:"bytes,3,opt,name=trusted_root,json=trustedRoot,proto3" json:"trusted_root,omitempty"`
+	// Collateral to check the quote against, e.g. as bundled by GetQuote. If
+	// empty, the server's configured collateral source is used, if any.
+	Collateral    *Collateral `protobuf:"bytes,4,opt,name=collateral,proto3" json:"collateral,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyQuoteRequest) Reset() {
+	*x = VerifyQuoteRequest{}
+	mi := &file_proto_attest_attest_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyQuoteRequest) ProtoMessage() {}
+
+func (x *VerifyQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyQuoteRequest.ProtoReflect.Descriptor instead.
+func (*VerifyQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VerifyQuoteRequest) GetEvidence() isVerifyQuoteRequest_Evidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *VerifyQuoteRequest) GetQuote() *Quote {
+	if x != nil {
+		if x, ok := x.Evidence.(*VerifyQuoteRequest_Quote); ok {
+			return x.Quote
+		}
+	}
+	return nil
+}
+
+func (x *VerifyQuoteRequest) GetRawQuote() []byte {
+	if x != nil {
+		if x, ok := x.Evidence.(*VerifyQuoteRequest_RawQuote); ok {
+			return x.RawQuote
+		}
+	}
+	return nil
+}
+
+func (x *VerifyQuoteRequest) GetTrustedRoot() []byte {
+	if x != nil {
+		return x.TrustedRoot
+	}
+	return nil
+}
+
+func (x *VerifyQuoteRequest) GetCollateral() *Collateral {
+	if x != nil {
+		return x.Collateral
+	}
+	return nil
+}
+
+type isVerifyQuoteRequest_Evidence interface {
+	isVerifyQuoteRequest_Evidence()
+}
+
+type VerifyQuoteRequest_Quote struct {
+	Quote *Quote `protobuf:"bytes,1,opt,name=quote,proto3,oneof"`
+}
+
+type VerifyQuoteRequest_RawQuote struct {
+	RawQuote []byte `protobuf:"bytes,2,opt,name=raw_quote,json=rawQuote,proto3,oneof"`
+}
+
+func (*VerifyQuoteRequest_Quote) isVerifyQuoteRequest_Evidence() {}
+
+func (*VerifyQuoteRequest_RawQuote) isVerifyQuoteRequest_Evidence() {}
+
+type VerifyQuoteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the full signature chain verified successfully.
+	Verified bool `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	// Reason of the failure if not verified.
+	FailureReason string `protobuf:"bytes,2,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	// TD identity reported by the quote.
+	TdQuoteBody *TDQuoteBody `protobuf:"bytes,3,opt,name=td_quote_body,json=tdQuoteBody,proto3" json:"td_quote_body,omitempty"`
+	// PCK leaf certificate used to verify the QE report.
+	PckCertificate *PCKCertificateInfo `protobuf:"bytes,4,opt,name=pck_certificate,json=pckCertificate,proto3" json:"pck_certificate,omitempty"`
+	// Whether TCB info, QE identity and CRLs were checked in addition to the signature chain.
+	CollateralChecked bool `protobuf:"varint,5,opt,name=collateral_checked,json=collateralChecked,proto3" json:"collateral_checked,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *VerifyQuoteResponse) Reset() {
+	*x = VerifyQuoteResponse{}
+	mi := &file_proto_attest_attest_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyQuoteResponse) ProtoMessage() {}
+
+func (x *VerifyQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyQuoteResponse.ProtoReflect.Descriptor instead.
+func (*VerifyQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VerifyQuoteResponse) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *VerifyQuoteResponse) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *VerifyQuoteResponse) GetTdQuoteBody() *TDQuoteBody {
+	if x != nil {
+		return x.TdQuoteBody
+	}
+	return nil
+}
+
+func (x *VerifyQuoteResponse) GetPckCertificate() *PCKCertificateInfo {
+	if x != nil {
+		return x.PckCertificate
+	}
+	return nil
+}
+
+func (x *VerifyQuoteResponse) GetCollateralChecked() bool {
+	if x != nil {
+		return x.CollateralChecked
+	}
+	return false
+}
+
+type PCKCertificateInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReportData    []byte                 `protobuf:"bytes,1,opt,name=report_data,json=reportData,proto3" json:"report_data,omitempty"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Issuer        string                 `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	SerialNumber  []byte                 `protobuf:"bytes,3,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	NotBefore     int64                  `protobuf:"varint,4,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"` // Unix timestamp in seconds
+	NotAfter      int64                  `protobuf:"varint,5,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`    // Unix timestamp in seconds
+	Fmspc         string                 `protobuf:"bytes,6,opt,name=fmspc,proto3" json:"fmspc,omitempty"`                           // hex encoded
+	PceId         string                 `protobuf:"bytes,7,opt,name=pce_id,json=pceId,proto3" json:"pce_id,omitempty"`              // hex encoded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PCKCertificateInfo) Reset() {
+	*x = PCKCertificateInfo{}
+	mi := &file_proto_attest_attest_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PCKCertificateInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PCKCertificateInfo) ProtoMessage() {}
+
+func (x *PCKCertificateInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PCKCertificateInfo.ProtoReflect.Descriptor instead.
+func (*PCKCertificateInfo) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PCKCertificateInfo) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *PCKCertificateInfo) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *PCKCertificateInfo) GetSerialNumber() []byte {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return nil
+}
+
+func (x *PCKCertificateInfo) GetNotBefore() int64 {
+	if x != nil {
+		return x.NotBefore
+	}
+	return 0
+}
+
+func (x *PCKCertificateInfo) GetNotAfter() int64 {
+	if x != nil {
+		return x.NotAfter
+	}
+	return 0
+}
+
+func (x *PCKCertificateInfo) GetFmspc() string {
+	if x != nil {
+		return x.Fmspc
+	}
+	return ""
+}
+
+func (x *PCKCertificateInfo) GetPceId() string {
+	if x != nil {
+		return x.PceId
+	}
+	return ""
+}
+
+type GetEventLogRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sequence number of the first event to return.
+	StartSequence uint64 `protobuf:"varint,1,opt,name=start_sequence,json=startSequence,proto3" json:"start_sequence,omitempty"`
+	// Maximum number of events to return. 0 returns all remaining events.
+	PageSize      uint32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventLogRequest) Reset() {
+	*x = GetEventLogRequest{}
+	mi := &file_proto_attest_attest_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventLogRequest) ProtoMessage() {}
+
+func (x *GetEventLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventLogRequest.ProtoReflect.Descriptor instead.
+func (*GetEventLogRequest) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetEventLogRequest) GetStartSequence() uint64 {
+	if x != nil {
+		return x.StartSequence
+	}
+	return 0
+}
+
+func (x *GetEventLogRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetEventLogResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Events []*MeasurementEvent    `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Sequence number to request the next page from.
+	NextSequence uint64 `protobuf:"varint,2,opt,name=next_sequence,json=nextSequence,proto3" json:"next_sequence,omitempty"`
+	// Total number of events recorded so far.
+	TotalEvents uint64 `protobuf:"varint,3,opt,name=total_events,json=totalEvents,proto3" json:"total_events,omitempty"`
+	// RTMR values after replaying all recorded events.
+	Rtmrs         [][]byte `protobuf:"bytes,4,rep,name=rtmrs,proto3" json:"rtmrs,omitempty"` // should be 48 * rtmrsCount
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventLogResponse) Reset() {
+	*x = GetEventLogResponse{}
+	mi := &file_proto_attest_attest_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventLogResponse) ProtoMessage() {}
+
+func (x *GetEventLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventLogResponse.ProtoReflect.Descriptor instead.
+func (*GetEventLogResponse) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetEventLogResponse) GetEvents() []*MeasurementEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetEventLogResponse) GetNextSequence() uint64 {
+	if x != nil {
+		return x.NextSequence
+	}
+	return 0
+}
+
+func (x *GetEventLogResponse) GetTotalEvents() uint64 {
+	if x != nil {
+		return x.TotalEvents
+	}
+	return 0
+}
+
+func (x *GetEventLogResponse) GetRtmrs() [][]byte {
+	if x != nil {
+		return x.Rtmrs
+	}
+	return nil
+}
+
+type WatchMeasurementsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sequence number of the first event to emit. Clients resume after a
+	// reconnection with the sequence number following the last received event.
+	StartSequence uint64 `protobuf:"varint,1,opt,name=start_sequence,json=startSequence,proto3" json:"start_sequence,omitempty"`
+	// Whether to attach a fresh quote to the last update of every batch of
+	// events recorded together.
+	IncludeQuote bool `protobuf:"varint,2,opt,name=include_quote,json=includeQuote,proto3" json:"include_quote,omitempty"`
+	// Caller supplied data bound into the attached quotes, as in GetQuote.
+	// At most 64 bytes.
+	ReportData    []byte `protobuf:"bytes,3,opt,name=report_data,json=reportData,proto3" json:"report_data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetQuoteRequest) Reset() {
-	*x = GetQuoteRequest{}
-	mi := &file_proto_attest_attest_proto_msgTypes[0]
+func (x *WatchMeasurementsRequest) Reset() {
+	*x = WatchMeasurementsRequest{}
+	mi := &file_proto_attest_attest_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetQuoteRequest) String() string {
+func (x *WatchMeasurementsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetQuoteRequest) ProtoMessage() {}
+func (*WatchMeasurementsRequest) ProtoMessage() {}
 
-func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_attest_attest_proto_msgTypes[0]
+func (x *WatchMeasurementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -52,40 +1422,62 @@ func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
-func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
-	return file_proto_attest_attest_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use WatchMeasurementsRequest.ProtoReflect.Descriptor instead.
+func (*WatchMeasurementsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetQuoteRequest) GetReportData() []byte {
+func (x *WatchMeasurementsRequest) GetStartSequence() uint64 {
+	if x != nil {
+		return x.StartSequence
+	}
+	return 0
+}
+
+func (x *WatchMeasurementsRequest) GetIncludeQuote() bool {
+	if x != nil {
+		return x.IncludeQuote
+	}
+	return false
+}
+
+func (x *WatchMeasurementsRequest) GetReportData() []byte {
 	if x != nil {
 		return x.ReportData
 	}
 	return nil
 }
 
-type GetQuoteResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Quote         *Quote                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// MeasurementUpdate is emitted for every event extended into an RTMR.
+type MeasurementUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The event, carrying its sequence number, RTMR index and event data.
+	Event *MeasurementEvent `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	// Value of the RTMR after extending the event.
+	RtmrValue []byte `protobuf:"bytes,2,opt,name=rtmr_value,json=rtmrValue,proto3" json:"rtmr_value,omitempty"` // should be 48 bytes
+	// Quote taken after the event if requested. It commits to the RTMRs at the
+	// time of the quote, which may include events emitted later.
+	Quote             *Quote             `protobuf:"bytes,3,opt,name=quote,proto3" json:"quote,omitempty"`
+	ReportDataBinding *ReportDataBinding `protobuf:"bytes,4,opt,name=report_data_binding,json=reportDataBinding,proto3" json:"report_data_binding,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *GetQuoteResponse) Reset() {
-	*x = GetQuoteResponse{}
-	mi := &file_proto_attest_attest_proto_msgTypes[1]
+func (x *MeasurementUpdate) Reset() {
+	*x = MeasurementUpdate{}
+	mi := &file_proto_attest_attest_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetQuoteResponse) String() string {
+func (x *MeasurementUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetQuoteResponse) ProtoMessage() {}
+func (*MeasurementUpdate) ProtoMessage() {}
 
-func (x *GetQuoteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_attest_attest_proto_msgTypes[1]
+func (x *MeasurementUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -96,24 +1488,176 @@ func (x *GetQuoteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetQuoteResponse.ProtoReflect.Descriptor instead.
-func (*GetQuoteResponse) Descriptor() ([]byte, []int) {
-	return file_proto_attest_attest_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use MeasurementUpdate.ProtoReflect.Descriptor instead.
+func (*MeasurementUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetQuoteResponse) GetQuote() *Quote {
+func (x *MeasurementUpdate) GetEvent() *MeasurementEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *MeasurementUpdate) GetRtmrValue() []byte {
+	if x != nil {
+		return x.RtmrValue
+	}
+	return nil
+}
+
+func (x *MeasurementUpdate) GetQuote() *Quote {
 	if x != nil {
 		return x.Quote
 	}
 	return nil
 }
 
+func (x *MeasurementUpdate) GetReportDataBinding() *ReportDataBinding {
+	if x != nil {
+		return x.ReportDataBinding
+	}
+	return nil
+}
+
+// MeasurementEvent is a single event extended into an RTMR.
+type MeasurementEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the event in the log, starting from 0.
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// RTMR index the event was extended into.
+	RtmrIndex uint32 `protobuf:"varint,2,opt,name=rtmr_index,json=rtmrIndex,proto3" json:"rtmr_index,omitempty"`
+	// SHA-384 digest of the event, extended into the RTMR as is, as in the TCG event logs.
+	Digest []byte `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	// Hash algorithm of the digest (e.g. "SHA-384").
+	HashAlgorithm string `protobuf:"bytes,4,opt,name=hash_algorithm,json=hashAlgorithm,proto3" json:"hash_algorithm,omitempty"`
+	// Type of the event (e.g. the IMA template name).
+	EventType string `protobuf:"bytes,5,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// Raw event data the digest was computed from.
+	EventData []byte `protobuf:"bytes,6,opt,name=event_data,json=eventData,proto3" json:"event_data,omitempty"`
+	// Source of the event (e.g. the IMA log path) and its line number.
+	Source     string `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	SourceLine uint32 `protobuf:"varint,8,opt,name=source_line,json=sourceLine,proto3" json:"source_line,omitempty"`
+	// Digest of the event in the hash algorithm of its source, if it is not SHA-384, and that algorithm. The source
+	// digest is metadata and is not extended into the RTMR.
+	SourceDigest        []byte `protobuf:"bytes,9,opt,name=source_digest,json=sourceDigest,proto3" json:"source_digest,omitempty"`
+	SourceHashAlgorithm string `protobuf:"bytes,10,opt,name=source_hash_algorithm,json=sourceHashAlgorithm,proto3" json:"source_hash_algorithm,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *MeasurementEvent) Reset() {
+	*x = MeasurementEvent{}
+	mi := &file_proto_attest_attest_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeasurementEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeasurementEvent) ProtoMessage() {}
+
+func (x *MeasurementEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeasurementEvent.ProtoReflect.Descriptor instead.
+func (*MeasurementEvent) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MeasurementEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *MeasurementEvent) GetRtmrIndex() uint32 {
+	if x != nil {
+		return x.RtmrIndex
+	}
+	return 0
+}
+
+func (x *MeasurementEvent) GetDigest() []byte {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+func (x *MeasurementEvent) GetHashAlgorithm() string {
+	if x != nil {
+		return x.HashAlgorithm
+	}
+	return ""
+}
+
+func (x *MeasurementEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *MeasurementEvent) GetEventData() []byte {
+	if x != nil {
+		return x.EventData
+	}
+	return nil
+}
+
+func (x *MeasurementEvent) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *MeasurementEvent) GetSourceLine() uint32 {
+	if x != nil {
+		return x.SourceLine
+	}
+	return 0
+}
+
+func (x *MeasurementEvent) GetSourceDigest() []byte {
+	if x != nil {
+		return x.SourceDigest
+	}
+	return nil
+}
+
+func (x *MeasurementEvent) GetSourceHashAlgorithm() string {
+	if x != nil {
+		return x.SourceHashAlgorithm
+	}
+	return ""
+}
+
 type Quote struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Header of quote structure
 	Header *Header `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"` // should be 48 bytes
-	// TD report by which quote is generated
-	TdQuoteBody *TDQuoteBody `protobuf:"bytes,2,opt,name=td_quote_body,json=tdQuoteBody,proto3" json:"td_quote_body,omitempty"` // should be 584 bytes
+	// TD report by which quote is generated, depending on the header version
+	//
+	// Types that are valid to be assigned to Body:
+	//
+	//	*Quote_TdQuoteBody
+	//	*Quote_TdQuoteBodyV5
+	Body isQuote_Body `protobuf_oneof:"body"`
 	// Size of the Quote Signature Data structure
 	SignedDataSize uint32 `protobuf:"varint,3,opt,name=signed_data_size,json=signedDataSize,proto3" json:"signed_data_size,omitempty"`
 	// Quote Signature Data structure.
@@ -126,7 +1670,7 @@ type Quote struct {
 
 func (x *Quote) Reset() {
 	*x = Quote{}
-	mi := &file_proto_attest_attest_proto_msgTypes[2]
+	mi := &file_proto_attest_attest_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -138,7 +1682,7 @@ func (x *Quote) String() string {
 func (*Quote) ProtoMessage() {}
 
 func (x *Quote) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_attest_attest_proto_msgTypes[2]
+	mi := &file_proto_attest_attest_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -151,7 +1695,7 @@ func (x *Quote) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Quote.ProtoReflect.Descriptor instead.
 func (*Quote) Descriptor() ([]byte, []int) {
-	return file_proto_attest_attest_proto_rawDescGZIP(), []int{2}
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *Quote) GetHeader() *Header {
@@ -161,9 +1705,27 @@ func (x *Quote) GetHeader() *Header {
 	return nil
 }
 
+func (x *Quote) GetBody() isQuote_Body {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
 func (x *Quote) GetTdQuoteBody() *TDQuoteBody {
 	if x != nil {
-		return x.TdQuoteBody
+		if x, ok := x.Body.(*Quote_TdQuoteBody); ok {
+			return x.TdQuoteBody
+		}
+	}
+	return nil
+}
+
+func (x *Quote) GetTdQuoteBodyV5() *TDQuoteBodyV5 {
+	if x != nil {
+		if x, ok := x.Body.(*Quote_TdQuoteBodyV5); ok {
+			return x.TdQuoteBodyV5
+		}
 	}
 	return nil
 }
@@ -189,9 +1751,25 @@ func (x *Quote) GetExtraBytes() []byte {
 	return nil
 }
 
+type isQuote_Body interface {
+	isQuote_Body()
+}
+
+type Quote_TdQuoteBody struct {
+	TdQuoteBody *TDQuoteBody `protobuf:"bytes,2,opt,name=td_quote_body,json=tdQuoteBody,proto3,oneof"` // version 4, should be 584 bytes
+}
+
+type Quote_TdQuoteBodyV5 struct {
+	TdQuoteBodyV5 *TDQuoteBodyV5 `protobuf:"bytes,6,opt,name=td_quote_body_v5,json=tdQuoteBodyV5,proto3,oneof"` // version 5
+}
+
+func (*Quote_TdQuoteBody) isQuote_Body() {}
+
+func (*Quote_TdQuoteBodyV5) isQuote_Body() {}
+
 type Header struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Version 4 supported
+	// Versions 4 and 5 supported
 	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 	// Type of attestation key used by quoting enclave
 	// Values :
@@ -213,7 +1791,7 @@ type Header struct {
 
 func (x *Header) Reset() {
 	*x = Header{}
-	mi := &file_proto_attest_attest_proto_msgTypes[3]
+	mi := &file_proto_attest_attest_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -225,7 +1803,7 @@ func (x *Header) String() string {
 func (*Header) ProtoMessage() {}
 
 func (x *Header) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_attest_attest_proto_msgTypes[3]
+	mi := &file_proto_attest_attest_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -238,7 +1816,7 @@ func (x *Header) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Header.ProtoReflect.Descriptor instead.
 func (*Header) Descriptor() ([]byte, []int) {
-	return file_proto_attest_attest_proto_rawDescGZIP(), []int{3}
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *Header) GetVersion() uint32 {
@@ -310,7 +1888,7 @@ type TDQuoteBody struct {
 
 func (x *TDQuoteBody) Reset() {
 	*x = TDQuoteBody{}
-	mi := &file_proto_attest_attest_proto_msgTypes[4]
+	mi := &file_proto_attest_attest_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -322,7 +1900,7 @@ func (x *TDQuoteBody) String() string {
 func (*TDQuoteBody) ProtoMessage() {}
 
 func (x *TDQuoteBody) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_attest_attest_proto_msgTypes[4]
+	mi := &file_proto_attest_attest_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -335,7 +1913,7 @@ func (x *TDQuoteBody) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TDQuoteBody.ProtoReflect.Descriptor instead.
 func (*TDQuoteBody) Descriptor() ([]byte, []int) {
-	return file_proto_attest_attest_proto_rawDescGZIP(), []int{4}
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *TDQuoteBody) GetTeeTcbSvn() []byte {
@@ -422,6 +2000,81 @@ func (x *TDQuoteBody) GetReportData() []byte {
 	return nil
 }
 
+// Body of a version 5 quote, preceded by a body descriptor in the quote.
+type TDQuoteBodyV5 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Type of the body descriptor
+	// Values :
+	// 2 (TD report of TDX 1.0, 584 bytes)
+	// 3 (TD report of TDX 1.5, 648 bytes)
+	BodyType uint32 `protobuf:"varint,1,opt,name=body_type,json=bodyType,proto3" json:"body_type,omitempty"`
+	// Fields shared with the TDX 1.0 TD report
+	TdQuoteBody *TDQuoteBody `protobuf:"bytes,2,opt,name=td_quote_body,json=tdQuoteBody,proto3" json:"td_quote_body,omitempty"`
+	// Fields added by the TDX 1.5 TD report, empty for body type 2
+	TeeTcbSvn2    []byte `protobuf:"bytes,3,opt,name=tee_tcb_svn2,json=teeTcbSvn2,proto3" json:"tee_tcb_svn2,omitempty"`  // should be 16 bytes
+	MrServicetd   []byte `protobuf:"bytes,4,opt,name=mr_servicetd,json=mrServicetd,proto3" json:"mr_servicetd,omitempty"` // should be 48 bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TDQuoteBodyV5) Reset() {
+	*x = TDQuoteBodyV5{}
+	mi := &file_proto_attest_attest_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TDQuoteBodyV5) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TDQuoteBodyV5) ProtoMessage() {}
+
+func (x *TDQuoteBodyV5) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attest_attest_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TDQuoteBodyV5.ProtoReflect.Descriptor instead.
+func (*TDQuoteBodyV5) Descriptor() ([]byte, []int) {
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TDQuoteBodyV5) GetBodyType() uint32 {
+	if x != nil {
+		return x.BodyType
+	}
+	return 0
+}
+
+func (x *TDQuoteBodyV5) GetTdQuoteBody() *TDQuoteBody {
+	if x != nil {
+		return x.TdQuoteBody
+	}
+	return nil
+}
+
+func (x *TDQuoteBodyV5) GetTeeTcbSvn2() []byte {
+	if x != nil {
+		return x.TeeTcbSvn2
+	}
+	return nil
+}
+
+func (x *TDQuoteBodyV5) GetMrServicetd() []byte {
+	if x != nil {
+		return x.MrServicetd
+	}
+	return nil
+}
+
 type Ecdsa256BitQuoteV4AuthData struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The ECDSA 256-bit signature.
@@ -436,7 +2089,7 @@ type Ecdsa256BitQuoteV4AuthData struct {
 
 func (x *Ecdsa256BitQuoteV4AuthData) Reset() {
 	*x = Ecdsa256BitQuoteV4AuthData{}
-	mi := &file_proto_attest_attest_proto_msgTypes[5]
+	mi := &file_proto_attest_attest_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -448,7 +2101,7 @@ func (x *Ecdsa256BitQuoteV4AuthData) String() string {
 func (*Ecdsa256BitQuoteV4AuthData) ProtoMessage() {}
 
 func (x *Ecdsa256BitQuoteV4AuthData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_attest_attest_proto_msgTypes[5]
+	mi := &file_proto_attest_attest_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -461,7 +2114,7 @@ func (x *Ecdsa256BitQuoteV4AuthData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Ecdsa256BitQuoteV4AuthData.ProtoReflect.Descriptor instead.
 func (*Ecdsa256BitQuoteV4AuthData) Descriptor() ([]byte, []int) {
-	return file_proto_attest_attest_proto_rawDescGZIP(), []int{5}
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *Ecdsa256BitQuoteV4AuthData) GetSignature() []byte {
@@ -487,8 +2140,7 @@ func (x *Ecdsa256BitQuoteV4AuthData) GetCertificationData() *CertificationData {
 
 type CertificationData struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	//	Supported values:
-	//
+	//   Supported values:
 	// - 1 (PCK identifier: PPID in plain text,  CPUSVN and PCESVN)
 	// - 2 (PCK identifier: PPID encrypted using RSA-2048-OAEP, CPUSVN and PCESVN)
 	// - 3 (PCK identifier: PPID encrypted using RSA-3072-OAEP, CPUSVN and PCESVN)
@@ -506,7 +2158,7 @@ type CertificationData struct {
 
 func (x *CertificationData) Reset() {
 	*x = CertificationData{}
-	mi := &file_proto_attest_attest_proto_msgTypes[6]
+	mi := &file_proto_attest_attest_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -518,7 +2170,7 @@ func (x *CertificationData) String() string {
 func (*CertificationData) ProtoMessage() {}
 
 func (x *CertificationData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_attest_attest_proto_msgTypes[6]
+	mi := &file_proto_attest_attest_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -531,7 +2183,7 @@ func (x *CertificationData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CertificationData.ProtoReflect.Descriptor instead.
 func (*CertificationData) Descriptor() ([]byte, []int) {
-	return file_proto_attest_attest_proto_rawDescGZIP(), []int{6}
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *CertificationData) GetCertificateDataType() uint32 {
@@ -567,7 +2219,7 @@ type QEReportCertificationData struct {
 
 func (x *QEReportCertificationData) Reset() {
 	*x = QEReportCertificationData{}
-	mi := &file_proto_attest_attest_proto_msgTypes[7]
+	mi := &file_proto_attest_attest_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -579,7 +2231,7 @@ func (x *QEReportCertificationData) String() string {
 func (*QEReportCertificationData) ProtoMessage() {}
 
 func (x *QEReportCertificationData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_attest_attest_proto_msgTypes[7]
+	mi := &file_proto_attest_attest_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -592,7 +2244,7 @@ func (x *QEReportCertificationData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QEReportCertificationData.ProtoReflect.Descriptor instead.
 func (*QEReportCertificationData) Descriptor() ([]byte, []int) {
-	return file_proto_attest_attest_proto_rawDescGZIP(), []int{7}
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *QEReportCertificationData) GetQeReport() *EnclaveReport {
@@ -625,8 +2277,7 @@ func (x *QEReportCertificationData) GetPckCertificateChainData() *PCKCertificate
 
 type PCKCertificateChainData struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	//	Supported values:
-	//
+	//   Supported values:
 	// - 1 (PCK identifier: PPID in plain text,  CPUSVN and PCESVN)
 	// - 2 (PCK identifier: PPID encrypted using RSA-2048-OAEP, CPUSVN and PCESVN)
 	// - 3 (PCK identifier: PPID encrypted using RSA-3072-OAEP, CPUSVN and PCESVN)
@@ -644,7 +2295,7 @@ type PCKCertificateChainData struct {
 
 func (x *PCKCertificateChainData) Reset() {
 	*x = PCKCertificateChainData{}
-	mi := &file_proto_attest_attest_proto_msgTypes[8]
+	mi := &file_proto_attest_attest_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -656,7 +2307,7 @@ func (x *PCKCertificateChainData) String() string {
 func (*PCKCertificateChainData) ProtoMessage() {}
 
 func (x *PCKCertificateChainData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_attest_attest_proto_msgTypes[8]
+	mi := &file_proto_attest_attest_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -669,7 +2320,7 @@ func (x *PCKCertificateChainData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PCKCertificateChainData.ProtoReflect.Descriptor instead.
 func (*PCKCertificateChainData) Descriptor() ([]byte, []int) {
-	return file_proto_attest_attest_proto_rawDescGZIP(), []int{8}
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *PCKCertificateChainData) GetCertificateDataType() uint32 {
@@ -705,7 +2356,7 @@ type QeAuthData struct {
 
 func (x *QeAuthData) Reset() {
 	*x = QeAuthData{}
-	mi := &file_proto_attest_attest_proto_msgTypes[9]
+	mi := &file_proto_attest_attest_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -717,7 +2368,7 @@ func (x *QeAuthData) String() string {
 func (*QeAuthData) ProtoMessage() {}
 
 func (x *QeAuthData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_attest_attest_proto_msgTypes[9]
+	mi := &file_proto_attest_attest_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -730,7 +2381,7 @@ func (x *QeAuthData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QeAuthData.ProtoReflect.Descriptor instead.
 func (*QeAuthData) Descriptor() ([]byte, []int) {
-	return file_proto_attest_attest_proto_rawDescGZIP(), []int{9}
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *QeAuthData) GetParsedDataSize() uint32 {
@@ -767,7 +2418,7 @@ type EnclaveReport struct {
 
 func (x *EnclaveReport) Reset() {
 	*x = EnclaveReport{}
-	mi := &file_proto_attest_attest_proto_msgTypes[10]
+	mi := &file_proto_attest_attest_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -779,7 +2430,7 @@ func (x *EnclaveReport) String() string {
 func (*EnclaveReport) ProtoMessage() {}
 
 func (x *EnclaveReport) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_attest_attest_proto_msgTypes[10]
+	mi := &file_proto_attest_attest_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -792,7 +2443,7 @@ func (x *EnclaveReport) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EnclaveReport.ProtoReflect.Descriptor instead.
 func (*EnclaveReport) Descriptor() ([]byte, []int) {
-	return file_proto_attest_attest_proto_rawDescGZIP(), []int{10}
+	return file_proto_attest_attest_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *EnclaveReport) GetCpuSvn() []byte {
@@ -884,154 +2535,447 @@ var File_proto_attest_attest_proto protoreflect.FileDescriptor
 var file_proto_attest_attest_proto_rawDesc = []byte{
 	0x0a, 0x19, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2f, 0x61,
 	0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x61, 0x74, 0x74,
-	0x65, 0x73, 0x74, 0x22, 0x32, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x72, 0x65, 0x70,
-	0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x37, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x51, 0x75,
-	0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x71,
-	0x75, 0x6f, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x74, 0x74,
-	0x65, 0x73, 0x74, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65,
-	0x22, 0xf8, 0x01, 0x0a, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x68, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x74, 0x74,
-	0x65, 0x73, 0x74, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64,
-	0x65, 0x72, 0x12, 0x37, 0x0a, 0x0d, 0x74, 0x64, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x62,
-	0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x74, 0x74, 0x65,
-	0x73, 0x74, 0x2e, 0x54, 0x44, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x0b,
-	0x74, 0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x28, 0x0a, 0x10, 0x73,
-	0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x44, 0x61, 0x74,
-	0x61, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x43, 0x0a, 0x0b, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x74, 0x74,
-	0x65, 0x73, 0x74, 0x2e, 0x45, 0x63, 0x64, 0x73, 0x61, 0x32, 0x35, 0x36, 0x42, 0x69, 0x74, 0x51,
-	0x75, 0x6f, 0x74, 0x65, 0x56, 0x34, 0x41, 0x75, 0x74, 0x68, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a,
-	0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x78,
-	0x74, 0x72, 0x61, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x0a, 0x65, 0x78, 0x74, 0x72, 0x61, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xde, 0x01, 0x0a, 0x06,
-	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x12, 0x30, 0x0a, 0x14, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
-	0x6b, 0x65, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12,
-	0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x65, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x74, 0x65, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x15, 0x0a,
-	0x06, 0x71, 0x65, 0x5f, 0x73, 0x76, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x71,
-	0x65, 0x53, 0x76, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x63, 0x65, 0x5f, 0x73, 0x76, 0x6e, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x63, 0x65, 0x53, 0x76, 0x6e, 0x12, 0x20, 0x0a,
-	0x0c, 0x71, 0x65, 0x5f, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x0a, 0x71, 0x65, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x49, 0x64, 0x12,
-	0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x22, 0xff, 0x02, 0x0a,
-	0x0b, 0x54, 0x44, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x1e, 0x0a, 0x0b,
-	0x74, 0x65, 0x65, 0x5f, 0x74, 0x63, 0x62, 0x5f, 0x73, 0x76, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x09, 0x74, 0x65, 0x65, 0x54, 0x63, 0x62, 0x53, 0x76, 0x6e, 0x12, 0x17, 0x0a, 0x07,
-	0x6d, 0x72, 0x5f, 0x73, 0x65, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6d,
-	0x72, 0x53, 0x65, 0x61, 0x6d, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e,
-	0x65, 0x72, 0x5f, 0x73, 0x65, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x6d,
-	0x72, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x53, 0x65, 0x61, 0x6d, 0x12, 0x27, 0x0a, 0x0f, 0x73,
-	0x65, 0x61, 0x6d, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x73, 0x65, 0x61, 0x6d, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62,
-	0x75, 0x74, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x64, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69,
-	0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x74, 0x64, 0x41,
-	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x78, 0x66, 0x61,
-	0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x78, 0x66, 0x61, 0x6d, 0x12, 0x13, 0x0a,
-	0x05, 0x6d, 0x72, 0x5f, 0x74, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6d, 0x72,
-	0x54, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f,
-	0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6d, 0x72, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x72, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72,
-	0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6d, 0x72, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12,
-	0x26, 0x0a, 0x0f, 0x6d, 0x72, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x6d, 0x72, 0x4f, 0x77, 0x6e, 0x65,
-	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x74, 0x6d, 0x72, 0x73,
-	0x18, 0x0b, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x72, 0x74, 0x6d, 0x72, 0x73, 0x12, 0x1f, 0x0a,
-	0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0c, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0xb8,
-	0x01, 0x0a, 0x1a, 0x45, 0x63, 0x64, 0x73, 0x61, 0x32, 0x35, 0x36, 0x42, 0x69, 0x74, 0x51, 0x75,
-	0x6f, 0x74, 0x65, 0x56, 0x34, 0x41, 0x75, 0x74, 0x68, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a,
-	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x65,
-	0x63, 0x64, 0x73, 0x61, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x13, 0x65, 0x63, 0x64, 0x73,
-	0x61, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x12,
-	0x48, 0x0a, 0x12, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x74,
-	0x74, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x11, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x22, 0xbf, 0x01, 0x0a, 0x11, 0x43, 0x65,
-	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12,
-	0x32, 0x0a, 0x15, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x64,
-	0x61, 0x74, 0x61, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13,
-	0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x62, 0x0a, 0x1c, 0x71, 0x65, 0x5f, 0x72, 0x65,
-	0x70, 0x6f, 0x72, 0x74, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
-	0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x51, 0x45, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43,
-	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61,
-	0x52, 0x19, 0x71, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66,
-	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x22, 0x93, 0x02, 0x0a, 0x19,
-	0x51, 0x45, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x32, 0x0a, 0x09, 0x71, 0x65, 0x5f,
-	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61,
-	0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x63, 0x6c, 0x61, 0x76, 0x65, 0x52, 0x65, 0x70,
-	0x6f, 0x72, 0x74, 0x52, 0x08, 0x71, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x2e, 0x0a,
-	0x13, 0x71, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61,
-	0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x71, 0x65, 0x52, 0x65,
-	0x70, 0x6f, 0x72, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x34, 0x0a,
-	0x0c, 0x71, 0x65, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x51, 0x65, 0x41,
-	0x75, 0x74, 0x68, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a, 0x71, 0x65, 0x41, 0x75, 0x74, 0x68, 0x44,
-	0x61, 0x74, 0x61, 0x12, 0x5c, 0x0a, 0x1a, 0x70, 0x63, 0x6b, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69,
-	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74,
-	0x2e, 0x50, 0x43, 0x4b, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x43,
-	0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x17, 0x70, 0x63, 0x6b, 0x43, 0x65, 0x72,
-	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
-	0x61, 0x22, 0x87, 0x01, 0x0a, 0x17, 0x50, 0x43, 0x4b, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
-	0x63, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x32, 0x0a,
-	0x15, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x64, 0x61, 0x74,
-	0x61, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x63, 0x65,
-	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x63, 0x6b, 0x5f, 0x63, 0x65, 0x72,
-	0x74, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70,
-	0x63, 0x6b, 0x43, 0x65, 0x72, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x22, 0x4a, 0x0a, 0x0a, 0x51,
-	0x65, 0x41, 0x75, 0x74, 0x68, 0x44, 0x61, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x61, 0x72,
-	0x73, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x0e, 0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53,
-	0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xf7, 0x02, 0x0a, 0x0d, 0x45, 0x6e, 0x63, 0x6c,
-	0x61, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x70, 0x75,
-	0x5f, 0x73, 0x76, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x70, 0x75, 0x53,
-	0x76, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x69, 0x73, 0x63, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x69, 0x73, 0x63, 0x53, 0x65, 0x6c,
-	0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x31,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64,
-	0x31, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
-	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x72, 0x5f, 0x65, 0x6e, 0x63, 0x6c, 0x61, 0x76, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6d, 0x72, 0x45, 0x6e, 0x63, 0x6c, 0x61, 0x76, 0x65,
-	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x32, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x32, 0x12, 0x1b,
-	0x0a, 0x09, 0x6d, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x08, 0x6d, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72,
-	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x33, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
-	0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x33, 0x12, 0x1e, 0x0a, 0x0b, 0x69, 0x73, 0x76,
-	0x5f, 0x70, 0x72, 0x6f, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
-	0x69, 0x73, 0x76, 0x50, 0x72, 0x6f, 0x64, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x73, 0x76,
-	0x5f, 0x73, 0x76, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x69, 0x73, 0x76, 0x53,
-	0x76, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x34, 0x18,
-	0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x34,
+	0x65, 0x73, 0x74, 0x22, 0x15, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x53, 0x0a, 0x14, 0x47, 0x65,
+	0x74, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22,
+	0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0xf8, 0x02, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x10, 0x73, 0x65, 0x6c,
+	0x66, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x6c,
+	0x66, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x0f, 0x73, 0x65, 0x6c, 0x66, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x6f, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x69,
+	0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x67, 0x72,
+	0x61, 0x64, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x65, 0x67, 0x72,
+	0x61, 0x64, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x51, 0x75, 0x6f,
+	0x74, 0x65, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x39, 0x0a, 0x0d, 0x6c, 0x61, 0x75,
+	0x6e, 0x63, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x38, 0x0a, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x61,
+	0x63, 0x68, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x74, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x0a, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x22, 0x75,
+	0x0a, 0x0f, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x68, 0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x63, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x63, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x65, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x98, 0x01, 0x0a, 0x0c, 0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x07, 0x62, 0x69,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x61, 0x74,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x74, 0x6d, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x74, 0x6d, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x22, 0x7f, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x44, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f,
+	0x6c, 0x6c, 0x61, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x65, 0x72, 0x61,
+	0x6c, 0x22, 0xf1, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x49, 0x0a, 0x13, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x62, 0x69, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x42, 0x69, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x52, 0x11, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x42,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x32, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x74,
+	0x65, 0x72, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x52, 0x0a,
+	0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x12, 0x39, 0x0a, 0x0d, 0x6c, 0x61,
+	0x75, 0x6e, 0x63, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x75, 0x6e, 0x63,
+	0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xec, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x61, 0x77,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x72, 0x61, 0x77, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x72, 0x61, 0x77, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x49, 0x0a, 0x13, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x42, 0x69, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x52, 0x11, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x42, 0x69,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x32, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x65,
+	0x72, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x74, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x52, 0x0a, 0x63,
+	0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x12, 0x39, 0x0a, 0x0d, 0x6c, 0x61, 0x75,
+	0x6e, 0x63, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x22, 0xc4, 0x02, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x65,
+	0x72, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6d, 0x73, 0x70, 0x63, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x66, 0x6d, 0x73, 0x70, 0x63, 0x12, 0x0e, 0x0a, 0x02, 0x63, 0x61, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x63, 0x61, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x63, 0x62,
+	0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x74, 0x63, 0x62,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x31, 0x0a, 0x15, 0x74, 0x63, 0x62, 0x5f, 0x69, 0x6e, 0x66, 0x6f,
+	0x5f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x12, 0x74, 0x63, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x49, 0x73, 0x73, 0x75,
+	0x65, 0x72, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x65, 0x5f, 0x69, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x71, 0x65,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x37, 0x0a, 0x18, 0x71, 0x65, 0x5f, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x5f, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x15, 0x71, 0x65, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x63, 0x6b, 0x5f, 0x63, 0x72, 0x6c, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x70, 0x63, 0x6b, 0x43, 0x72, 0x6c, 0x12, 0x2f, 0x0a, 0x14, 0x70, 0x63,
+	0x6b, 0x5f, 0x63, 0x72, 0x6c, 0x5f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x70, 0x63, 0x6b, 0x43, 0x72, 0x6c,
+	0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x1e, 0x0a, 0x0b, 0x72,
+	0x6f, 0x6f, 0x74, 0x5f, 0x63, 0x61, 0x5f, 0x63, 0x72, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x72, 0x6f, 0x6f, 0x74, 0x43, 0x61, 0x43, 0x72, 0x6c, 0x22, 0xe1, 0x01, 0x0a, 0x11,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x30, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x18, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x44, 0x61, 0x74, 0x61, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x74, 0x6d, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x74, 0x6d, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x1f, 0x0a, 0x0b, 0x72, 0x74, 0x6d, 0x72, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x72, 0x74, 0x6d, 0x72, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
 	0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x0c, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74,
-	0x61, 0x32, 0x4e, 0x0a, 0x0d, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x17,
-	0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74,
-	0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x78, 0x79, 0x7a, 0x2f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x62,
-	0x75, 0x6c, 0x62, 0x2d, 0x74, 0x64, 0x78, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x74,
-	0x74, 0x65, 0x73, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x22,
+	0xbd, 0x01, 0x0a, 0x12, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x48, 0x00, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x1d, 0x0a,
+	0x09, 0x72, 0x61, 0x77, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x48, 0x00, 0x52, 0x08, 0x72, 0x61, 0x77, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0b, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x74, 0x12,
+	0x32, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6c,
+	0x6c, 0x61, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x65,
+	0x72, 0x61, 0x6c, 0x42, 0x0a, 0x0a, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x22,
+	0x85, 0x02, 0x0a, 0x13, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x0d, 0x74, 0x64,
+	0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x44, 0x51, 0x75, 0x6f,
+	0x74, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x0b, 0x74, 0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x42,
+	0x6f, 0x64, 0x79, 0x12, 0x43, 0x0a, 0x0f, 0x70, 0x63, 0x6b, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61,
+	0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x43, 0x4b, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0e, 0x70, 0x63, 0x6b, 0x43, 0x65, 0x72,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x63, 0x6f, 0x6c, 0x6c,
+	0x61, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x65, 0x72, 0x61, 0x6c,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x22, 0xd4, 0x01, 0x0a, 0x12, 0x50, 0x43, 0x4b, 0x43,
+	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f, 0x62, 0x65, 0x66,
+	0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65,
+	0x66, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x41, 0x66, 0x74, 0x65,
+	0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6d, 0x73, 0x70, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x66, 0x6d, 0x73, 0x70, 0x63, 0x12, 0x15, 0x0a, 0x06, 0x70, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x63, 0x65, 0x49, 0x64, 0x22, 0x58,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xa5, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x30, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6e, 0x65, 0x78, 0x74, 0x53,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x74,
+	0x6d, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x72, 0x74, 0x6d, 0x72, 0x73,
+	0x22, 0x87, 0x01, 0x0a, 0x18, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a,
+	0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f,
+	0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0xd2, 0x01, 0x0a, 0x11, 0x4d,
+	0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x2e, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x74, 0x6d, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x74, 0x6d, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x23, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x71,
+	0x75, 0x6f, 0x74, 0x65, 0x12, 0x49, 0x0a, 0x13, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x44, 0x61, 0x74, 0x61, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x11, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22,
+	0xdc, 0x02, 0x0a, 0x10, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x74, 0x6d, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x74, 0x6d, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x61, 0x73, 0x68, 0x5f,
+	0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x68, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x1d,
+	0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c,
+	0x69, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69,
+	0x74, 0x68, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x48, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x22, 0xc4,
+	0x02, 0x0a, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x39, 0x0a, 0x0d, 0x74, 0x64, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x62, 0x6f, 0x64,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x54, 0x44, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x48, 0x00, 0x52, 0x0b,
+	0x74, 0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x40, 0x0a, 0x10, 0x74,
+	0x64, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x5f, 0x76, 0x35, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54,
+	0x44, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x56, 0x35, 0x48, 0x00, 0x52, 0x0d,
+	0x74, 0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x56, 0x35, 0x12, 0x28, 0x0a,
+	0x10, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x43, 0x0a, 0x0b, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61,
+	0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x63, 0x64, 0x73, 0x61, 0x32, 0x35, 0x36, 0x42, 0x69,
+	0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x56, 0x34, 0x41, 0x75, 0x74, 0x68, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b,
+	0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x61, 0x42, 0x79, 0x74, 0x65, 0x73, 0x42, 0x06, 0x0a,
+	0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0xde, 0x01, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x74,
+	0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x74, 0x65, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07,
+	0x74, 0x65, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x71, 0x65, 0x5f, 0x73, 0x76,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x71, 0x65, 0x53, 0x76, 0x6e, 0x12, 0x17,
+	0x0a, 0x07, 0x70, 0x63, 0x65, 0x5f, 0x73, 0x76, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x70, 0x63, 0x65, 0x53, 0x76, 0x6e, 0x12, 0x20, 0x0a, 0x0c, 0x71, 0x65, 0x5f, 0x76, 0x65,
+	0x6e, 0x64, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x71,
+	0x65, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x22, 0xff, 0x02, 0x0a, 0x0b, 0x54, 0x44, 0x51, 0x75, 0x6f,
+	0x74, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x1e, 0x0a, 0x0b, 0x74, 0x65, 0x65, 0x5f, 0x74, 0x63,
+	0x62, 0x5f, 0x73, 0x76, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x74, 0x65, 0x65,
+	0x54, 0x63, 0x62, 0x53, 0x76, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x72, 0x5f, 0x73, 0x65, 0x61,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6d, 0x72, 0x53, 0x65, 0x61, 0x6d, 0x12,
+	0x24, 0x0a, 0x0e, 0x6d, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x61,
+	0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x6d, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x65,
+	0x72, 0x53, 0x65, 0x61, 0x6d, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x61, 0x6d, 0x5f, 0x61, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e,
+	0x73, 0x65, 0x61, 0x6d, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x74, 0x64, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x74, 0x64, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x78, 0x66, 0x61, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x78, 0x66, 0x61, 0x6d, 0x12, 0x13, 0x0a, 0x05, 0x6d, 0x72, 0x5f, 0x74, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6d, 0x72, 0x54, 0x64, 0x12, 0x20, 0x0a, 0x0c,
+	0x6d, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x6d, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x49, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x6d, 0x72, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x6d, 0x72, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x72, 0x5f,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0d, 0x6d, 0x72, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x74, 0x6d, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x05, 0x72, 0x74, 0x6d, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0xaa, 0x01, 0x0a, 0x0d, 0x54, 0x44, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x56, 0x35, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x6f,
+	0x64, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x62,
+	0x6f, 0x64, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x37, 0x0a, 0x0d, 0x74, 0x64, 0x5f, 0x71, 0x75,
+	0x6f, 0x74, 0x65, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x44, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x42,
+	0x6f, 0x64, 0x79, 0x52, 0x0b, 0x74, 0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x42, 0x6f, 0x64, 0x79,
+	0x12, 0x20, 0x0a, 0x0c, 0x74, 0x65, 0x65, 0x5f, 0x74, 0x63, 0x62, 0x5f, 0x73, 0x76, 0x6e, 0x32,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x74, 0x65, 0x65, 0x54, 0x63, 0x62, 0x53, 0x76,
+	0x6e, 0x32, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x72, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x74, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x6d, 0x72, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x74, 0x64, 0x22, 0xb8, 0x01, 0x0a, 0x1a, 0x45, 0x63, 0x64, 0x73, 0x61, 0x32,
+	0x35, 0x36, 0x42, 0x69, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x56, 0x34, 0x41, 0x75, 0x74, 0x68,
+	0x44, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x65, 0x63, 0x64, 0x73, 0x61, 0x5f, 0x61, 0x74, 0x74, 0x65,
+	0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x13, 0x65, 0x63, 0x64, 0x73, 0x61, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x48, 0x0a, 0x12, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x65, 0x72, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x11, 0x63,
+	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61,
+	0x22, 0xbf, 0x01, 0x0a, 0x11, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x62,
+	0x0a, 0x1c, 0x71, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x63, 0x65, 0x72, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x51, 0x45,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x19, 0x71, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x22, 0x93, 0x02, 0x0a, 0x19, 0x51, 0x45, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43,
+	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x32, 0x0a, 0x09, 0x71, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x63,
+	0x6c, 0x61, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x08, 0x71, 0x65, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x71, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x11, 0x71, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x12, 0x34, 0x0a, 0x0c, 0x71, 0x65, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x5f,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x51, 0x65, 0x41, 0x75, 0x74, 0x68, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a,
+	0x71, 0x65, 0x41, 0x75, 0x74, 0x68, 0x44, 0x61, 0x74, 0x61, 0x12, 0x5c, 0x0a, 0x1a, 0x70, 0x63,
+	0x6b, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x43, 0x4b, 0x43, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x17, 0x70, 0x63, 0x6b, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x43,
+	0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x22, 0x87, 0x01, 0x0a, 0x17, 0x50, 0x43, 0x4b,
+	0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x44, 0x61, 0x74, 0x61, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x13, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x24, 0x0a, 0x0e,
+	0x70, 0x63, 0x6b, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x63, 0x6b, 0x43, 0x65, 0x72, 0x74, 0x43, 0x68, 0x61,
+	0x69, 0x6e, 0x22, 0x4a, 0x0a, 0x0a, 0x51, 0x65, 0x41, 0x75, 0x74, 0x68, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x28, 0x0a, 0x10, 0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x70, 0x61, 0x72, 0x73,
+	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xf7,
+	0x02, 0x0a, 0x0d, 0x45, 0x6e, 0x63, 0x6c, 0x61, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x17, 0x0a, 0x07, 0x63, 0x70, 0x75, 0x5f, 0x73, 0x76, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x63, 0x70, 0x75, 0x53, 0x76, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x69, 0x73,
+	0x63, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x6d, 0x69, 0x73, 0x63, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x31, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x31, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x61, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x72, 0x5f, 0x65,
+	0x6e, 0x63, 0x6c, 0x61, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6d, 0x72,
+	0x45, 0x6e, 0x63, 0x6c, 0x61, 0x76, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x64, 0x32, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x64, 0x32, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x72, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x33, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x33,
+	0x12, 0x1e, 0x0a, 0x0b, 0x69, 0x73, 0x76, 0x5f, 0x70, 0x72, 0x6f, 0x64, 0x5f, 0x69, 0x64, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x69, 0x73, 0x76, 0x50, 0x72, 0x6f, 0x64, 0x49, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x69, 0x73, 0x76, 0x5f, 0x73, 0x76, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x69, 0x73, 0x76, 0x53, 0x76, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x64, 0x34, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x34, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x2a, 0xa8, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x6c,
+	0x66, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x26, 0x0a, 0x22, 0x53, 0x45, 0x4c, 0x46, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x53, 0x54,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e, 0x53, 0x45, 0x4c,
+	0x46, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x53, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x45, 0x5f, 0x53, 0x4b, 0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x01, 0x12, 0x21, 0x0a,
+	0x1d, 0x53, 0x45, 0x4c, 0x46, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x53, 0x54, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x45, 0x44, 0x10, 0x02,
+	0x12, 0x21, 0x0a, 0x1d, 0x53, 0x45, 0x4c, 0x46, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x53, 0x54, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45,
+	0x44, 0x10, 0x03, 0x2a, 0x84, 0x01, 0x0a, 0x13, 0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x25, 0x0a, 0x21, 0x4c,
+	0x41, 0x55, 0x4e, 0x43, 0x48, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x5f, 0x42, 0x49, 0x4e,
+	0x44, 0x49, 0x4e, 0x47, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x26, 0x0a, 0x22, 0x4c, 0x41, 0x55, 0x4e, 0x43, 0x48, 0x5f, 0x43, 0x4f, 0x4e,
+	0x46, 0x49, 0x47, 0x5f, 0x42, 0x49, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x52, 0x5f, 0x43,
+	0x4f, 0x4e, 0x46, 0x49, 0x47, 0x5f, 0x49, 0x44, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x4c, 0x41,
+	0x55, 0x4e, 0x43, 0x48, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x5f, 0x42, 0x49, 0x4e, 0x44,
+	0x49, 0x4e, 0x47, 0x5f, 0x52, 0x54, 0x4d, 0x52, 0x10, 0x02, 0x2a, 0xd6, 0x01, 0x0a, 0x10, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12,
+	0x22, 0x0a, 0x1e, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x53,
+	0x43, 0x48, 0x45, 0x4d, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x44, 0x41,
+	0x54, 0x41, 0x5f, 0x53, 0x43, 0x48, 0x45, 0x4d, 0x45, 0x5f, 0x5a, 0x45, 0x52, 0x4f, 0x10, 0x01,
+	0x12, 0x1b, 0x0a, 0x17, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f,
+	0x53, 0x43, 0x48, 0x45, 0x4d, 0x45, 0x5f, 0x52, 0x54, 0x4d, 0x52, 0x10, 0x02, 0x12, 0x2c, 0x0a,
+	0x28, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x53, 0x43, 0x48,
+	0x45, 0x4d, 0x45, 0x5f, 0x53, 0x48, 0x41, 0x35, 0x31, 0x32, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x5f,
+	0x44, 0x41, 0x54, 0x41, 0x5f, 0x52, 0x54, 0x4d, 0x52, 0x10, 0x03, 0x12, 0x36, 0x0a, 0x32, 0x52,
+	0x45, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x53, 0x43, 0x48, 0x45, 0x4d,
+	0x45, 0x5f, 0x53, 0x48, 0x41, 0x35, 0x31, 0x32, 0x5f, 0x43, 0x48, 0x41, 0x4c, 0x4c, 0x45, 0x4e,
+	0x47, 0x45, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x52, 0x54, 0x4d,
+	0x52, 0x10, 0x04, 0x32, 0x84, 0x04, 0x0a, 0x0d, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x12, 0x17, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1b, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b,
+	0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x1a, 0x2e, 0x61, 0x74,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x61, 0x77, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x74,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61,
+	0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x77, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x11, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x20,
+	0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x61,
+	0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x49, 0x0a,
+	0x0c, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x1b, 0x2e,
+	0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x78,
+	0x79, 0x7a, 0x2f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x62, 0x75, 0x6c, 0x62, 0x2d, 0x74, 0x64, 0x78,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1046,37 +2990,93 @@ func file_proto_attest_attest_proto_rawDescGZIP() []byte {
 	return file_proto_attest_attest_proto_rawDescData
 }
 
-var file_proto_attest_attest_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_attest_attest_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_attest_attest_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_proto_attest_attest_proto_goTypes = []any{
-	(*GetQuoteRequest)(nil),            // 0: attest.GetQuoteRequest
-	(*GetQuoteResponse)(nil),           // 1: attest.GetQuoteResponse
-	(*Quote)(nil),                      // 2: attest.Quote
-	(*Header)(nil),                     // 3: attest.Header
-	(*TDQuoteBody)(nil),                // 4: attest.TDQuoteBody
-	(*Ecdsa256BitQuoteV4AuthData)(nil), // 5: attest.Ecdsa256BitQuoteV4AuthData
-	(*CertificationData)(nil),          // 6: attest.CertificationData
-	(*QEReportCertificationData)(nil),  // 7: attest.QEReportCertificationData
-	(*PCKCertificateChainData)(nil),    // 8: attest.PCKCertificateChainData
-	(*QeAuthData)(nil),                 // 9: attest.QeAuthData
-	(*EnclaveReport)(nil),              // 10: attest.EnclaveReport
+	(SelfAttestationState)(0),          // 0: attest.SelfAttestationState
+	(LaunchConfigBinding)(0),           // 1: attest.LaunchConfigBinding
+	(ReportDataScheme)(0),              // 2: attest.ReportDataScheme
+	(*GetChallengeRequest)(nil),        // 3: attest.GetChallengeRequest
+	(*GetChallengeResponse)(nil),       // 4: attest.GetChallengeResponse
+	(*GetStatusRequest)(nil),           // 5: attest.GetStatusRequest
+	(*GetStatusResponse)(nil),          // 6: attest.GetStatusResponse
+	(*QuoteCacheStats)(nil),            // 7: attest.QuoteCacheStats
+	(*LaunchConfig)(nil),               // 8: attest.LaunchConfig
+	(*GetQuoteRequest)(nil),            // 9: attest.GetQuoteRequest
+	(*GetQuoteResponse)(nil),           // 10: attest.GetQuoteResponse
+	(*GetRawQuoteResponse)(nil),        // 11: attest.GetRawQuoteResponse
+	(*Collateral)(nil),                 // 12: attest.Collateral
+	(*ReportDataBinding)(nil),          // 13: attest.ReportDataBinding
+	(*VerifyQuoteRequest)(nil),         // 14: attest.VerifyQuoteRequest
+	(*VerifyQuoteResponse)(nil),        // 15: attest.VerifyQuoteResponse
+	(*PCKCertificateInfo)(nil),         // 16: attest.PCKCertificateInfo
+	(*GetEventLogRequest)(nil),         // 17: attest.GetEventLogRequest
+	(*GetEventLogResponse)(nil),        // 18: attest.GetEventLogResponse
+	(*WatchMeasurementsRequest)(nil),   // 19: attest.WatchMeasurementsRequest
+	(*MeasurementUpdate)(nil),          // 20: attest.MeasurementUpdate
+	(*MeasurementEvent)(nil),           // 21: attest.MeasurementEvent
+	(*Quote)(nil),                      // 22: attest.Quote
+	(*Header)(nil),                     // 23: attest.Header
+	(*TDQuoteBody)(nil),                // 24: attest.TDQuoteBody
+	(*TDQuoteBodyV5)(nil),              // 25: attest.TDQuoteBodyV5
+	(*Ecdsa256BitQuoteV4AuthData)(nil), // 26: attest.Ecdsa256BitQuoteV4AuthData
+	(*CertificationData)(nil),          // 27: attest.CertificationData
+	(*QEReportCertificationData)(nil),  // 28: attest.QEReportCertificationData
+	(*PCKCertificateChainData)(nil),    // 29: attest.PCKCertificateChainData
+	(*QeAuthData)(nil),                 // 30: attest.QeAuthData
+	(*EnclaveReport)(nil),              // 31: attest.EnclaveReport
 }
 var file_proto_attest_attest_proto_depIdxs = []int32{
-	2,  // 0: attest.GetQuoteResponse.quote:type_name -> attest.Quote
-	3,  // 1: attest.Quote.header:type_name -> attest.Header
-	4,  // 2: attest.Quote.td_quote_body:type_name -> attest.TDQuoteBody
-	5,  // 3: attest.Quote.signed_data:type_name -> attest.Ecdsa256BitQuoteV4AuthData
-	6,  // 4: attest.Ecdsa256BitQuoteV4AuthData.certification_data:type_name -> attest.CertificationData
-	7,  // 5: attest.CertificationData.qe_report_certification_data:type_name -> attest.QEReportCertificationData
-	10, // 6: attest.QEReportCertificationData.qe_report:type_name -> attest.EnclaveReport
-	9,  // 7: attest.QEReportCertificationData.qe_auth_data:type_name -> attest.QeAuthData
-	8,  // 8: attest.QEReportCertificationData.pck_certificate_chain_data:type_name -> attest.PCKCertificateChainData
-	0,  // 9: attest.AttestService.GetQuote:input_type -> attest.GetQuoteRequest
-	1,  // 10: attest.AttestService.GetQuote:output_type -> attest.GetQuoteResponse
-	10, // [10:11] is the sub-list for method output_type
-	9,  // [9:10] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	0,  // 0: attest.GetStatusResponse.self_attestation:type_name -> attest.SelfAttestationState
+	22, // 1: attest.GetStatusResponse.quote:type_name -> attest.Quote
+	8,  // 2: attest.GetStatusResponse.launch_config:type_name -> attest.LaunchConfig
+	7,  // 3: attest.GetStatusResponse.quote_cache:type_name -> attest.QuoteCacheStats
+	1,  // 4: attest.LaunchConfig.binding:type_name -> attest.LaunchConfigBinding
+	22, // 5: attest.GetQuoteResponse.quote:type_name -> attest.Quote
+	13, // 6: attest.GetQuoteResponse.report_data_binding:type_name -> attest.ReportDataBinding
+	12, // 7: attest.GetQuoteResponse.collateral:type_name -> attest.Collateral
+	8,  // 8: attest.GetQuoteResponse.launch_config:type_name -> attest.LaunchConfig
+	13, // 9: attest.GetRawQuoteResponse.report_data_binding:type_name -> attest.ReportDataBinding
+	12, // 10: attest.GetRawQuoteResponse.collateral:type_name -> attest.Collateral
+	8,  // 11: attest.GetRawQuoteResponse.launch_config:type_name -> attest.LaunchConfig
+	2,  // 12: attest.ReportDataBinding.scheme:type_name -> attest.ReportDataScheme
+	22, // 13: attest.VerifyQuoteRequest.quote:type_name -> attest.Quote
+	12, // 14: attest.VerifyQuoteRequest.collateral:type_name -> attest.Collateral
+	24, // 15: attest.VerifyQuoteResponse.td_quote_body:type_name -> attest.TDQuoteBody
+	16, // 16: attest.VerifyQuoteResponse.pck_certificate:type_name -> attest.PCKCertificateInfo
+	21, // 17: attest.GetEventLogResponse.events:type_name -> attest.MeasurementEvent
+	21, // 18: attest.MeasurementUpdate.event:type_name -> attest.MeasurementEvent
+	22, // 19: attest.MeasurementUpdate.quote:type_name -> attest.Quote
+	13, // 20: attest.MeasurementUpdate.report_data_binding:type_name -> attest.ReportDataBinding
+	23, // 21: attest.Quote.header:type_name -> attest.Header
+	24, // 22: attest.Quote.td_quote_body:type_name -> attest.TDQuoteBody
+	25, // 23: attest.Quote.td_quote_body_v5:type_name -> attest.TDQuoteBodyV5
+	26, // 24: attest.Quote.signed_data:type_name -> attest.Ecdsa256BitQuoteV4AuthData
+	24, // 25: attest.TDQuoteBodyV5.td_quote_body:type_name -> attest.TDQuoteBody
+	27, // 26: attest.Ecdsa256BitQuoteV4AuthData.certification_data:type_name -> attest.CertificationData
+	28, // 27: attest.CertificationData.qe_report_certification_data:type_name -> attest.QEReportCertificationData
+	31, // 28: attest.QEReportCertificationData.qe_report:type_name -> attest.EnclaveReport
+	30, // 29: attest.QEReportCertificationData.qe_auth_data:type_name -> attest.QeAuthData
+	29, // 30: attest.QEReportCertificationData.pck_certificate_chain_data:type_name -> attest.PCKCertificateChainData
+	9,  // 31: attest.AttestService.GetQuote:input_type -> attest.GetQuoteRequest
+	14, // 32: attest.AttestService.VerifyQuote:input_type -> attest.VerifyQuoteRequest
+	17, // 33: attest.AttestService.GetEventLog:input_type -> attest.GetEventLogRequest
+	9,  // 34: attest.AttestService.GetRawQuote:input_type -> attest.GetQuoteRequest
+	19, // 35: attest.AttestService.WatchMeasurements:input_type -> attest.WatchMeasurementsRequest
+	3,  // 36: attest.AttestService.GetChallenge:input_type -> attest.GetChallengeRequest
+	5,  // 37: attest.AttestService.GetStatus:input_type -> attest.GetStatusRequest
+	10, // 38: attest.AttestService.GetQuote:output_type -> attest.GetQuoteResponse
+	15, // 39: attest.AttestService.VerifyQuote:output_type -> attest.VerifyQuoteResponse
+	18, // 40: attest.AttestService.GetEventLog:output_type -> attest.GetEventLogResponse
+	11, // 41: attest.AttestService.GetRawQuote:output_type -> attest.GetRawQuoteResponse
+	20, // 42: attest.AttestService.WatchMeasurements:output_type -> attest.MeasurementUpdate
+	4,  // 43: attest.AttestService.GetChallenge:output_type -> attest.GetChallengeResponse
+	6,  // 44: attest.AttestService.GetStatus:output_type -> attest.GetStatusResponse
+	38, // [38:45] is the sub-list for method output_type
+	31, // [31:38] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_proto_attest_attest_proto_init() }
@@ -1084,18 +3084,27 @@ func file_proto_attest_attest_proto_init() {
 	if File_proto_attest_attest_proto != nil {
 		return
 	}
+	file_proto_attest_attest_proto_msgTypes[11].OneofWrappers = []any{
+		(*VerifyQuoteRequest_Quote)(nil),
+		(*VerifyQuoteRequest_RawQuote)(nil),
+	}
+	file_proto_attest_attest_proto_msgTypes[19].OneofWrappers = []any{
+		(*Quote_TdQuoteBody)(nil),
+		(*Quote_TdQuoteBodyV5)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_attest_attest_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      3,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_attest_attest_proto_goTypes,
 		DependencyIndexes: file_proto_attest_attest_proto_depIdxs,
+		EnumInfos:         file_proto_attest_attest_proto_enumTypes,
 		MessageInfos:      file_proto_attest_attest_proto_msgTypes,
 	}.Build()
 	File_proto_attest_attest_proto = out.File
@@ -19,7 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AttestService_GetQuote_FullMethodName = "/attest.AttestService/GetQuote"
+	AttestService_GetQuote_FullMethodName          = "/attest.AttestService/GetQuote"
+	AttestService_VerifyQuote_FullMethodName       = "/attest.AttestService/VerifyQuote"
+	AttestService_GetEventLog_FullMethodName       = "/attest.AttestService/GetEventLog"
+	AttestService_GetRawQuote_FullMethodName       = "/attest.AttestService/GetRawQuote"
+	AttestService_WatchMeasurements_FullMethodName = "/attest.AttestService/WatchMeasurements"
+	AttestService_GetChallenge_FullMethodName      = "/attest.AttestService/GetChallenge"
+	AttestService_GetStatus_FullMethodName         = "/attest.AttestService/GetStatus"
 )
 
 // AttestServiceClient is the client API for AttestService service.
@@ -27,6 +33,12 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type AttestServiceClient interface {
 	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetQuoteResponse, error)
+	VerifyQuote(ctx context.Context, in *VerifyQuoteRequest, opts ...grpc.CallOption) (*VerifyQuoteResponse, error)
+	GetEventLog(ctx context.Context, in *GetEventLogRequest, opts ...grpc.CallOption) (*GetEventLogResponse, error)
+	GetRawQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetRawQuoteResponse, error)
+	WatchMeasurements(ctx context.Context, in *WatchMeasurementsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MeasurementUpdate], error)
+	GetChallenge(ctx context.Context, in *GetChallengeRequest, opts ...grpc.CallOption) (*GetChallengeResponse, error)
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
 }
 
 type attestServiceClient struct {
@@ -47,11 +59,86 @@ func (c *attestServiceClient) GetQuote(ctx context.Context, in *GetQuoteRequest,
 	return out, nil
 }
 
+func (c *attestServiceClient) VerifyQuote(ctx context.Context, in *VerifyQuoteRequest, opts ...grpc.CallOption) (*VerifyQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyQuoteResponse)
+	err := c.cc.Invoke(ctx, AttestService_VerifyQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attestServiceClient) GetEventLog(ctx context.Context, in *GetEventLogRequest, opts ...grpc.CallOption) (*GetEventLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEventLogResponse)
+	err := c.cc.Invoke(ctx, AttestService_GetEventLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attestServiceClient) GetRawQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetRawQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRawQuoteResponse)
+	err := c.cc.Invoke(ctx, AttestService_GetRawQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attestServiceClient) WatchMeasurements(ctx context.Context, in *WatchMeasurementsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MeasurementUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AttestService_ServiceDesc.Streams[0], AttestService_WatchMeasurements_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchMeasurementsRequest, MeasurementUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AttestService_WatchMeasurementsClient = grpc.ServerStreamingClient[MeasurementUpdate]
+
+func (c *attestServiceClient) GetChallenge(ctx context.Context, in *GetChallengeRequest, opts ...grpc.CallOption) (*GetChallengeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChallengeResponse)
+	err := c.cc.Invoke(ctx, AttestService_GetChallenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attestServiceClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, AttestService_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AttestServiceServer is the server API for AttestService service.
 // All implementations must embed UnimplementedAttestServiceServer
 // for forward compatibility.
 type AttestServiceServer interface {
 	GetQuote(context.Context, *GetQuoteRequest) (*GetQuoteResponse, error)
+	VerifyQuote(context.Context, *VerifyQuoteRequest) (*VerifyQuoteResponse, error)
+	GetEventLog(context.Context, *GetEventLogRequest) (*GetEventLogResponse, error)
+	GetRawQuote(context.Context, *GetQuoteRequest) (*GetRawQuoteResponse, error)
+	WatchMeasurements(*WatchMeasurementsRequest, grpc.ServerStreamingServer[MeasurementUpdate]) error
+	GetChallenge(context.Context, *GetChallengeRequest) (*GetChallengeResponse, error)
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
 	mustEmbedUnimplementedAttestServiceServer()
 }
 
@@ -65,6 +152,24 @@ type UnimplementedAttestServiceServer struct{}
 func (UnimplementedAttestServiceServer) GetQuote(context.Context, *GetQuoteRequest) (*GetQuoteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetQuote not implemented")
 }
+func (UnimplementedAttestServiceServer) VerifyQuote(context.Context, *VerifyQuoteRequest) (*VerifyQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyQuote not implemented")
+}
+func (UnimplementedAttestServiceServer) GetEventLog(context.Context, *GetEventLogRequest) (*GetEventLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventLog not implemented")
+}
+func (UnimplementedAttestServiceServer) GetRawQuote(context.Context, *GetQuoteRequest) (*GetRawQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRawQuote not implemented")
+}
+func (UnimplementedAttestServiceServer) WatchMeasurements(*WatchMeasurementsRequest, grpc.ServerStreamingServer[MeasurementUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMeasurements not implemented")
+}
+func (UnimplementedAttestServiceServer) GetChallenge(context.Context, *GetChallengeRequest) (*GetChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChallenge not implemented")
+}
+func (UnimplementedAttestServiceServer) GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
 func (UnimplementedAttestServiceServer) mustEmbedUnimplementedAttestServiceServer() {}
 func (UnimplementedAttestServiceServer) testEmbeddedByValue()                       {}
 
@@ -104,6 +209,107 @@ func _AttestService_GetQuote_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AttestService_VerifyQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestServiceServer).VerifyQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestService_VerifyQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestServiceServer).VerifyQuote(ctx, req.(*VerifyQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttestService_GetEventLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestServiceServer).GetEventLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestService_GetEventLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestServiceServer).GetEventLog(ctx, req.(*GetEventLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttestService_GetRawQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestServiceServer).GetRawQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestService_GetRawQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestServiceServer).GetRawQuote(ctx, req.(*GetQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttestService_WatchMeasurements_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMeasurementsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AttestServiceServer).WatchMeasurements(m, &grpc.GenericServerStream[WatchMeasurementsRequest, MeasurementUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AttestService_WatchMeasurementsServer = grpc.ServerStreamingServer[MeasurementUpdate]
+
+func _AttestService_GetChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestServiceServer).GetChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestService_GetChallenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestServiceServer).GetChallenge(ctx, req.(*GetChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttestService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttestServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttestService_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttestServiceServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AttestService_ServiceDesc is the grpc.ServiceDesc for AttestService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +321,33 @@ var AttestService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetQuote",
 			Handler:    _AttestService_GetQuote_Handler,
 		},
+		{
+			MethodName: "VerifyQuote",
+			Handler:    _AttestService_VerifyQuote_Handler,
+		},
+		{
+			MethodName: "GetEventLog",
+			Handler:    _AttestService_GetEventLog_Handler,
+		},
+		{
+			MethodName: "GetRawQuote",
+			Handler:    _AttestService_GetRawQuote_Handler,
+		},
+		{
+			MethodName: "GetChallenge",
+			Handler:    _AttestService_GetChallenge_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _AttestService_GetStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMeasurements",
+			Handler:       _AttestService_WatchMeasurements_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/attest/attest.proto",
 }
@@ -24,6 +24,7 @@ const (
 	AuctionService_GetAuctionInfo_FullMethodName  = "/auction.AuctionService/GetAuctionInfo"
 	AuctionService_GetLatestTob_FullMethodName    = "/auction.AuctionService/GetLatestTob"
 	AuctionService_GetAuctionState_FullMethodName = "/auction.AuctionService/GetAuctionState"
+	AuctionService_GetSigningKey_FullMethodName   = "/auction.AuctionService/GetSigningKey"
 )
 
 // AuctionServiceClient is the client API for AuctionService service.
@@ -42,6 +43,8 @@ type AuctionServiceClient interface {
 	GetLatestTob(ctx context.Context, in *GetLatestTobRequest, opts ...grpc.CallOption) (*GetLatestTobResponse, error)
 	// Retrieves the current state of an auction.
 	GetAuctionState(ctx context.Context, in *GetAuctionStateRequest, opts ...grpc.CallOption) (*GetAuctionStateResponse, error)
+	// Retrieves a key signing the auction results, with a quote committing to it.
+	GetSigningKey(ctx context.Context, in *GetSigningKeyRequest, opts ...grpc.CallOption) (*GetSigningKeyResponse, error)
 }
 
 type auctionServiceClient struct {
@@ -102,6 +105,16 @@ func (c *auctionServiceClient) GetAuctionState(ctx context.Context, in *GetAucti
 	return out, nil
 }
 
+func (c *auctionServiceClient) GetSigningKey(ctx context.Context, in *GetSigningKeyRequest, opts ...grpc.CallOption) (*GetSigningKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSigningKeyResponse)
+	err := c.cc.Invoke(ctx, AuctionService_GetSigningKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuctionServiceServer is the server API for AuctionService service.
 // All implementations must embed UnimplementedAuctionServiceServer
 // for forward compatibility.
@@ -118,6 +131,8 @@ type AuctionServiceServer interface {
 	GetLatestTob(context.Context, *GetLatestTobRequest) (*GetLatestTobResponse, error)
 	// Retrieves the current state of an auction.
 	GetAuctionState(context.Context, *GetAuctionStateRequest) (*GetAuctionStateResponse, error)
+	// Retrieves a key signing the auction results, with a quote committing to it.
+	GetSigningKey(context.Context, *GetSigningKeyRequest) (*GetSigningKeyResponse, error)
 	mustEmbedUnimplementedAuctionServiceServer()
 }
 
@@ -143,6 +158,9 @@ func (UnimplementedAuctionServiceServer) GetLatestTob(context.Context, *GetLates
 func (UnimplementedAuctionServiceServer) GetAuctionState(context.Context, *GetAuctionStateRequest) (*GetAuctionStateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAuctionState not implemented")
 }
+func (UnimplementedAuctionServiceServer) GetSigningKey(context.Context, *GetSigningKeyRequest) (*GetSigningKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSigningKey not implemented")
+}
 func (UnimplementedAuctionServiceServer) mustEmbedUnimplementedAuctionServiceServer() {}
 func (UnimplementedAuctionServiceServer) testEmbeddedByValue()                        {}
 
@@ -254,6 +272,24 @@ func _AuctionService_GetAuctionState_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuctionService_GetSigningKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSigningKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).GetSigningKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_GetSigningKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).GetSigningKey(ctx, req.(*GetSigningKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuctionService_ServiceDesc is the grpc.ServiceDesc for AuctionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,6 +317,10 @@ var AuctionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAuctionState",
 			Handler:    _AuctionService_GetAuctionState_Handler,
 		},
+		{
+			MethodName: "GetSigningKey",
+			Handler:    _AuctionService_GetSigningKey_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/auction/auction.proto",
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx"
+)
+
+// DefaultSocketPath is the Unix socket served when QGS_SOCKET_PATH is unset.
+const DefaultSocketPath = "/tmp/lightbulb-qgs.sock"
+
+// Stand-in Quote Generation Service for local runs of the qgs quote backend. It signs quotes with a throwaway test
+// key, so its root certificate must be trusted explicitly by verifiers.
+func main() {
+	// Load environment variables, the .env file is optional
+	if err := godotenv.Load(); err != nil {
+		log.Printf("[Info] No .env file loaded: %v", err)
+	}
+
+	socketPath := os.Getenv("QGS_SOCKET_PATH")
+	if socketPath == "" {
+		socketPath = DefaultSocketPath
+	}
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		log.Fatalf("Failed to remove stale socket: %v", err)
+	}
+	lis, err := net.Listen("unix", socketPath)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	signer, err := tdx.NewMockQuoteSigner()
+	if err != nil {
+		log.Fatalf("Failed to create quote signer: %v", err)
+	}
+	if path := os.Getenv("QGS_ROOT_CERT_PATH"); path != "" {
+		// Let the TD trust the test root through TDX_TRUSTED_ROOT_PATH
+		if err := os.WriteFile(path, signer.RootCertificatePEM(), 0o644); err != nil {
+			log.Fatalf("Failed to write root certificate: %v", err)
+		}
+		log.Printf("Wrote the root certificate to %s", path)
+	}
+
+	server := tdx.NewQgsServer(signer)
+	go func() {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+		<-sigCh
+		log.Println("Shutting down the QGS stand-in...")
+		server.Close()
+	}()
+
+	log.Printf("QGS stand-in listening on unix:%s", socketPath)
+	if err := server.Serve(lis); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+	os.Remove(socketPath)
+}
@@ -0,0 +1,160 @@
+package ratls
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+var (
+	// ErrMissingQuote is returned when the server certificate has no quote extension.
+	ErrMissingQuote = errors.New("certificate has no TDX quote")
+	// ErrKeyNotBound is returned when the REPORTDATA of the quote does not bind the certificate key.
+	ErrKeyNotBound = errors.New("quote does not bind the certificate key")
+)
+
+// ClientOptions configures how clients check the server certificate.
+type ClientOptions struct {
+	Verifier *verifier.Verifier // Verifies the quote signature chain; nil uses a Verifier trusting the Intel root
+	Policy   *verifier.Policy   // Checks the TD identity if set; event log rules fail as no events are exchanged
+
+	// TrustEmbeddedRoot trusts the root certificate at the end of the PCK chain of the quote. It is only meant for the
+	// throwaway roots of mock quotes.
+	TrustEmbeddedRoot bool
+}
+
+// DefaultClientOptions verifies quotes against TDX_TRUSTED_ROOT_PATH and checks the policy at RA_TLS_POLICY_PATH, if
+// set. The root embedded in the quotes is only trusted with RA_TLS_TRUST_EMBEDDED_ROOT=true, for servers signing mock
+// quotes: any server can then forge its certificate.
+func DefaultClientOptions() (ClientOptions, error) {
+	quoteVerifier, err := verifier.DefaultVerifier()
+	if err != nil {
+		return ClientOptions{}, err
+	}
+	opts := ClientOptions{
+		Verifier:          quoteVerifier,
+		TrustEmbeddedRoot: os.Getenv("RA_TLS_TRUST_EMBEDDED_ROOT") == "true",
+	}
+	if opts.TrustEmbeddedRoot {
+		log.Printf("[Warning] RA_TLS_TRUST_EMBEDDED_ROOT is set. Server certificates are trusted under the root they carry, so RA-TLS does not authenticate the server.")
+	}
+	if path := os.Getenv("RA_TLS_POLICY_PATH"); path != "" {
+		opts.Policy, err = verifier.LoadPolicy(path)
+		if err != nil {
+			return ClientOptions{}, err
+		}
+	}
+	return opts, nil
+}
+
+// DefaultTransportCredentials returns RA-TLS client credentials with DefaultClientOptions if RA_TLS is true, and
+// insecure credentials otherwise.
+func DefaultTransportCredentials() (credentials.TransportCredentials, error) {
+	if !Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+	opts, err := DefaultClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	return ClientCredentials(opts), nil
+}
+
+// ClientCredentials returns gRPC transport credentials that accept a server certificate only if it carries a valid
+// quote binding its key, and satisfies the policy if one is set. The usual WebPKI checks do not apply.
+func ClientCredentials(opts ClientOptions) credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		// The certificate is self-signed, it is checked by VerifyPeerCertificate instead
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("no server certificate")
+			}
+			cert, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return fmt.Errorf("failed to parse server certificate: %w", err)
+			}
+			_, err = VerifyCertificate(cert, opts)
+			return err
+		},
+		MinVersion: tls.VersionTLS13,
+	})
+}
+
+// VerifyCertificate checks the validity period of an RA-TLS certificate, the binding of its key in the quote, the
+// quote signature chain and the policy. It returns the verified quote.
+func VerifyCertificate(cert *x509.Certificate, opts ClientOptions) (*attestpb.Quote, error) {
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, fmt.Errorf("certificate is not valid at %v", now)
+	}
+
+	var rawQuote []byte
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(QuoteExtensionOID) {
+			rawQuote = ext.Value
+			break
+		}
+	}
+	if rawQuote == nil {
+		return nil, ErrMissingQuote
+	}
+	quote, err := utils.ConvertRawQuoteToQuote(rawQuote)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", verifier.ErrInvalidQuote, err)
+	}
+
+	reportData := ReportData(cert.RawSubjectPublicKeyInfo)
+	if !bytes.Equal(utils.GetTDQuoteBody(quote).GetReportData(), reportData[:]) {
+		return nil, ErrKeyNotBound
+	}
+
+	quoteVerifier := opts.Verifier
+	if quoteVerifier == nil {
+		quoteVerifier = verifier.NewVerifier(verifier.Options{})
+	}
+	if opts.TrustEmbeddedRoot {
+		roots, err := embeddedRoot(quote)
+		if err != nil {
+			return nil, err
+		}
+		quoteVerifier = quoteVerifier.WithTrustedRoots(roots)
+	}
+	if _, err := quoteVerifier.VerifyRawQuote(rawQuote); err != nil {
+		return nil, err
+	}
+
+	if opts.Policy != nil {
+		if err := opts.Policy.Check(quote, nil); err != nil {
+			return nil, err
+		}
+	}
+	return quote, nil
+}
+
+// embeddedRoot returns the last certificate of the PCK chain of a quote as a CertPool.
+func embeddedRoot(quote *attestpb.Quote) (*x509.CertPool, error) {
+	chain := quote.GetSignedData().GetCertificationData().GetQeReportCertificationData().GetPckCertificateChainData().GetPckCertChain()
+	var root *pem.Block
+	for block, rest := pem.Decode(chain); block != nil; block, rest = pem.Decode(rest) {
+		root = block
+	}
+	if root == nil {
+		return nil, errors.New("no PEM certificate in PCK certificate chain")
+	}
+	return verifier.ParseTrustedRoots(pem.EncodeToMemory(root))
+}
@@ -0,0 +1,142 @@
+// Package ratls implements attested TLS: the server certificate is self-signed by an ephemeral key and carries a TDX
+// quote whose REPORTDATA binds that key, so clients can check that they are talking to the measured TD.
+package ratls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+)
+
+// DefaultCertificateValidity is the lifetime of a server certificate. A new key and quote are generated once half of
+// it has elapsed.
+const DefaultCertificateValidity = 24 * time.Hour
+
+// QuoteExtensionOID identifies the certificate extension holding the raw TDX quote, as used by Intel RA-TLS.
+var QuoteExtensionOID = asn1.ObjectIdentifier{1, 2, 840, 113741, 1, 5, 5, 1, 6}
+
+// Enabled reports whether RA_TLS is set to true.
+func Enabled() bool {
+	return os.Getenv("RA_TLS") == "true"
+}
+
+// ReportData returns the REPORTDATA binding a public key: the SHA-512 digest of its DER encoded SubjectPublicKeyInfo.
+func ReportData(subjectPublicKeyInfo []byte) [tdx.ReportDataSize]byte {
+	return sha512.Sum512(subjectPublicKeyInfo)
+}
+
+// NewCertificate generates an ephemeral P-256 key, gets a quote binding it and returns a self-signed certificate
+// carrying the quote in the QuoteExtensionOID extension.
+func NewCertificate(tdxClient tdx.TDXClientInterface, validity time.Duration) (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate TLS key: %w", err)
+	}
+	spki, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal TLS public key: %w", err)
+	}
+
+	quote, err := tdx.GetQuoteForReportData(tdxClient, ReportData(spki))
+	if err != nil {
+		return nil, err
+	}
+	rawQuote, err := utils.ConvertQuoteToRawQuote(quote)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize quote: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:    serial,
+		Subject:         pkix.Name{CommonName: "Lightbulb TDX RA-TLS"},
+		NotBefore:       now.Add(-time.Minute),
+		NotAfter:        now.Add(validity),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		ExtraExtensions: []pkix.Extension{{Id: QuoteExtensionOID, Value: rawQuote}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return &tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
+
+// CertificateSource serves RA-TLS certificates, renewing the key and the quote once half of the validity has elapsed.
+type CertificateSource struct {
+	tdxClient tdx.TDXClientInterface
+	validity  time.Duration
+
+	mu   sync.Mutex
+	cert *tls.Certificate
+}
+
+// NewCertificateSource creates a CertificateSource. The first certificate is generated immediately so that quote
+// failures surface at startup.
+func NewCertificateSource(tdxClient tdx.TDXClientInterface, validity time.Duration) (*CertificateSource, error) {
+	if validity <= 0 {
+		validity = DefaultCertificateValidity
+	}
+	s := &CertificateSource{tdxClient: tdxClient, validity: validity}
+	if _, err := s.GetCertificate(nil); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetCertificate returns the current certificate, renewing it if needed. It matches tls.Config.GetCertificate.
+func (s *CertificateSource) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cert != nil && time.Until(s.cert.Leaf.NotAfter) > s.validity/2 {
+		return s.cert, nil
+	}
+	cert, err := NewCertificate(s.tdxClient, s.validity)
+	if err != nil {
+		if s.cert != nil && time.Now().Before(s.cert.Leaf.NotAfter) {
+			// Keep serving the current certificate until it expires
+			return s.cert, nil
+		}
+		return nil, err
+	}
+	s.cert = cert
+	return cert, nil
+}
+
+// ServerCredentials returns gRPC transport credentials serving RA-TLS certificates from the source.
+func ServerCredentials(source *CertificateSource) credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		GetCertificate: source.GetCertificate,
+		MinVersion:     tls.VersionTLS13,
+	})
+}
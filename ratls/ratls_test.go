@@ -0,0 +1,164 @@
+package ratls
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx"
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+func newMockClient(t *testing.T, mrTd []byte) *tdx.MockTDXClient {
+	tdxtest.ClearEnv(t)
+	return tdx.NewMockTDXClientWithConfig(tdx.MockQuoteConfig{MrTd: mrTd})
+}
+
+// serveAttest serves the attestation service over RA-TLS on a local port and returns its address.
+func serveAttest(t *testing.T, client tdx.TDXClientInterface) string {
+	source, err := NewCertificateSource(client, time.Hour)
+	if err != nil {
+		t.Fatalf("NewCertificateSource failed: %v", err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer(grpc.Creds(ServerCredentials(source)))
+	attestpb.RegisterAttestServiceServer(server, tdx.NewServer(client, verifier.NewVerifier(verifier.Options{})))
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func call(t *testing.T, addr string, opts ClientOptions) error {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(ClientCredentials(opts)))
+	if err != nil {
+		t.Fatalf("grpc.NewClient failed: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = attestpb.NewAttestServiceClient(conn).GetEventLog(ctx, &attestpb.GetEventLogRequest{})
+	return err
+}
+
+func TestHandshake(t *testing.T) {
+	mrTd := bytes.Repeat([]byte{0x11}, 48)
+	addr := serveAttest(t, newMockClient(t, mrTd))
+
+	if err := call(t, addr, ClientOptions{TrustEmbeddedRoot: true}); err != nil {
+		t.Errorf("call with the mock root trusted failed: %v", err)
+	}
+
+	policy := &verifier.Policy{MrTd: []verifier.HexBytes{mrTd}}
+	if err := call(t, addr, ClientOptions{TrustEmbeddedRoot: true, Policy: policy}); err != nil {
+		t.Errorf("call with a matching policy failed: %v", err)
+	}
+
+	policy = &verifier.Policy{MrTd: []verifier.HexBytes{bytes.Repeat([]byte{0x22}, 48)}}
+	if err := call(t, addr, ClientOptions{TrustEmbeddedRoot: true, Policy: policy}); err == nil {
+		t.Error("call with a mismatching policy succeeded")
+	}
+
+	// The mock root is not the Intel root
+	if err := call(t, addr, ClientOptions{}); err == nil {
+		t.Error("call trusting only the Intel root succeeded")
+	}
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	client := newMockClient(t, nil)
+	cert, err := NewCertificate(client, time.Hour)
+	if err != nil {
+		t.Fatalf("NewCertificate failed: %v", err)
+	}
+
+	quote, err := VerifyCertificate(cert.Leaf, ClientOptions{TrustEmbeddedRoot: true})
+	if err != nil {
+		t.Fatalf("VerifyCertificate failed: %v", err)
+	}
+	reportData := ReportData(cert.Leaf.RawSubjectPublicKeyInfo)
+	if !bytes.Equal(quote.GetTdQuoteBody().GetReportData(), reportData[:]) {
+		t.Errorf("REPORTDATA = %x, want %x", quote.GetTdQuoteBody().GetReportData(), reportData)
+	}
+
+	// The same quote presented with another key
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := *cert.Leaf
+	template.PublicKey = &otherKey.PublicKey
+	template.ExtraExtensions = cert.Leaf.Extensions
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &otherKey.PublicKey, otherKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	forged, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	if _, err := VerifyCertificate(forged, ClientOptions{TrustEmbeddedRoot: true}); !errors.Is(err, ErrKeyNotBound) {
+		t.Errorf("VerifyCertificate with another key = %v, want ErrKeyNotBound", err)
+	}
+
+	// A plain self-signed certificate
+	template.ExtraExtensions = nil
+	template.Extensions = nil
+	der, err = x509.CreateCertificate(rand.Reader, &template, &template, &otherKey.PublicKey, otherKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	plain, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	if _, err := VerifyCertificate(plain, ClientOptions{TrustEmbeddedRoot: true}); !errors.Is(err, ErrMissingQuote) {
+		t.Errorf("VerifyCertificate without a quote = %v, want ErrMissingQuote", err)
+	}
+}
+
+func TestDefaultClientOptions(t *testing.T) {
+	addr := serveAttest(t, newMockClient(t, bytes.Repeat([]byte{0x11}, 48)))
+	t.Setenv("TDX_TRUSTED_ROOT_PATH", "")
+	t.Setenv("RA_TLS_POLICY_PATH", "")
+
+	// The environment of the client does not make it trust the root sent by the server
+	t.Setenv("ENV", "MOCK_TDX")
+	t.Setenv("RA_TLS_TRUST_EMBEDDED_ROOT", "")
+	opts, err := DefaultClientOptions()
+	if err != nil {
+		t.Fatalf("DefaultClientOptions failed: %v", err)
+	}
+	if opts.TrustEmbeddedRoot {
+		t.Error("DefaultClientOptions trusts the embedded root without RA_TLS_TRUST_EMBEDDED_ROOT")
+	}
+	if err := call(t, addr, opts); err == nil {
+		t.Error("handshake with a mock quote succeeded under the Intel root")
+	}
+
+	t.Setenv("RA_TLS_TRUST_EMBEDDED_ROOT", "true")
+	if opts, err = DefaultClientOptions(); err != nil {
+		t.Fatalf("DefaultClientOptions failed: %v", err)
+	}
+	if !opts.TrustEmbeddedRoot {
+		t.Error("DefaultClientOptions does not trust the embedded root with RA_TLS_TRUST_EMBEDDED_ROOT=true")
+	}
+	if err := call(t, addr, opts); err != nil {
+		t.Errorf("handshake with RA_TLS_TRUST_EMBEDDED_ROOT=true failed: %v", err)
+	}
+}
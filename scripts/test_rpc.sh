@@ -166,15 +166,32 @@ EOF
   grpcurl -plaintext -d "$JSON_PAYLOAD" $GRPC_URL auction.AuctionService/GetLatestTob
 }
 
+get_challenge() {
+  # Execute gRPC call
+  grpcurl -plaintext -d '{}' $GRPC_URL attest.AttestService/GetChallenge
+}
+
+get_status() {
+  # Execute gRPC call
+  grpcurl -plaintext -d '{}' $GRPC_URL attest.AttestService/GetStatus
+}
+
+# Prints a fresh challenge in base64 format
+new_challenge() {
+  get_challenge | sed -n 's/.*"challenge": "\([^"]*\)".*/\1/p'
+}
+
 get_quote() {
   # Report data in base64 (or hex) format
   REPORT_DATA=${1:-"dGVzdHJlcG9ydGRhdGE="} # "testreportdata"
+  CHALLENGE=$(new_challenge)
 
   # gRPC request payload
   JSON_PAYLOAD=$(
     cat <<EOF
 {
-  "report_data": "$REPORT_DATA"
+  "report_data": "$REPORT_DATA",
+  "challenge": "$CHALLENGE"
 }
 EOF
   )
@@ -187,6 +204,50 @@ EOF
   grpcurl -plaintext -d "$JSON_PAYLOAD" $GRPC_URL attest.AttestService/GetQuote
 }
 
+get_raw_quote() {
+  # Report data in base64 (or hex) format
+  REPORT_DATA=${1:-"dGVzdHJlcG9ydGRhdGE="} # "testreportdata"
+  CHALLENGE=$(new_challenge)
+
+  # gRPC request payload
+  JSON_PAYLOAD=$(
+    cat <<EOF
+{
+  "report_data": "$REPORT_DATA",
+  "challenge": "$CHALLENGE"
+}
+EOF
+  )
+
+  # Print payload for debugging
+  echo "Sending gRPC request to GetRawQuote with payload:"
+  echo "$JSON_PAYLOAD"
+
+  # Execute gRPC call
+  grpcurl -plaintext -d "$JSON_PAYLOAD" $GRPC_URL attest.AttestService/GetRawQuote
+}
+
+get_signing_key() {
+  # Key ID, 0 for the current key
+  KEY_ID=${1:-0}
+
+  # gRPC request payload
+  JSON_PAYLOAD=$(
+    cat <<EOF
+{
+  "key_id": $KEY_ID
+}
+EOF
+  )
+
+  # Print payload for debugging
+  echo "Sending gRPC request to GetSigningKey with payload:"
+  echo "$JSON_PAYLOAD"
+
+  # Execute gRPC call
+  grpcurl -plaintext -d "$JSON_PAYLOAD" $GRPC_URL auction.AuctionService/GetSigningKey
+}
+
 # Main script logic
 case $1 in
 add_auction)
@@ -204,11 +265,23 @@ get_auction_state)
 get_latest_tob)
   get_latest_tob
   ;;
+get_challenge)
+  get_challenge
+  ;;
+get_status)
+  get_status
+  ;;
 get_quote)
   get_quote "$2"
   ;;
+get_raw_quote)
+  get_raw_quote "$2"
+  ;;
+get_signing_key)
+  get_signing_key "$2"
+  ;;
 *)
-  echo "Usage: $0 {add_auction|submit_bids|get_auction_info|get_auction_state|get_latest_tob|get_quote} [arguments]"
+  echo "Usage: $0 {add_auction|submit_bids|get_auction_info|get_auction_state|get_latest_tob|get_challenge|get_status|get_quote|get_raw_quote|get_signing_key} [arguments]"
   exit 1
   ;;
 esac
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net"
 	"os"
@@ -15,7 +16,9 @@ import (
 
 	"github.com/radiusxyz/lightbulb-tdx/auction"
 	"github.com/radiusxyz/lightbulb-tdx/benchmark"
+	"github.com/radiusxyz/lightbulb-tdx/ratls"
 	"github.com/radiusxyz/lightbulb-tdx/tdx"
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
 
 	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
 	auctionpb "github.com/radiusxyz/lightbulb-tdx/proto/auction"
@@ -36,19 +39,110 @@ func main() {
 
 	log.Printf("Server listening on port %s in %s environment", lis.Addr(), os.Getenv("ENV"))
 
-	// Create gRPC server and TDX client
-	grpcServer := grpc.NewServer()
-	tdxClient := tdx.NewTDXClient()
+	// Create TDX client
+	tdxClient, err := tdx.DefaultTDXClient()
+	if err != nil {
+		log.Fatalf("Failed to create TDX client: %v", err)
+	}
+
+	// Background tasks run until shutdown
+	ctx, cancelTasks := context.WithCancel(context.Background())
+	defer cancelTasks()
+
+	// Optionally keep the RTMRs up to date in the background
+	if interval, ok := tdx.DefaultRtmrWatch(); ok {
+		go func() {
+			if err := tdxClient.WatchRtmrs(ctx, interval); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("[Error] RTMR watcher stopped: %v", err)
+			}
+		}()
+	}
+
+	// Create the quote verifier
+	quoteVerifier, err := verifier.DefaultVerifier()
+	if err != nil {
+		log.Fatalf("Failed to create quote verifier: %v", err)
+	}
+	mockClient := tdxClient
+	if faulty, ok := tdxClient.(*tdx.FaultInjectingTDXClient); ok {
+		mockClient = faulty.TDXClientInterface
+	}
+	if _, ok := mockClient.(*tdx.MockTDXClient); ok && os.Getenv("TDX_TRUSTED_ROOT_PATH") == "" {
+		// Trust the throwaway root of the mock quotes
+		signer, err := tdx.DefaultMockQuoteSigner()
+		if err != nil {
+			log.Fatalf("Failed to create mock quote signer: %v", err)
+		}
+		roots, err := verifier.ParseTrustedRoots(signer.RootCertificatePEM())
+		if err != nil {
+			log.Fatalf("Failed to parse mock root certificate: %v", err)
+		}
+		quoteVerifier = quoteVerifier.WithTrustedRoots(roots)
+	}
+
+	// Share cached quotes between the services
+	cachingClient := tdx.NewCachingTDXClient(tdxClient, tdx.DefaultQuoteCacheConfig())
+
+	// Bind the launch configuration to MRCONFIGID, or measure it into an RTMR
+	launchConfig, err := tdx.BindLaunchConfig(cachingClient)
+	if err != nil {
+		log.Fatalf("Failed to bind launch configuration: %v", err)
+	}
+	log.Printf("Launch configuration %s bound by %v", launchConfig.Document, launchConfig.Binding)
+
+	// Check the quote of the TD before serving, refusing to start or disabling auctions if it fails
+	selfAttestConfig, err := tdx.DefaultSelfAttestationConfig()
+	if err != nil {
+		log.Fatalf("Failed to load self-attestation policy: %v", err)
+	}
+	selfAttestation := tdx.SelfAttest(cachingClient, quoteVerifier, selfAttestConfig)
+	switch {
+	case selfAttestation.Passed():
+		log.Printf("Self-attestation: %v", selfAttestation.State)
+	case selfAttestation.Degraded:
+		log.Printf("[Warning] Self-attestation failed, AuctionService is disabled: %v", selfAttestation.Err)
+	default:
+		log.Fatalf("Self-attestation failed: %v", selfAttestation.Err)
+	}
+
+	// Create gRPC server, serving RA-TLS certificates bound to the TD if enabled
+	var serverOpts []grpc.ServerOption
+	if ratls.Enabled() {
+		certSource, err := ratls.NewCertificateSource(cachingClient, ratls.DefaultCertificateValidity)
+		if err != nil {
+			log.Fatalf("Failed to create RA-TLS certificate: %v", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(ratls.ServerCredentials(certSource)))
+		log.Println("Serving RA-TLS")
+	}
+	if selfAttestation.Degraded {
+		serverOpts = append(serverOpts, grpc.UnknownServiceHandler(auction.DisabledServiceHandler(selfAttestation.Err)))
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
 
 	// Create and register services
-	attestServer := tdx.NewServer(tdxClient)
-	auctionServer := auction.NewServer()
+	attestServer := tdx.NewServer(cachingClient, quoteVerifier)
+	attestServer.SetSelfAttestation(selfAttestation)
+	attestServer.SetLaunchConfig(launchConfig)
 	benchmarkServer, err := benchmark.NewServer()
 	if err != nil {
 		log.Fatalf("Failed to create benchmark server: %v", err)
 	}
 	attestpb.RegisterAttestServiceServer(grpcServer, attestServer)
-	auctionpb.RegisterAuctionServiceServer(grpcServer, auctionServer)
+	if !selfAttestation.Degraded {
+		signer, err := auction.NewSigner(cachingClient)
+		if err != nil {
+			log.Fatalf("Failed to create auction signing key: %v", err)
+		}
+		if interval := auction.DefaultSigningKeyRotationInterval(); interval > 0 {
+			go func() {
+				if err := signer.RunRotation(ctx, interval); err != nil && !errors.Is(err, context.Canceled) {
+					log.Printf("[Error] Signing key rotation stopped: %v", err)
+				}
+			}()
+		}
+		auctionpb.RegisterAuctionServiceServer(grpcServer, auction.NewServer(cachingClient, signer))
+	}
 	benchmarkpb.RegisterBenchmarkServiceServer(grpcServer, benchmarkServer)
 
 	// Enable reflection for debugging
@@ -76,6 +170,11 @@ func main() {
 	// Gracefully stop the gRPC server
 	grpcServer.GracefulStop()
 
+	// Stop the background tasks
+	cancelTasks()
+
 	// Perform any additional cleanup tasks if necessary
+	stats := cachingClient.Stats()
+	log.Printf("Quote cache: %d hits, %d misses, %d coalesced", stats.Hits, stats.Misses, stats.Coalesced)
 	log.Println("Server stopped")
 }
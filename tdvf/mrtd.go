@@ -0,0 +1,71 @@
+package tdvf
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"hash"
+
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+)
+
+const (
+	extensionBufferSize = 128   // Size of the buffer hashed by TDH.MEM.PAGE.ADD and of the header of TDH.MR.EXTEND
+	extensionGpaOffset  = 16    // Offset of the guest physical address in the buffer
+	mrExtendChunkSize   = 0x100 // Granularity of TDH.MR.EXTEND
+)
+
+// Mrtd replays the measurement of the image by the TDX module and returns the expected MRTD. Sections are added in
+// image order, one page at a time: TDH.MEM.PAGE.ADD, then TDH.MR.EXTEND of the page content in 256-byte chunks if
+// the section has AttributeMrExtend, which is how QEMU and KVM build the TD. Sections with AttributePageAug are
+// accepted by the firmware later and are not part of MRTD.
+func (img *Image) Mrtd() []byte {
+	h := sha512.New384()
+	var content [mrExtendChunkSize]byte
+	for _, section := range img.Sections {
+		if section.Attributes&AttributePageAug != 0 {
+			continue
+		}
+		for offset := uint64(0); offset < section.MemoryDataSize; offset += PageSize {
+			gpa := section.MemoryAddress + offset
+			extendBuffer(h, "MEM.PAGE.ADD", gpa)
+			if section.Attributes&AttributeMrExtend == 0 {
+				continue
+			}
+			for chunk := uint64(0); chunk < PageSize; chunk += mrExtendChunkSize {
+				extendBuffer(h, "MR.EXTEND", gpa+chunk)
+				img.readChunk(section, offset+chunk, content[:])
+				h.Write(content[:])
+			}
+		}
+	}
+	return h.Sum(nil)
+}
+
+// extendBuffer hashes the buffer of a TDX module operation: its name, padded to the guest physical address, and zeros.
+func extendBuffer(h hash.Hash, operation string, gpa uint64) {
+	var buffer [extensionBufferSize]byte
+	copy(buffer[:], operation)
+	binary.LittleEndian.PutUint64(buffer[extensionGpaOffset:], gpa)
+	h.Write(buffer[:])
+}
+
+// ReferencePolicy adds an MRTD to the mr_td values accepted by a policy, or to a new policy if base is nil. The debug
+// attribute is forbidden in a new policy, since the MRTD of a debug TD says nothing about its state.
+func ReferencePolicy(base *verifier.Policy, mrTd []byte) (*verifier.Policy, error) {
+	policy := base
+	if policy == nil {
+		policy = &verifier.Policy{TdAttributes: &verifier.TdAttributesPolicy{Forbidden: []string{"debug"}}}
+	}
+	for _, value := range policy.MrTd {
+		if bytes.Equal(value, mrTd) {
+			return policy, nil
+		}
+	}
+	policy.MrTd = append(policy.MrTd, mrTd)
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid reference policy: %w", err)
+	}
+	return policy, nil
+}
@@ -0,0 +1,249 @@
+package tdvf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	descriptorSignature = "TDVF" // Signature of the TDX metadata descriptor
+	descriptorVersion   = 1      // Supported version of the TDX metadata descriptor
+	descriptorSize      = 16     // Size of the descriptor header
+	sectionSize         = 32     // Size of a section entry
+
+	legacyMetadataOffset = 0x20 // Offset from the end of the image of the legacy metadata pointer
+	tableFooterOffset    = 0x30 // Offset from the end of the image of the GUIDed table footer
+	guidSize             = 16   // Size of a GUID
+	tableEntryHeaderSize = 2 + guidSize
+
+	PageSize = 0x1000 // Granularity of TDH.MEM.PAGE.ADD
+
+	maxMeasuredSize = 1 << 30 // Largest measured section, far above the firmware volumes and memory of TDVF
+)
+
+// SectionType is the type of a TDVF section.
+type SectionType uint32
+
+const (
+	SectionBfv          SectionType = 0 // Boot firmware volume, the firmware code
+	SectionCfv          SectionType = 1 // Configuration firmware volume, the UEFI variables
+	SectionTdHob        SectionType = 2 // TD HOB built by the VMM
+	SectionTempMem      SectionType = 3 // Temporary memory used during early boot
+	SectionPermMem      SectionType = 4 // Permanent memory accepted by the firmware
+	SectionPayload      SectionType = 5 // Payload loaded by the VMM, such as a kernel
+	SectionPayloadParam SectionType = 6 // Parameters of the payload, such as a command line
+)
+
+func (t SectionType) String() string {
+	switch t {
+	case SectionBfv:
+		return "BFV"
+	case SectionCfv:
+		return "CFV"
+	case SectionTdHob:
+		return "TD_HOB"
+	case SectionTempMem:
+		return "TEMP_MEM"
+	case SectionPermMem:
+		return "PERM_MEM"
+	case SectionPayload:
+		return "PAYLOAD"
+	case SectionPayloadParam:
+		return "PAYLOAD_PARAM"
+	default:
+		return fmt.Sprintf("SectionType(%d)", uint32(t))
+	}
+}
+
+// Section attributes.
+const (
+	AttributeMrExtend = 1 << 0 // The section content is measured with TDH.MR.EXTEND
+	AttributePageAug  = 1 << 1 // The section is added by the firmware with TDH.MEM.PAGE.AUG and not measured
+)
+
+var (
+	// GUID of the footer of the GUIDed table at the end of OVMF images.
+	tableFooterGuid = mustGuid("96b582de-1fb2-45f7-baea-a366c55a082d")
+	// GUID of the GUIDed table entry holding the offset of the TDX metadata.
+	metadataOffsetGuid = mustGuid("e47a6535-984a-4798-865e-4685a7bf8ec2")
+)
+
+// ErrNoMetadata is returned when a firmware image has no TDX metadata, such as OVMF built without TDX support.
+var ErrNoMetadata = errors.New("no TDX metadata in firmware image")
+
+// Section describes a region of TD memory initialized by the VMM from the firmware image.
+type Section struct {
+	DataOffset     uint32      // Offset of the section data in the image
+	RawDataSize    uint32      // Size of the section data in the image
+	MemoryAddress  uint64      // Guest physical address of the region
+	MemoryDataSize uint64      // Size of the region, a multiple of PageSize
+	Type           SectionType // Section type
+	Attributes     uint32      // AttributeMrExtend and AttributePageAug bits
+}
+
+func (s Section) String() string {
+	return fmt.Sprintf("%s at 0x%x-0x%x (data 0x%x+0x%x, attributes 0x%x)", s.Type, s.MemoryAddress,
+		s.MemoryAddress+s.MemoryDataSize, s.DataOffset, s.RawDataSize, s.Attributes)
+}
+
+// Image is a TDVF firmware image, such as OVMF.fd built with TDX support.
+type Image struct {
+	Data     []byte    // Content of the image
+	Sections []Section // Sections of the TDX metadata, in image order
+}
+
+// LoadImage reads and parses a firmware image.
+func LoadImage(path string) (*Image, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read firmware: %w", err)
+	}
+	image, err := ParseImage(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid firmware %s: %w", path, err)
+	}
+	return image, nil
+}
+
+// ParseImage locates and parses the TDX metadata of a firmware image. The metadata is found through the GUIDed table
+// at the end of OVMF images, or through the legacy pointer 0x20 bytes before the end of the image.
+func ParseImage(data []byte) (*Image, error) {
+	offset, err := metadataOffset(data)
+	if err != nil {
+		return nil, err
+	}
+	if offset+descriptorSize > len(data) || string(data[offset:offset+4]) != descriptorSignature {
+		return nil, ErrNoMetadata
+	}
+
+	length := binary.LittleEndian.Uint32(data[offset+4:])
+	version := binary.LittleEndian.Uint32(data[offset+8:])
+	count := binary.LittleEndian.Uint32(data[offset+12:])
+	if version != descriptorVersion {
+		return nil, fmt.Errorf("unsupported TDX metadata version %d", version)
+	}
+	if uint64(length) != descriptorSize+uint64(count)*sectionSize || offset+int(length) > len(data) {
+		return nil, fmt.Errorf("invalid TDX metadata length %d for %d sections", length, count)
+	}
+
+	image := &Image{Data: data, Sections: make([]Section, count)}
+	for i := range image.Sections {
+		entry := data[offset+descriptorSize+i*sectionSize:]
+		section := Section{
+			DataOffset:     binary.LittleEndian.Uint32(entry[0:]),
+			RawDataSize:    binary.LittleEndian.Uint32(entry[4:]),
+			MemoryAddress:  binary.LittleEndian.Uint64(entry[8:]),
+			MemoryDataSize: binary.LittleEndian.Uint64(entry[16:]),
+			Type:           SectionType(binary.LittleEndian.Uint32(entry[24:])),
+			Attributes:     binary.LittleEndian.Uint32(entry[28:]),
+		}
+		if err := section.validate(len(data)); err != nil {
+			return nil, fmt.Errorf("section %d: %w", i, err)
+		}
+		image.Sections[i] = section
+	}
+	return image, nil
+}
+
+// readChunk fills chunk with the section memory at offset as the VMM initializes it: the raw data of the image
+// followed by zeros.
+func (img *Image) readChunk(section Section, offset uint64, chunk []byte) {
+	n := 0
+	if offset < uint64(section.RawDataSize) {
+		n = copy(chunk, img.Data[uint64(section.DataOffset)+offset:uint64(section.DataOffset)+uint64(section.RawDataSize)])
+	}
+	clear(chunk[n:])
+}
+
+func (s Section) validate(imageSize int) error {
+	if s.MemoryAddress%PageSize != 0 || s.MemoryDataSize%PageSize != 0 {
+		return fmt.Errorf("%s is not page aligned", s)
+	}
+	if s.MemoryAddress+s.MemoryDataSize < s.MemoryAddress {
+		return fmt.Errorf("%s wraps around the address space", s)
+	}
+	if s.Attributes&AttributePageAug == 0 && s.MemoryDataSize > maxMeasuredSize {
+		return fmt.Errorf("%s is too large to be measured", s)
+	}
+	if uint64(s.RawDataSize) > s.MemoryDataSize {
+		return fmt.Errorf("%s has more data than memory", s)
+	}
+	if uint64(s.DataOffset)+uint64(s.RawDataSize) > uint64(imageSize) {
+		return fmt.Errorf("%s has data beyond the end of the image", s)
+	}
+	if s.Attributes&AttributeMrExtend != 0 && s.Attributes&AttributePageAug != 0 {
+		return fmt.Errorf("%s is both extended and augmented", s)
+	}
+	return nil
+}
+
+// metadataOffset returns the offset of the TDX metadata descriptor in the image.
+func metadataOffset(data []byte) (int, error) {
+	if len(data) < tableFooterOffset {
+		return 0, ErrNoMetadata
+	}
+	if entry, ok := findTableEntry(data, metadataOffsetGuid); ok {
+		if len(entry) < 4 {
+			return 0, fmt.Errorf("invalid TDX metadata offset entry of %d bytes", len(entry))
+		}
+		// The entry holds the offset of the descriptor from the end of the image
+		fromEnd := int(binary.LittleEndian.Uint32(entry))
+		if fromEnd > len(data) {
+			return 0, fmt.Errorf("TDX metadata offset 0x%x beyond the start of the image", fromEnd)
+		}
+		return len(data) - fromEnd, nil
+	}
+	// The legacy pointer holds the offset of the descriptor from the start of the image
+	offset := int(binary.LittleEndian.Uint32(data[len(data)-legacyMetadataOffset:]))
+	if offset == 0 || offset >= len(data) {
+		return 0, ErrNoMetadata
+	}
+	return offset, nil
+}
+
+// findTableEntry returns the data of an entry of the GUIDed table of an OVMF image. The table ends with its length
+// and footer GUID, and each entry is its data followed by its length and GUID.
+func findTableEntry(data []byte, guid []byte) ([]byte, bool) {
+	footer := len(data) - tableFooterOffset
+	if footer < 2 || !bytes.Equal(data[footer:footer+guidSize], tableFooterGuid) {
+		return nil, false
+	}
+	tableSize := int(binary.LittleEndian.Uint16(data[footer-2:]))
+	if tableSize < tableEntryHeaderSize || tableSize > footer+guidSize {
+		return nil, false
+	}
+	table := data[footer+guidSize-tableSize : footer-2]
+	for end := len(table); end >= tableEntryHeaderSize; {
+		size := int(binary.LittleEndian.Uint16(table[end-tableEntryHeaderSize:]))
+		if size < tableEntryHeaderSize || size > end {
+			return nil, false
+		}
+		if bytes.Equal(table[end-guidSize:end], guid) {
+			return table[end-size : end-tableEntryHeaderSize], true
+		}
+		end -= size
+	}
+	return nil, false
+}
+
+// mustGuid encodes a GUID in its mixed-endian byte order.
+func mustGuid(s string) []byte {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil || len(b) != guidSize {
+		panic("invalid GUID " + s)
+	}
+	reverse := func(p []byte) {
+		for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+			p[i], p[j] = p[j], p[i]
+		}
+	}
+	reverse(b[0:4])
+	reverse(b[4:6])
+	reverse(b[6:8])
+	return b
+}
@@ -0,0 +1,247 @@
+package tdvf
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+)
+
+const (
+	testImageSize        = 0x3000
+	testDescriptorOffset = 0x1800
+)
+
+// testSections is the layout of a small OVMF-like image: the firmware volume is extended, the variables, HOB and
+// temporary memory are only added, and the permanent memory is accepted by the firmware.
+var testSections = []Section{
+	{DataOffset: 0x1000, RawDataSize: 0x2000, MemoryAddress: 0xffffe000, MemoryDataSize: 0x2000, Type: SectionBfv, Attributes: AttributeMrExtend},
+	{DataOffset: 0, RawDataSize: 0x1000, MemoryAddress: 0xffc00000, MemoryDataSize: 0x1000, Type: SectionCfv},
+	{MemoryAddress: 0x809000, MemoryDataSize: 0x1000, Type: SectionTdHob},
+	{MemoryAddress: 0x800000, MemoryDataSize: 0x2000, Type: SectionTempMem},
+	{MemoryAddress: 0x10000000, MemoryDataSize: 0x1000, Type: SectionPermMem, Attributes: AttributePageAug},
+}
+
+// buildImage lays out an image with the TDX metadata of the sections, found through the GUIDed table or the legacy
+// pointer.
+func buildImage(t *testing.T, sections []Section, legacy bool) []byte {
+	t.Helper()
+	data := make([]byte, testImageSize)
+	for i := range data[:testDescriptorOffset] {
+		data[i] = byte(i * 7)
+	}
+
+	descriptor := data[testDescriptorOffset:]
+	copy(descriptor, descriptorSignature)
+	binary.LittleEndian.PutUint32(descriptor[4:], uint32(descriptorSize+len(sections)*sectionSize))
+	binary.LittleEndian.PutUint32(descriptor[8:], descriptorVersion)
+	binary.LittleEndian.PutUint32(descriptor[12:], uint32(len(sections)))
+	for i, section := range sections {
+		entry := descriptor[descriptorSize+i*sectionSize:]
+		binary.LittleEndian.PutUint32(entry[0:], section.DataOffset)
+		binary.LittleEndian.PutUint32(entry[4:], section.RawDataSize)
+		binary.LittleEndian.PutUint64(entry[8:], section.MemoryAddress)
+		binary.LittleEndian.PutUint64(entry[16:], section.MemoryDataSize)
+		binary.LittleEndian.PutUint32(entry[24:], uint32(section.Type))
+		binary.LittleEndian.PutUint32(entry[28:], section.Attributes)
+	}
+
+	if legacy {
+		binary.LittleEndian.PutUint32(data[len(data)-legacyMetadataOffset:], testDescriptorOffset)
+		return data
+	}
+	// Table: an unrelated entry, the metadata offset entry, the table length and the footer GUID
+	footer := len(data) - tableFooterOffset
+	copy(data[footer:], tableFooterGuid)
+	metadataEntry := footer - 2 - 4 - tableEntryHeaderSize
+	binary.LittleEndian.PutUint32(data[metadataEntry:], uint32(len(data)-testDescriptorOffset))
+	binary.LittleEndian.PutUint16(data[metadataEntry+4:], 4+tableEntryHeaderSize)
+	copy(data[metadataEntry+6:], metadataOffsetGuid)
+	otherEntry := metadataEntry - tableEntryHeaderSize
+	binary.LittleEndian.PutUint16(data[otherEntry:], tableEntryHeaderSize)
+	copy(data[otherEntry+2:], mustGuid("7255371f-3a3b-4b04-927b-1da6efa8d454"))
+	binary.LittleEndian.PutUint16(data[footer-2:], uint16(footer+guidSize-otherEntry))
+	return data
+}
+
+func TestParseImage(t *testing.T) {
+	for _, legacy := range []bool{false, true} {
+		image, err := ParseImage(buildImage(t, testSections, legacy))
+		if err != nil {
+			t.Fatalf("ParseImage (legacy %v) failed: %v", legacy, err)
+		}
+		if len(image.Sections) != len(testSections) {
+			t.Fatalf("ParseImage (legacy %v) found %d sections, want %d", legacy, len(image.Sections), len(testSections))
+		}
+		for i, section := range image.Sections {
+			if section != testSections[i] {
+				t.Errorf("section %d = %v, want %v", i, section, testSections[i])
+			}
+		}
+	}
+}
+
+func TestParseImageErrors(t *testing.T) {
+	if _, err := ParseImage(make([]byte, testImageSize)); !errors.Is(err, ErrNoMetadata) {
+		t.Errorf("ParseImage of an image without metadata = %v, want ErrNoMetadata", err)
+	}
+	// The footer GUID of an image too small to hold the table length
+	for _, size := range []int{tableFooterOffset, tableFooterOffset + 1} {
+		tiny := make([]byte, size)
+		copy(tiny, tableFooterGuid)
+		if _, err := ParseImage(tiny); !errors.Is(err, ErrNoMetadata) {
+			t.Errorf("ParseImage of a %d byte image with a table footer = %v, want ErrNoMetadata", size, err)
+		}
+	}
+	unaligned := append([]Section{}, testSections...)
+	unaligned[2].MemoryDataSize = 0x800
+	if _, err := ParseImage(buildImage(t, unaligned, false)); err == nil {
+		t.Error("ParseImage accepted an unaligned section")
+	}
+	beyondEnd := append([]Section{}, testSections...)
+	beyondEnd[0].MemoryDataSize, beyondEnd[0].RawDataSize = 0x3000, 0x3000
+	if _, err := ParseImage(buildImage(t, beyondEnd, false)); err == nil {
+		t.Error("ParseImage accepted a section beyond the end of the image")
+	}
+	tooLarge := append([]Section{}, testSections...)
+	tooLarge[3].MemoryDataSize = 1 << 40
+	if _, err := ParseImage(buildImage(t, tooLarge, false)); err == nil {
+		t.Error("ParseImage accepted a measured section of 1 TiB")
+	}
+	wrapping := append([]Section{}, testSections...)
+	wrapping[4].MemoryAddress = 1<<64 - PageSize
+	wrapping[4].MemoryDataSize = 2 * PageSize
+	if _, err := ParseImage(buildImage(t, wrapping, false)); err == nil {
+		t.Error("ParseImage accepted a section wrapping around the address space")
+	}
+}
+
+func TestMrtd(t *testing.T) {
+	data := buildImage(t, testSections, false)
+	image, err := ParseImage(data)
+	if err != nil {
+		t.Fatalf("ParseImage failed: %v", err)
+	}
+
+	// The TDX module hashes a 128-byte buffer for each page added and each 256-byte chunk extended
+	var log bytes.Buffer
+	operation := func(name string, gpa uint64) {
+		buffer := make([]byte, 128)
+		copy(buffer, name)
+		binary.LittleEndian.PutUint64(buffer[16:], gpa)
+		log.Write(buffer)
+	}
+	for gpa := uint64(0xffffe000); gpa < 0x100000000; gpa += 0x1000 {
+		operation("MEM.PAGE.ADD", gpa)
+		for chunk := uint64(0); chunk < 0x1000; chunk += 0x100 {
+			operation("MR.EXTEND", gpa+chunk)
+			offset := 0x1000 + gpa - 0xffffe000 + chunk
+			log.Write(data[offset : offset+0x100])
+		}
+	}
+	for _, gpa := range []uint64{0xffc00000, 0x809000, 0x800000, 0x801000} {
+		operation("MEM.PAGE.ADD", gpa)
+	}
+	want := sha512.Sum384(log.Bytes())
+	if mrtd := image.Mrtd(); !bytes.Equal(mrtd, want[:]) {
+		t.Errorf("Mrtd = %x, want %x", mrtd, want)
+	}
+
+	// Only the content of extended sections is measured
+	data[0x10]++
+	if mrtd := image.Mrtd(); !bytes.Equal(mrtd, want[:]) {
+		t.Errorf("Mrtd changed with the content of a section that is not extended: %x", mrtd)
+	}
+	data[0x1010]++
+	if mrtd := image.Mrtd(); bytes.Equal(mrtd, want[:]) {
+		t.Error("Mrtd did not change with the content of an extended section")
+	}
+}
+
+// TestMrtdKnownAnswers checks the MRTD of every firmware image of testdata against the hex value of the .mrtd file
+// next to it. fixture.fd lays out a partially backed extended section and a TD HOB, and its MRTD was computed apart
+// from this package; the MRTD of a TD booted from an OVMF build can be added the same way.
+func TestMrtdKnownAnswers(t *testing.T) {
+	paths, err := filepath.Glob("testdata/*.fd")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no firmware image in testdata: %v", err)
+	}
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			image, err := LoadImage(path)
+			if err != nil {
+				t.Fatalf("LoadImage failed: %v", err)
+			}
+			expected, err := os.ReadFile(strings.TrimSuffix(path, ".fd") + ".mrtd")
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := hex.DecodeString(strings.TrimSpace(string(expected)))
+			if err != nil {
+				t.Fatalf("invalid MRTD of %s: %v", path, err)
+			}
+			if mrtd := image.Mrtd(); !bytes.Equal(mrtd, want) {
+				t.Errorf("Mrtd = %x, want %x", mrtd, want)
+			}
+		})
+	}
+}
+
+func TestReadChunk(t *testing.T) {
+	image := &Image{Data: buildImage(t, testSections, false)}
+	section := Section{DataOffset: 0x1000, RawDataSize: 0x180, MemoryDataSize: PageSize, Attributes: AttributeMrExtend}
+
+	// The raw data ends within the second chunk, and the memory after it is zero
+	chunk := bytes.Repeat([]byte{0xff}, mrExtendChunkSize)
+	image.readChunk(section, 0x100, chunk)
+	if !bytes.Equal(chunk[:0x80], image.Data[0x1100:0x1180]) || !bytes.Equal(chunk[0x80:], make([]byte, 0x80)) {
+		t.Errorf("chunk at 0x100 = %x, want the end of the raw data followed by zeros", chunk)
+	}
+	image.readChunk(section, 0x200, chunk)
+	if !bytes.Equal(chunk, make([]byte, mrExtendChunkSize)) {
+		t.Errorf("chunk at 0x200 = %x, want zeros", chunk)
+	}
+}
+
+func TestReferencePolicy(t *testing.T) {
+	image, err := ParseImage(buildImage(t, testSections, false))
+	if err != nil {
+		t.Fatalf("ParseImage failed: %v", err)
+	}
+	mrtd := image.Mrtd()
+	policy, err := ReferencePolicy(nil, mrtd)
+	if err != nil {
+		t.Fatalf("ReferencePolicy failed: %v", err)
+	}
+	data, err := policy.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	parsed, err := verifier.ParsePolicy(data)
+	if err != nil {
+		t.Fatalf("ParsePolicy of the reference policy failed: %v\n%s", err, data)
+	}
+	if len(parsed.MrTd) != 1 || !bytes.Equal(parsed.MrTd[0], mrtd) {
+		t.Errorf("reference policy accepts mr_td %x, want %x", parsed.MrTd, mrtd)
+	}
+
+	// Adding to an existing policy keeps its values and does not repeat the MRTD
+	other := bytes.Repeat([]byte{0x01}, sha512.Size384)
+	policy, err = ReferencePolicy(&verifier.Policy{MrTd: []verifier.HexBytes{other}}, mrtd)
+	if err != nil {
+		t.Fatalf("ReferencePolicy failed: %v", err)
+	}
+	if policy, err = ReferencePolicy(policy, mrtd); err != nil {
+		t.Fatalf("ReferencePolicy failed: %v", err)
+	}
+	if len(policy.MrTd) != 2 || !bytes.Equal(policy.MrTd[0], other) || !bytes.Equal(policy.MrTd[1], mrtd) {
+		t.Errorf("reference policy accepts mr_td %x, want %x and %x", policy.MrTd, other, mrtd)
+	}
+}
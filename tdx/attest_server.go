@@ -2,37 +2,265 @@ package tdx
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/radiusxyz/lightbulb-tdx/collateral"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
 
 	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
 )
 
 type Server struct {
     attestpb.UnimplementedAttestServiceServer
-    tdxClient TDXClientInterface
+    tdxClient  TDXClientInterface
+    verifier   *verifier.Verifier
+    challenges *ChallengeStore
+
+    selfAttestation atomic.Pointer[SelfAttestation]
+    launchConfig    atomic.Pointer[LaunchConfig]
 }
 
-// NewServer creates a new server with a TDXClientInterface.
-func NewServer(client TDXClientInterface) *Server {
+// NewServer creates a new server with a TDXClientInterface and a quote verifier. Challenges are configured by
+// DefaultChallengeConfig.
+func NewServer(client TDXClientInterface, v *verifier.Verifier) *Server {
     return &Server{
-        tdxClient: client,
+        tdxClient:  client,
+        verifier:   v,
+        challenges: NewChallengeStore(DefaultChallengeConfig()),
     }
 }
 
+// SetSelfAttestation records the outcome of the startup self-attestation, reported by GetStatus.
+func (s *Server) SetSelfAttestation(selfAttestation *SelfAttestation) {
+	s.selfAttestation.Store(selfAttestation)
+}
+
+// SetLaunchConfig records the launch configuration, returned with quotes and by GetStatus.
+func (s *Server) SetLaunchConfig(launchConfig *LaunchConfig) {
+	s.launchConfig.Store(launchConfig)
+}
+
+// GetStatus reports the outcome of the startup self-attestation, whether the server runs degraded, its launch
+// configuration and the counters of its quote cache.
+func (s *Server) GetStatus(ctx context.Context, req *attestpb.GetStatusRequest) (*attestpb.GetStatusResponse, error) {
+	resp := ConvertSelfAttestationToProtobuf(s.selfAttestation.Load())
+	resp.LaunchConfig = ConvertLaunchConfigToProtobuf(s.launchConfig.Load())
+	if cache, ok := s.tdxClient.(*CachingTDXClient); ok {
+		resp.QuoteCache = ConvertQuoteCacheStatsToProtobuf(cache.Stats())
+	}
+	return resp, nil
+}
+
+// GetChallenge issues a single-use challenge that GetQuote and GetRawQuote bind into REPORTDATA.
+func (s *Server) GetChallenge(ctx context.Context, req *attestpb.GetChallengeRequest) (*attestpb.GetChallengeResponse, error) {
+	challenge, expiresAt, err := s.challenges.Issue()
+	if errors.Is(err, ErrTooManyChallenges) {
+		return nil, status.Errorf(codes.ResourceExhausted, "%v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to issue challenge: %v", err)
+	}
+
+	return &attestpb.GetChallengeResponse{
+		Challenge: challenge,
+		ExpiresAt: expiresAt.UnixMilli(),
+	}, nil
+}
+
 func (s *Server) GetQuote(ctx context.Context, req *attestpb.GetQuoteRequest) (*attestpb.GetQuoteResponse, error) {
-	// Get the quote
-	quoteProto, err := GetQuote(s.tdxClient)
+	// Get the quote bound to the challenge and the caller supplied report data
+	quoteProto, binding, err := s.getChallengedQuote(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get quote: %v", err)
+		return nil, err
 	}
 
     // Debug: Print RTMR values
-    for i, rtmr := range quoteProto.TdQuoteBody.Rtmrs {
+    for i, rtmr := range utils.GetTDQuoteBody(quoteProto).GetRtmrs() {
         log.Printf("rtmr[%d]: %x", i, rtmr)
     }
 
-    return &attestpb.GetQuoteResponse{
-        Quote: quoteProto,
-    }, nil
-}
\ No newline at end of file
+    resp := &attestpb.GetQuoteResponse{
+        Quote:             quoteProto,
+        ReportDataBinding: binding,
+        LaunchConfig:      ConvertLaunchConfigToProtobuf(s.launchConfig.Load()),
+    }
+    if req.GetIncludeCollateral() {
+        if resp.Collateral, err = s.quoteCollateral(quoteProto); err != nil {
+            return nil, err
+        }
+    }
+    return resp, nil
+}
+
+// GetRawQuote returns the quote bound to the caller supplied report data in the wire format of Intel DCAP.
+func (s *Server) GetRawQuote(ctx context.Context, req *attestpb.GetQuoteRequest) (*attestpb.GetRawQuoteResponse, error) {
+	quoteProto, binding, err := s.getChallengedQuote(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rawQuote, err := utils.ConvertQuoteToRawQuote(quoteProto)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to serialize quote: %v", err)
+	}
+
+	resp := &attestpb.GetRawQuoteResponse{
+		RawQuote:          rawQuote,
+		ReportDataBinding: binding,
+		LaunchConfig:      ConvertLaunchConfigToProtobuf(s.launchConfig.Load()),
+	}
+	if req.GetIncludeCollateral() {
+		if resp.Collateral, err = s.quoteCollateral(quoteProto); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// getChallengedQuote redeems the challenge of the request and returns a quote bound to it, with gRPC status errors.
+func (s *Server) getChallengedQuote(req *attestpb.GetQuoteRequest) (*attestpb.Quote, *attestpb.ReportDataBinding, error) {
+	if err := validateUserReportData(req.GetReportData()); err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid report data: %v", err)
+	}
+	if len(req.GetChallenge()) == 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "missing challenge, request one with GetChallenge")
+	}
+	// Fail before the challenge is spent if collateral cannot be bundled
+	if req.GetIncludeCollateral() && s.verifier.Collateral() == nil {
+		return nil, nil, status.Error(codes.FailedPrecondition, "no collateral source configured")
+	}
+	if err := s.challenges.Redeem(req.GetChallenge()); err != nil {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "invalid challenge: %v", err)
+	}
+
+	quoteProto, binding, err := GetQuoteWithChallenge(s.tdxClient, req.GetChallenge(), req.GetReportData())
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get quote: %v", err)
+	}
+	return quoteProto, binding, nil
+}
+
+// quoteCollateral fetches the collateral of the platform that produced the quote from the collateral source.
+func (s *Server) quoteCollateral(quote *attestpb.Quote) (*attestpb.Collateral, error) {
+	bundle, err := collateral.ForQuote(s.verifier.Collateral(), utils.ConvertQuoteToQuoteV4(quote))
+	if errors.Is(err, collateral.ErrNoPckCertificate) {
+		return nil, status.Errorf(codes.FailedPrecondition, "no collateral for quote: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to get collateral: %v", err)
+	}
+	return collateral.ConvertBundleToProtobuf(bundle), nil
+}
+
+// VerifyQuote verifies the signature chain of a quote against the trusted root, and against the collateral of the
+// request or of the configured collateral source.
+func (s *Server) VerifyQuote(ctx context.Context, req *attestpb.VerifyQuoteRequest) (*attestpb.VerifyQuoteResponse, error) {
+	v := s.verifier
+	if len(req.GetTrustedRoot()) > 0 {
+		roots, err := verifier.ParseTrustedRoots(req.GetTrustedRoot())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid trusted root: %v", err)
+		}
+		v = v.WithTrustedRoots(roots)
+	}
+	if bundle := collateral.ConvertProtobufToBundle(req.GetCollateral()); bundle != nil {
+		v = v.WithCollateral(bundle)
+	}
+
+	var result *verifier.Result
+	var err error
+	switch evidence := req.GetEvidence().(type) {
+	case *attestpb.VerifyQuoteRequest_Quote:
+		result, err = v.VerifyQuote(evidence.Quote)
+	case *attestpb.VerifyQuoteRequest_RawQuote:
+		result, err = v.VerifyRawQuote(evidence.RawQuote)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "missing quote")
+	}
+	if errors.Is(err, verifier.ErrInvalidQuote) {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err != nil && !errors.Is(err, verifier.ErrVerificationFailed) {
+		return nil, status.Errorf(codes.Internal, "failed to verify quote: %v", err)
+	}
+
+	return verifier.ConvertResultToProtobuf(result), nil
+}
+
+// GetEventLog returns the events extended into the RTMRs, so that verifiers can replay them against the quote.
+func (s *Server) GetEventLog(ctx context.Context, req *attestpb.GetEventLogRequest) (*attestpb.GetEventLogResponse, error) {
+	page, err := s.tdxClient.GetEventLog(req.GetStartSequence(), int(req.GetPageSize()))
+	if errors.Is(err, ErrSequenceOutOfRange) {
+		return nil, status.Errorf(codes.OutOfRange, "%v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get event log: %v", err)
+	}
+
+	return ConvertEventLogPageToProtobuf(page), nil
+}
+
+// WatchMeasurements streams the events extended into the RTMRs from the requested sequence number, then every new
+// event as it is recorded. Sources are also polled at DefaultWatchInterval, so that events appear without RTMR_WATCH.
+func (s *Server) WatchMeasurements(req *attestpb.WatchMeasurementsRequest, stream attestpb.AttestService_WatchMeasurementsServer) error {
+	if err := validateUserReportData(req.GetReportData()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid report data: %v", err)
+	}
+
+	ticker := time.NewTicker(DefaultWatchInterval)
+	defer ticker.Stop()
+
+	next := req.GetStartSequence()
+	for {
+		// Get the channel before reading the log, so that no event recorded in between is missed
+		recorded := s.tdxClient.EventRecorded()
+		page, err := s.tdxClient.GetEventLog(next, 0)
+		if errors.Is(err, ErrSequenceOutOfRange) {
+			return status.Errorf(codes.OutOfRange, "%v", err)
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get event log: %v", err)
+		}
+
+		if err := s.sendMeasurementUpdates(stream, page.Events, req); err != nil {
+			return err
+		}
+		next = page.NextSequence()
+
+		select {
+		case <-recorded:
+		case <-ticker.C:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// sendMeasurementUpdates sends an update for every event, attaching a quote to the last one if requested.
+func (s *Server) sendMeasurementUpdates(stream attestpb.AttestService_WatchMeasurementsServer, events []MeasurementEvent, req *attestpb.WatchMeasurementsRequest) error {
+	for i, event := range events {
+		update := &attestpb.MeasurementUpdate{
+			Event:     ConvertEventToProtobuf(event),
+			RtmrValue: event.RtmrValue,
+		}
+		if req.GetIncludeQuote() && i == len(events)-1 {
+			quote, binding, err := GetQuoteWithReportData(s.tdxClient, req.GetReportData())
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to get quote: %v", err)
+			}
+			update.Quote = quote
+			update.ReportDataBinding = binding
+		}
+		if err := stream.Send(update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package tdx
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/radiusxyz/lightbulb-tdx/collateral"
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+// updateStream collects the updates sent by WatchMeasurements.
+type updateStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates chan *attestpb.MeasurementUpdate
+}
+
+func (s *updateStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *updateStream) Send(update *attestpb.MeasurementUpdate) error {
+	s.updates <- update
+	return nil
+}
+
+func (s *updateStream) next(t *testing.T) *attestpb.MeasurementUpdate {
+	t.Helper()
+	select {
+	case update := <-s.updates:
+		return update
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a measurement update")
+		return nil
+	}
+}
+
+func TestWatchMeasurements(t *testing.T) {
+	tdxtest.ClearEnv(t)
+	t.Setenv("IMA_LOG_PATH", "testdata/ima/ascii_runtime_measurements")
+	client := NewMockTDXClient()
+	server := NewServer(client, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &updateStream{ctx: ctx, updates: make(chan *attestpb.MeasurementUpdate, 16)}
+	done := make(chan error, 1)
+	go func() {
+		done <- server.WatchMeasurements(&attestpb.WatchMeasurementsRequest{StartSequence: 4, IncludeQuote: true}, stream)
+	}()
+
+	// Resume after the first four events of the IMA log
+	for _, want := range []uint64{4, 5} {
+		update := stream.next(t)
+		if update.GetEvent().GetSequence() != want {
+			t.Fatalf("sequence = %d, want %d", update.GetEvent().GetSequence(), want)
+		}
+		if len(update.GetRtmrValue()) != RtmrSize {
+			t.Errorf("RTMR value is %d bytes, want %d", len(update.GetRtmrValue()), RtmrSize)
+		}
+		if (update.GetQuote() != nil) != (want == 5) {
+			t.Errorf("update %d has quote %v, want a quote on the last update of the batch only", want, update.GetQuote() != nil)
+		}
+	}
+
+	// A new event is emitted as soon as it is recorded
+	if err := client.ExtendRtmr(3, "app", []byte("event")); err != nil {
+		t.Fatalf("ExtendRtmr failed: %v", err)
+	}
+	update := stream.next(t)
+	rtmrs, err := client.GetRtmrs()
+	if err != nil {
+		t.Fatalf("GetRtmrs failed: %v", err)
+	}
+	if update.GetEvent().GetSequence() != 6 || update.GetEvent().GetRtmrIndex() != 3 || string(update.GetEvent().GetEventData()) != "event" {
+		t.Errorf("update = %v, want event 6 extended into RTMR[3]", update.GetEvent())
+	}
+	if !bytes.Equal(update.GetRtmrValue(), rtmrs[3]) {
+		t.Errorf("RTMR value = %x, want %x", update.GetRtmrValue(), rtmrs[3])
+	}
+	if quoteRtmr := update.GetQuote().GetTdQuoteBody().GetRtmrs()[3]; !bytes.Equal(quoteRtmr, rtmrs[3]) {
+		t.Errorf("quote RTMR[3] = %x, want %x", quoteRtmr, rtmrs[3])
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("WatchMeasurements did not return after cancellation")
+	}
+
+	err = server.WatchMeasurements(&attestpb.WatchMeasurementsRequest{StartSequence: 100}, stream)
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("WatchMeasurements beyond the log = %v, want OutOfRange", err)
+	}
+}
+
+func TestGetQuoteWithCollateral(t *testing.T) {
+	tdxtest.ClearEnv(t)
+	ctx := context.Background()
+	getChallenge := func(server *Server) []byte {
+		t.Helper()
+		resp, err := server.GetChallenge(ctx, &attestpb.GetChallengeRequest{})
+		if err != nil {
+			t.Fatalf("GetChallenge failed: %v", err)
+		}
+		return resp.GetChallenge()
+	}
+
+	// Without collateral source the request fails before the challenge is spent
+	server := NewServer(NewMockTDXClient(), nil)
+	challenge := getChallenge(server)
+	_, err := server.GetQuote(ctx, &attestpb.GetQuoteRequest{Challenge: challenge, IncludeCollateral: true})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetQuote with collateral and no source = %v, want FailedPrecondition", err)
+	}
+	if _, err := server.GetQuote(ctx, &attestpb.GetQuoteRequest{Challenge: challenge}); err != nil {
+		t.Errorf("GetQuote with the same challenge failed: %v", err)
+	}
+
+	// An offline cache does not hold the collateral of the mock platform
+	source, err := collateral.NewCache(t.TempDir(), time.Hour, nil)
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+	server = NewServer(NewMockTDXClient(), verifier.NewVerifier(verifier.Options{Collateral: source}))
+	_, err = server.GetRawQuote(ctx, &attestpb.GetQuoteRequest{Challenge: getChallenge(server), IncludeCollateral: true})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("GetRawQuote with uncached collateral = %v, want Unavailable", err)
+	}
+}
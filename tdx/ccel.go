@@ -0,0 +1,313 @@
+package tdx
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	DefaultCcelTablePath = "/sys/firmware/acpi/tables/CCEL"      // CCEL ACPI table exposed by the kernel
+	DefaultCcelDataPath  = "/sys/firmware/acpi/tables/data/CCEL" // Event log area referenced by the CCEL table
+
+	ccelTableSize   = 56 // Size of the CCEL ACPI table
+	ccelTypeTdx     = 2  // CC type of Intel TDX
+	acpiHeaderSize  = 36 // Size of the common ACPI table header
+	specIdSignature = "Spec ID Event03\x00"
+
+	tpmAlgSha1   = 0x0004
+	tpmAlgSha256 = 0x000b
+	tpmAlgSha384 = 0x000c
+	tpmAlgSha512 = 0x000d
+)
+
+// TCG event types of the firmware event log.
+const (
+	EvPostCode                 = 0x00000001
+	EvNoAction                 = 0x00000003
+	EvSeparator                = 0x00000004
+	EvAction                   = 0x00000005
+	EvSCrtmContents            = 0x00000007
+	EvSCrtmVersion             = 0x00000008
+	EvPlatformConfigFlags      = 0x0000000a
+	EvIpl                      = 0x0000000d
+	EvEventTag                 = 0x00000006
+	EvEfiVariableDriverConfig  = 0x80000001
+	EvEfiVariableBoot          = 0x80000002
+	EvEfiBootServicesApp       = 0x80000003
+	EvEfiBootServicesDriver    = 0x80000004
+	EvEfiRuntimeServicesDrv    = 0x80000005
+	EvEfiGptEvent              = 0x80000006
+	EvEfiAction                = 0x80000007
+	EvEfiPlatformFirmwareBlob  = 0x80000008
+	EvEfiHandoffTables         = 0x80000009
+	EvEfiPlatformFirmwareBlob2 = 0x8000000a
+	EvEfiHandoffTables2        = 0x8000000b
+	EvEfiVariableBoot2         = 0x8000000c
+	EvEfiVariableAuthority     = 0x800000e0
+)
+
+var eventTypeNames = map[uint32]string{
+	EvPostCode:                 "EV_POST_CODE",
+	EvNoAction:                 "EV_NO_ACTION",
+	EvSeparator:                "EV_SEPARATOR",
+	EvAction:                   "EV_ACTION",
+	EvEventTag:                 "EV_EVENT_TAG",
+	EvSCrtmContents:            "EV_S_CRTM_CONTENTS",
+	EvSCrtmVersion:             "EV_S_CRTM_VERSION",
+	EvPlatformConfigFlags:      "EV_PLATFORM_CONFIG_FLAGS",
+	EvIpl:                      "EV_IPL",
+	EvEfiVariableDriverConfig:  "EV_EFI_VARIABLE_DRIVER_CONFIG",
+	EvEfiVariableBoot:          "EV_EFI_VARIABLE_BOOT",
+	EvEfiBootServicesApp:       "EV_EFI_BOOT_SERVICES_APPLICATION",
+	EvEfiBootServicesDriver:    "EV_EFI_BOOT_SERVICES_DRIVER",
+	EvEfiRuntimeServicesDrv:    "EV_EFI_RUNTIME_SERVICES_DRIVER",
+	EvEfiGptEvent:              "EV_EFI_GPT_EVENT",
+	EvEfiAction:                "EV_EFI_ACTION",
+	EvEfiPlatformFirmwareBlob:  "EV_EFI_PLATFORM_FIRMWARE_BLOB",
+	EvEfiHandoffTables:         "EV_EFI_HANDOFF_TABLES",
+	EvEfiPlatformFirmwareBlob2: "EV_EFI_PLATFORM_FIRMWARE_BLOB2",
+	EvEfiHandoffTables2:        "EV_EFI_HANDOFF_TABLES2",
+	EvEfiVariableBoot2:         "EV_EFI_VARIABLE_BOOT2",
+	EvEfiVariableAuthority:     "EV_EFI_VARIABLE_AUTHORITY",
+}
+
+// ErrInvalidCcel is returned when the CCEL table or event log is malformed.
+var ErrInvalidCcel = errors.New("invalid CCEL")
+
+// CcelTable is the Confidential Computing Event Log ACPI table.
+type CcelTable struct {
+	Revision             uint8  // Revision of the table
+	OemId                string // OEM identifier
+	CcType               uint8  // Confidential computing type (2 for TDX)
+	CcSubType            uint8  // Confidential computing sub type
+	LogAreaMinimumLength uint64 // Length of the event log area
+	LogAreaStartAddress  uint64 // Physical address of the event log area
+}
+
+// CcelEvent is a single event of the TCG2 crypto agile event log recorded in the CCEL.
+type CcelEvent struct {
+	MrIndex   uint32            // Measurement register index: 0 is MRTD, 1-4 are RTMR[0]-RTMR[3]
+	EventType uint32            // TCG event type
+	Digests   map[uint16][]byte // Digests of the event by TPM algorithm identifier
+	Data      []byte            // Event data
+	Offset    int               // Offset of the event in the log
+}
+
+// RtmrIndex returns the RTMR the event is extended into. Events measured into MRTD have no RTMR.
+func (e *CcelEvent) RtmrIndex() (int, bool) {
+	if e.MrIndex < 1 || e.MrIndex > 4 {
+		return 0, false
+	}
+	return int(e.MrIndex) - 1, true
+}
+
+// EventTypeName returns the TCG name of the event type.
+func (e *CcelEvent) EventTypeName() string {
+	if name, ok := eventTypeNames[e.EventType]; ok {
+		return name
+	}
+	return fmt.Sprintf("EV_UNKNOWN(0x%08x)", e.EventType)
+}
+
+// Digest returns the digest of the event for the given hash algorithm.
+func (e *CcelEvent) Digest(hashAlgo crypto.Hash) ([]byte, error) {
+	algId, ok := tpmAlgorithmId(hashAlgo)
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm %v", hashAlgo)
+	}
+	digest, ok := e.Digests[algId]
+	if !ok {
+		return nil, fmt.Errorf("event at offset %d has no %v digest", e.Offset, hashAlgo)
+	}
+	return digest, nil
+}
+
+// ParseCcelTable parses the CCEL ACPI table.
+func ParseCcelTable(table []byte) (*CcelTable, error) {
+	if len(table) < ccelTableSize {
+		return nil, fmt.Errorf("%w: table is %d bytes, expected %d", ErrInvalidCcel, len(table), ccelTableSize)
+	}
+	if string(table[0:4]) != "CCEL" {
+		return nil, fmt.Errorf("%w: unexpected table signature %q", ErrInvalidCcel, table[0:4])
+	}
+	if length := binary.LittleEndian.Uint32(table[4:8]); int(length) != len(table) {
+		return nil, fmt.Errorf("%w: table length %d does not match %d bytes", ErrInvalidCcel, length, len(table))
+	}
+
+	var sum byte
+	for _, b := range table {
+		sum += b
+	}
+	if sum != 0 {
+		return nil, fmt.Errorf("%w: bad table checksum", ErrInvalidCcel)
+	}
+
+	return &CcelTable{
+		Revision:             table[8],
+		OemId:                string(bytes.TrimRight(table[10:16], " ")),
+		CcType:               table[acpiHeaderSize],
+		CcSubType:            table[acpiHeaderSize+1],
+		LogAreaMinimumLength: binary.LittleEndian.Uint64(table[40:48]),
+		LogAreaStartAddress:  binary.LittleEndian.Uint64(table[48:56]),
+	}, nil
+}
+
+// ParseCcelEventLog parses the TCG2 crypto agile event log of the CCEL. The log starts with a TCG_PCR_EVENT holding
+// the Spec ID event, followed by TCG_PCR_EVENT2 entries until the end of the log or the unused area filled with 0xff.
+func ParseCcelEventLog(data []byte) ([]*CcelEvent, error) {
+	r := bytes.NewReader(data)
+
+	// The first event uses the SHA-1 log format and describes the digest sizes of the following events
+	var header struct {
+		MrIndex   uint32
+		EventType uint32
+		Digest    [20]byte
+		EventSize uint32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("%w: failed to read Spec ID event: %v", ErrInvalidCcel, err)
+	}
+	if header.EventType != EvNoAction {
+		return nil, fmt.Errorf("%w: first event has type 0x%x, expected EV_NO_ACTION", ErrInvalidCcel, header.EventType)
+	}
+	if int64(header.EventSize) > int64(r.Len()) {
+		return nil, fmt.Errorf("%w: Spec ID event size %d exceeds the log", ErrInvalidCcel, header.EventSize)
+	}
+	specId := make([]byte, header.EventSize)
+	if _, err := io.ReadFull(r, specId); err != nil {
+		return nil, fmt.Errorf("%w: failed to read Spec ID event: %v", ErrInvalidCcel, err)
+	}
+	digestSizes, err := parseSpecIdEvent(specId)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*CcelEvent
+	for r.Len() > 0 {
+		offset := len(data) - r.Len()
+		event, err := readCcelEvent(r, digestSizes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: event at offset %d: %v", ErrInvalidCcel, offset, err)
+		}
+		if event == nil {
+			break
+		}
+		event.Offset = offset
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// parseSpecIdEvent returns the digest size of every algorithm listed in the TCG_EfiSpecIDEventStruct.
+func parseSpecIdEvent(event []byte) (map[uint16]int, error) {
+	// signature[16] | platformClass u32 | specVersionMinor u8 | specVersionMajor u8 | specErrata u8 | uintnSize u8
+	const fixedSize = 16 + 4 + 4
+	if len(event) < fixedSize+4 || string(event[:16]) != specIdSignature {
+		return nil, fmt.Errorf("%w: missing Spec ID Event03 signature", ErrInvalidCcel)
+	}
+	r := bytes.NewReader(event[fixedSize:])
+
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, fmt.Errorf("%w: failed to read algorithm count: %v", ErrInvalidCcel, err)
+	}
+	digestSizes := make(map[uint16]int, count)
+	for i := uint32(0); i < count; i++ {
+		var alg struct {
+			AlgId      uint16
+			DigestSize uint16
+		}
+		if err := binary.Read(r, binary.LittleEndian, &alg); err != nil {
+			return nil, fmt.Errorf("%w: failed to read algorithm %d: %v", ErrInvalidCcel, i, err)
+		}
+		digestSizes[alg.AlgId] = int(alg.DigestSize)
+	}
+	return digestSizes, nil
+}
+
+// readCcelEvent reads one TCG_PCR_EVENT2 entry. It returns nil at the end of the recorded events.
+//
+//	u32 mr index | u32 event type | u32 digest count | (u16 alg id | digest)... | u32 event size | event
+func readCcelEvent(r *bytes.Reader, digestSizes map[uint16]int) (*CcelEvent, error) {
+	var header struct {
+		MrIndex     uint32
+		EventType   uint32
+		DigestCount uint32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		// Trailing bytes too short for another event
+		return nil, nil
+	}
+	if header.MrIndex == 0xffffffff && header.EventType == 0xffffffff {
+		// Unused area of the log
+		return nil, nil
+	}
+
+	event := &CcelEvent{
+		MrIndex:   header.MrIndex,
+		EventType: header.EventType,
+		Digests:   make(map[uint16][]byte, header.DigestCount),
+	}
+	for i := uint32(0); i < header.DigestCount; i++ {
+		var algId uint16
+		if err := binary.Read(r, binary.LittleEndian, &algId); err != nil {
+			return nil, fmt.Errorf("failed to read digest algorithm: %v", err)
+		}
+		size, ok := digestSizes[algId]
+		if !ok {
+			return nil, fmt.Errorf("digest algorithm 0x%04x is not listed in the Spec ID event", algId)
+		}
+		digest := make([]byte, size)
+		if _, err := io.ReadFull(r, digest); err != nil {
+			return nil, fmt.Errorf("failed to read digest: %v", err)
+		}
+		event.Digests[algId] = digest
+	}
+
+	var size uint32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return nil, fmt.Errorf("failed to read event size: %v", err)
+	}
+	if int64(size) > int64(r.Len()) {
+		return nil, fmt.Errorf("event size %d exceeds the remaining %d bytes", size, r.Len())
+	}
+	event.Data = make([]byte, size)
+	if _, err := io.ReadFull(r, event.Data); err != nil {
+		return nil, fmt.Errorf("failed to read event data: %v", err)
+	}
+	return event, nil
+}
+
+// ReplayCcelEvents folds the digests of the events into RTMR values. EV_NO_ACTION events and events measured into
+// MRTD are not extended.
+func ReplayCcelEvents(events []*CcelEvent, hashAlgo crypto.Hash) ([4][]byte, error) {
+	measurements, err := ccelMeasurementEvents(events, hashAlgo, "")
+	if err != nil {
+		return [4][]byte{}, err
+	}
+	provider := NewRtmrProviderWithSources(hashAlgo)
+	for _, event := range measurements {
+		if err := provider.ExtendEvent(event); err != nil {
+			return [4][]byte{}, err
+		}
+	}
+	return provider.GetRtmrValues(), nil
+}
+
+func tpmAlgorithmId(hashAlgo crypto.Hash) (uint16, bool) {
+	switch hashAlgo {
+	case crypto.SHA1:
+		return tpmAlgSha1, true
+	case crypto.SHA256:
+		return tpmAlgSha256, true
+	case crypto.SHA384:
+		return tpmAlgSha384, true
+	case crypto.SHA512:
+		return tpmAlgSha512, true
+	}
+	return 0, false
+}
@@ -0,0 +1,124 @@
+package tdx
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/google/go-tdx-guest/abi"
+
+	tdxpb "github.com/google/go-tdx-guest/proto/tdx"
+)
+
+// The fixtures were recorded on a TD together with cos-113-tdx-quote.dat, whose RTMRs they must replay to.
+const (
+	ccelTableFixture = "testdata/ccel/ccel_table.dat"
+	ccelDataFixture  = "testdata/ccel/ccel_data.dat"
+	ccelQuoteFixture = "testdata/ccel/cos-113-tdx-quote.dat"
+)
+
+func readCcelQuoteRtmrs(t *testing.T) [][]byte {
+	t.Helper()
+	raw, err := os.ReadFile(ccelQuoteFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	quote, err := abi.QuoteToProto(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return quote.(*tdxpb.QuoteV4).GetTdQuoteBody().GetRtmrs()
+}
+
+func TestParseCcel(t *testing.T) {
+	tableBytes, err := os.ReadFile(ccelTableFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := ParseCcelTable(tableBytes)
+	if err != nil {
+		t.Fatalf("ParseCcelTable failed: %v", err)
+	}
+	if table.CcType != ccelTypeTdx || table.OemId != "INTEL" || table.LogAreaMinimumLength != 0x40000 {
+		t.Errorf("unexpected table: %+v", table)
+	}
+
+	data, err := os.ReadFile(ccelDataFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := ParseCcelEventLog(data)
+	if err != nil {
+		t.Fatalf("ParseCcelEventLog failed: %v", err)
+	}
+	if len(events) == 0 {
+		t.Fatal("no events parsed")
+	}
+	if got := events[0].EventTypeName(); got != "EV_EFI_HANDOFF_TABLES2" {
+		t.Errorf("first event type = %s", got)
+	}
+
+	rtmrs, err := ReplayCcelEvents(events, crypto.SHA384)
+	if err != nil {
+		t.Fatalf("ReplayCcelEvents failed: %v", err)
+	}
+	// The boot loader also measures into RTMR[2], which holds no IMA events on this TD
+	quoteRtmrs := readCcelQuoteRtmrs(t)
+	for i := 0; i < 3; i++ {
+		if !bytes.Equal(rtmrs[i], quoteRtmrs[i]) {
+			t.Errorf("replayed RTMR[%d] = %x, quote has %x", i, rtmrs[i], quoteRtmrs[i])
+		}
+	}
+}
+
+func TestParseCcelErrors(t *testing.T) {
+	tableBytes, err := os.ReadFile(ccelTableFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := bytes.Clone(tableBytes)
+	tampered[40]++
+	if _, err := ParseCcelTable(tampered); !errors.Is(err, ErrInvalidCcel) {
+		t.Errorf("ParseCcelTable with bad checksum returned %v", err)
+	}
+
+	data, err := os.ReadFile(ccelDataFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseCcelEventLog(data[:0x150]); !errors.Is(err, ErrInvalidCcel) {
+		t.Errorf("ParseCcelEventLog with truncated log returned %v", err)
+	}
+}
+
+func TestUpdateRtmrsWithCcel(t *testing.T) {
+	provider := NewRtmrProviderWithSources(crypto.SHA384,
+		NewCcelSource(ccelTableFixture, ccelDataFixture, crypto.SHA384),
+		NewImaSource("testdata/ima/ascii_runtime_measurements", ImaRtmrIndex, crypto.SHA384),
+	)
+	if err := provider.UpdateRtmrs(); err != nil {
+		t.Fatalf("UpdateRtmrs failed: %v", err)
+	}
+	if err := provider.UpdateRtmrs(); err != nil {
+		t.Fatalf("UpdateRtmrs failed: %v", err)
+	}
+
+	rtmrs := provider.GetRtmrValues()
+	quoteRtmrs := readCcelQuoteRtmrs(t)
+	for i := 0; i < 2; i++ {
+		if !bytes.Equal(rtmrs[i], quoteRtmrs[i]) {
+			t.Errorf("RTMR[%d] = %x, quote has %x", i, rtmrs[i], quoteRtmrs[i])
+		}
+	}
+
+	page, err := provider.GetEventLog(0, 1)
+	if err != nil {
+		t.Fatalf("GetEventLog failed: %v", err)
+	}
+	event := page.Events[0]
+	if event.RtmrIndex != 0 || event.Source != ccelDataFixture || event.SourceLine != 1 || len(event.EventData) == 0 {
+		t.Errorf("unexpected first event: %+v", event)
+	}
+}
@@ -0,0 +1,145 @@
+package tdx
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	ChallengeSize                   = 32          // Size of a challenge issued by a ChallengeStore
+	DefaultChallengeTTL             = time.Minute // Lifetime of an outstanding challenge
+	DefaultMaxOutstandingChallenges = 1024        // Maximum number of challenges issued but not yet used
+)
+
+var (
+	// ErrUnknownChallenge is returned for a challenge that was never issued or was already used.
+	ErrUnknownChallenge = errors.New("unknown challenge")
+	// ErrChallengeExpired is returned for a challenge used after its TTL.
+	ErrChallengeExpired = errors.New("challenge expired")
+	// ErrTooManyChallenges is returned when the maximum number of outstanding challenges is reached.
+	ErrTooManyChallenges = errors.New("too many outstanding challenges")
+)
+
+// ChallengeConfig configures a ChallengeStore.
+type ChallengeConfig struct {
+	TTL            time.Duration // Lifetime of an outstanding challenge
+	MaxOutstanding int           // Maximum number of challenges issued but not yet used or expired
+}
+
+// DefaultChallengeConfig reads the challenge configuration from CHALLENGE_TTL and CHALLENGE_MAX_OUTSTANDING. Invalid
+// values fall back to the defaults.
+func DefaultChallengeConfig() ChallengeConfig {
+	config := ChallengeConfig{
+		TTL:            DefaultChallengeTTL,
+		MaxOutstanding: DefaultMaxOutstandingChallenges,
+	}
+
+	if value := os.Getenv("CHALLENGE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			log.Printf("[Warning] Invalid CHALLENGE_TTL '%s'. Defaulting to %v.", value, DefaultChallengeTTL)
+		} else {
+			config.TTL = ttl
+		}
+	}
+	if value := os.Getenv("CHALLENGE_MAX_OUTSTANDING"); value != "" {
+		maxOutstanding, err := strconv.Atoi(value)
+		if err != nil || maxOutstanding <= 0 {
+			log.Printf("[Warning] Invalid CHALLENGE_MAX_OUTSTANDING '%s'. Defaulting to %d.", value, DefaultMaxOutstandingChallenges)
+		} else {
+			config.MaxOutstanding = maxOutstanding
+		}
+	}
+	return config
+}
+
+// ChallengeStore issues random challenges that can be redeemed once before they expire, so that a quote bound to a
+// challenge proves it was generated after the challenge was issued.
+type ChallengeStore struct {
+	config ChallengeConfig
+	now    func() time.Time // Clock, replaced in tests
+
+	mu          sync.Mutex
+	outstanding map[[ChallengeSize]byte]time.Time // Expiry times by challenge
+}
+
+// NewChallengeStore creates a ChallengeStore.
+func NewChallengeStore(config ChallengeConfig) *ChallengeStore {
+	if config.TTL <= 0 {
+		config.TTL = DefaultChallengeTTL
+	}
+	if config.MaxOutstanding <= 0 {
+		config.MaxOutstanding = DefaultMaxOutstandingChallenges
+	}
+	return &ChallengeStore{
+		config:      config,
+		now:         time.Now,
+		outstanding: make(map[[ChallengeSize]byte]time.Time),
+	}
+}
+
+// Issue returns a new challenge and its expiry time.
+func (s *ChallengeStore) Issue() ([]byte, time.Time, error) {
+	var challenge [ChallengeSize]byte
+	if _, err := rand.Read(challenge[:]); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if len(s.outstanding) >= s.config.MaxOutstanding {
+		s.pruneLocked(now)
+		if len(s.outstanding) >= s.config.MaxOutstanding {
+			return nil, time.Time{}, ErrTooManyChallenges
+		}
+	}
+	expiresAt := now.Add(s.config.TTL)
+	s.outstanding[challenge] = expiresAt
+	return challenge[:], expiresAt, nil
+}
+
+// Redeem consumes an outstanding challenge. A challenge is consumed even if it expired or the quote bound to it
+// cannot be generated.
+func (s *ChallengeStore) Redeem(challenge []byte) error {
+	if len(challenge) != ChallengeSize {
+		return fmt.Errorf("%w: got %d bytes, want %d", ErrUnknownChallenge, len(challenge), ChallengeSize)
+	}
+	key := [ChallengeSize]byte(challenge)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.outstanding[key]
+	if !ok {
+		return ErrUnknownChallenge
+	}
+	delete(s.outstanding, key)
+	if !s.now().Before(expiresAt) {
+		return ErrChallengeExpired
+	}
+	return nil
+}
+
+// Outstanding returns the number of challenges issued but not yet used or pruned.
+func (s *ChallengeStore) Outstanding() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.outstanding)
+}
+
+// pruneLocked drops the expired challenges. s.mu must be held.
+func (s *ChallengeStore) pruneLocked(now time.Time) {
+	for challenge, expiresAt := range s.outstanding {
+		if !now.Before(expiresAt) {
+			delete(s.outstanding, challenge)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package tdx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+func TestChallengeStore(t *testing.T) {
+	now := time.Unix(1000, 0)
+	store := NewChallengeStore(ChallengeConfig{TTL: time.Minute, MaxOutstanding: 2})
+	store.now = func() time.Time { return now }
+
+	first, expiresAt, err := store.Issue()
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if len(first) != ChallengeSize || !expiresAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("Issue = %x expiring at %v", first, expiresAt)
+	}
+	second, _, err := store.Issue()
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if bytes.Equal(first, second) {
+		t.Error("Issue returned the same challenge twice")
+	}
+	if _, _, err := store.Issue(); !errors.Is(err, ErrTooManyChallenges) {
+		t.Errorf("Issue over the limit = %v, want ErrTooManyChallenges", err)
+	}
+
+	// Challenges are single-use
+	if err := store.Redeem(first); err != nil {
+		t.Errorf("Redeem failed: %v", err)
+	}
+	if err := store.Redeem(first); !errors.Is(err, ErrUnknownChallenge) {
+		t.Errorf("Redeem of a used challenge = %v, want ErrUnknownChallenge", err)
+	}
+	if err := store.Redeem(make([]byte, ChallengeSize)); !errors.Is(err, ErrUnknownChallenge) {
+		t.Errorf("Redeem of an unknown challenge = %v, want ErrUnknownChallenge", err)
+	}
+
+	// Expired challenges are rejected, and pruned to make room for new ones
+	now = now.Add(time.Minute)
+	if err := store.Redeem(second); !errors.Is(err, ErrChallengeExpired) {
+		t.Errorf("Redeem of an expired challenge = %v, want ErrChallengeExpired", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, _, err := store.Issue(); err != nil {
+			t.Fatalf("Issue failed: %v", err)
+		}
+	}
+	now = now.Add(time.Minute)
+	if _, _, err := store.Issue(); err != nil {
+		t.Errorf("Issue after expiry failed: %v", err)
+	}
+	if store.Outstanding() != 1 {
+		t.Errorf("outstanding challenges = %d, want 1", store.Outstanding())
+	}
+}
+
+func TestGetQuoteWithChallenge(t *testing.T) {
+	tdxtest.ClearEnv(t)
+	server := NewServer(NewMockTDXClient(), nil)
+	ctx := context.Background()
+
+	resp, err := server.GetChallenge(ctx, &attestpb.GetChallengeRequest{})
+	if err != nil {
+		t.Fatalf("GetChallenge failed: %v", err)
+	}
+	userData := []byte("public key hash")
+	quoteResp, err := server.GetQuote(ctx, &attestpb.GetQuoteRequest{Challenge: resp.GetChallenge(), ReportData: userData})
+	if err != nil {
+		t.Fatalf("GetQuote failed: %v", err)
+	}
+	bound, err := verifier.CheckFreshness(quoteResp.GetQuote(), quoteResp.GetReportDataBinding(), resp.GetChallenge())
+	if err != nil {
+		t.Fatalf("CheckFreshness failed: %v", err)
+	}
+	if !bytes.Equal(bound, userData) {
+		t.Errorf("bound user data = %q, want %q", bound, userData)
+	}
+
+	// The challenge cannot be replayed
+	_, err = server.GetRawQuote(ctx, &attestpb.GetQuoteRequest{Challenge: resp.GetChallenge()})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetRawQuote with a used challenge = %v, want FailedPrecondition", err)
+	}
+	_, err = server.GetQuote(ctx, &attestpb.GetQuoteRequest{ReportData: userData})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetQuote without a challenge = %v, want InvalidArgument", err)
+	}
+
+	// Raw quotes carry the same binding
+	resp, err = server.GetChallenge(ctx, &attestpb.GetChallengeRequest{})
+	if err != nil {
+		t.Fatalf("GetChallenge failed: %v", err)
+	}
+	rawResp, err := server.GetRawQuote(ctx, &attestpb.GetQuoteRequest{Challenge: resp.GetChallenge()})
+	if err != nil {
+		t.Fatalf("GetRawQuote failed: %v", err)
+	}
+	quote, err := utils.ConvertRawQuoteToQuote(rawResp.GetRawQuote())
+	if err != nil {
+		t.Fatalf("ConvertRawQuoteToQuote failed: %v", err)
+	}
+	if _, err := verifier.CheckFreshness(quote, rawResp.GetReportDataBinding(), resp.GetChallenge()); err != nil {
+		t.Errorf("CheckFreshness of the raw quote failed: %v", err)
+	}
+}
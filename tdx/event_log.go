@@ -0,0 +1,81 @@
+package tdx
+
+import (
+	"crypto"
+	"errors"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+// ErrSequenceOutOfRange is returned when an event log is requested from beyond the last recorded event.
+var ErrSequenceOutOfRange = errors.New("sequence number out of range")
+
+// MeasurementEvent is a single event extended into an RTMR.
+type MeasurementEvent struct {
+	Sequence       uint64      // Position of the event in the log
+	RtmrIndex      int         // RTMR index the event was extended into
+	Digest         []byte      // SHA-384 digest of the event, extended into the RTMR as is
+	HashAlgo       crypto.Hash // Hash algorithm of the digest
+	SourceDigest   []byte      // Digest of the event in the hash algorithm of its source, if not SHA-384; not extended
+	SourceHashAlgo crypto.Hash // Hash algorithm of the source digest
+	EventType      string      // Type of the event (e.g. the IMA template name)
+	EventData      []byte      // Raw event data the digest was computed from
+	Source         string      // Source of the event (e.g. the IMA log path)
+	SourceLine     int         // Line of the event in the source, or its position for binary logs
+	RtmrValue      []byte      // Value of the RTMR after extending the event
+}
+
+// EventLogPage is a contiguous range of the recorded event log.
+type EventLogPage struct {
+	Start       uint64             // Sequence number of the first event of the page
+	Events      []MeasurementEvent // Events of the page
+	TotalEvents uint64             // Number of events recorded so far
+	Rtmrs       [4][]byte          // RTMR values after replaying all recorded events
+}
+
+// NextSequence returns the sequence number following the last event of the page.
+func (p *EventLogPage) NextSequence() uint64 {
+	return p.Start + uint64(len(p.Events))
+}
+
+// ConvertEventLogPageToProtobuf converts an EventLogPage to a GetEventLogResponse.
+func ConvertEventLogPageToProtobuf(page *EventLogPage) *attestpb.GetEventLogResponse {
+	rtmrs := normalizeRtmrs(page.Rtmrs)
+	resp := &attestpb.GetEventLogResponse{
+		NextSequence: page.NextSequence(),
+		TotalEvents:  page.TotalEvents,
+		Rtmrs:        rtmrs[:],
+	}
+	for _, event := range page.Events {
+		resp.Events = append(resp.Events, ConvertEventToProtobuf(event))
+	}
+	return resp
+}
+
+// ConvertEventToProtobuf converts a MeasurementEvent to its protobuf representation.
+func ConvertEventToProtobuf(event MeasurementEvent) *attestpb.MeasurementEvent {
+	pbEvent := &attestpb.MeasurementEvent{
+		Sequence:      event.Sequence,
+		RtmrIndex:     uint32(event.RtmrIndex),
+		Digest:        event.Digest,
+		HashAlgorithm: event.HashAlgo.String(),
+		EventType:     event.EventType,
+		EventData:     event.EventData,
+		Source:        event.Source,
+		SourceLine:    uint32(event.SourceLine),
+	}
+	if event.SourceDigest != nil {
+		pbEvent.SourceDigest = event.SourceDigest
+		pbEvent.SourceHashAlgorithm = event.SourceHashAlgo.String()
+	}
+	return pbEvent
+}
+
+// normalizeRtmrs returns all RTMR values as RtmrSize bytes, reporting RTMRs that were never extended as zeros.
+func normalizeRtmrs(rtmrs [4][]byte) [4][]byte {
+	var values [4][]byte
+	for i, rtmr := range rtmrs {
+		values[i] = normalizeRtmr(rtmr)
+	}
+	return values
+}
@@ -0,0 +1,71 @@
+package tdx
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+func TestGetEventLogReplay(t *testing.T) {
+	tdxtest.ClearEnv(t)
+	t.Setenv("IMA_LOG_PATH", "testdata/ima/ascii_runtime_measurements")
+	server := NewServer(NewMockTDXClient(), nil)
+
+	// Read the log in pages of two events
+	var events []*attestpb.MeasurementEvent
+	var resp *attestpb.GetEventLogResponse
+	next := uint64(0)
+	for {
+		var err error
+		resp, err = server.GetEventLog(context.Background(), &attestpb.GetEventLogRequest{StartSequence: next, PageSize: 2})
+		if err != nil {
+			t.Fatalf("GetEventLog failed: %v", err)
+		}
+		if len(resp.Events) == 0 {
+			break
+		}
+		events = append(events, resp.Events...)
+		next = resp.NextSequence
+	}
+	if len(events) != 6 || resp.TotalEvents != 6 {
+		t.Fatalf("got %d events, total %d, want 6", len(events), resp.TotalEvents)
+	}
+
+	// Replay the events and compare with the reported RTMRs
+	replayed := make([][]byte, 4)
+	for i := range replayed {
+		replayed[i] = make([]byte, RtmrSize)
+	}
+	for i, event := range events {
+		if event.Sequence != uint64(i) {
+			t.Errorf("event %d has sequence %d", i, event.Sequence)
+		}
+		if event.HashAlgorithm != "SHA-384" || event.Source != "testdata/ima/ascii_runtime_measurements" || event.SourceLine != uint32(i+1) {
+			t.Errorf("unexpected event metadata: %v", event)
+		}
+		// The last event is a violation, which extends 0xff bytes instead of the digest of the event data
+		if digest := sha512.Sum384(event.EventData); i != 5 && !bytes.Equal(digest[:], event.Digest) {
+			t.Errorf("event %d digest does not match its data", i)
+		}
+		extended := sha512.Sum384(append(replayed[event.RtmrIndex], event.Digest...))
+		replayed[event.RtmrIndex] = extended[:]
+	}
+	for i := range replayed {
+		if !bytes.Equal(replayed[i], resp.Rtmrs[i]) {
+			t.Errorf("replayed RTMR[%d] = %x, reported %x", i, replayed[i], resp.Rtmrs[i])
+		}
+	}
+
+	_, err := server.GetEventLog(context.Background(), &attestpb.GetEventLogRequest{StartSequence: 7})
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("GetEventLog beyond the end returned %v", err)
+	}
+}
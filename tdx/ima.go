@@ -0,0 +1,330 @@
+package tdx
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	ImaTemplate    = "ima"     // Legacy template: SHA-1 file digest and file name
+	ImaNgTemplate  = "ima-ng"  // File digest with algorithm and file name
+	ImaSigTemplate = "ima-sig" // ima-ng with the file signature
+
+	imaEventNameLenMax = 255 // IMA_EVENT_NAME_LEN_MAX of the kernel
+	imaDigestSize      = 20  // SHA-1 digest size of the legacy ima template
+)
+
+var (
+	// ErrUnsupportedTemplate is returned for IMA templates that cannot be reconstructed.
+	ErrUnsupportedTemplate = errors.New("unsupported IMA template")
+	// ErrTemplateHashMismatch is returned when the recorded template hash does not match the template data.
+	ErrTemplateHashMismatch = errors.New("IMA template hash mismatch")
+)
+
+// ImaEvent is a single entry of the IMA runtime measurement list.
+type ImaEvent struct {
+	Pcr            uint32   // PCR index the entry was measured into
+	TemplateHash   []byte   // Template hash as recorded in the log
+	TemplateName   string   // Template name (ima, ima-ng, ima-sig)
+	FileDigestAlgo string   // Algorithm of the file digest
+	FileDigest     []byte   // Digest of the measured file
+	FilePath       string   // Path of the measured file
+	Signature      []byte   // File signature (ima-sig only)
+	templateFields [][]byte // Template fields as stored by the kernel
+}
+
+// IsViolation reports whether the entry records a measurement violation. Violations have an all-zero template hash.
+func (e *ImaEvent) IsViolation() bool {
+	return len(e.TemplateHash) > 0 && bytes.Count(e.TemplateHash, []byte{0}) == len(e.TemplateHash)
+}
+
+// TemplateData returns the bytes the kernel hashes to compute the template hash.
+func (e *ImaEvent) TemplateData() []byte {
+	var buf bytes.Buffer
+	if e.TemplateName == ImaTemplate {
+		// The legacy template hashes the digest and the file name padded to IMA_EVENT_NAME_LEN_MAX + 1 bytes
+		buf.Write(e.templateFields[0])
+		name := make([]byte, imaEventNameLenMax+1)
+		copy(name, e.templateFields[1])
+		buf.Write(name)
+		return buf.Bytes()
+	}
+	for _, field := range e.templateFields {
+		binary.Write(&buf, binary.LittleEndian, uint32(len(field)))
+		buf.Write(field)
+	}
+	return buf.Bytes()
+}
+
+// Digest returns the template hash computed with the given algorithm. This is the digest the kernel extends into the
+// measurement register of the corresponding bank; violations extend all 0xff bytes.
+func (e *ImaEvent) Digest(hashAlgo crypto.Hash) []byte {
+	if e.IsViolation() {
+		return bytes.Repeat([]byte{0xff}, hashAlgo.Size())
+	}
+	hasher := hashAlgo.New()
+	hasher.Write(e.TemplateData())
+	return hasher.Sum(nil)
+}
+
+// verifyTemplateHash checks the recorded template hash against the template data.
+func (e *ImaEvent) verifyTemplateHash() error {
+	if e.IsViolation() {
+		return nil
+	}
+	hashAlgo, ok := hashBySize(len(e.TemplateHash))
+	if !ok {
+		return fmt.Errorf("unknown template hash size %d", len(e.TemplateHash))
+	}
+	if digest := e.Digest(hashAlgo); !bytes.Equal(digest, e.TemplateHash) {
+		return fmt.Errorf("%w: recorded %x, computed %x", ErrTemplateHashMismatch, e.TemplateHash, digest)
+	}
+	return nil
+}
+
+// ParseImaAsciiLine parses a single line of ascii_runtime_measurements:
+//
+//	PCR template-hash template-name file-digest file-path [signature]
+func ParseImaAsciiLine(line string) (*ImaEvent, error) {
+	parts := strings.SplitN(line, " ", 5)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("expected at least 5 fields, got %d", len(parts))
+	}
+
+	pcr, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PCR %q: %w", parts[0], err)
+	}
+	templateHash, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid template hash: %w", err)
+	}
+
+	event := &ImaEvent{
+		Pcr:          uint32(pcr),
+		TemplateHash: templateHash,
+		TemplateName: parts[2],
+	}
+
+	// ima-sig appends the signature after the path; the separator is written even if the signature is empty
+	path := parts[4]
+	if event.TemplateName == ImaSigTemplate {
+		idx := strings.LastIndex(path, " ")
+		if idx < 0 {
+			return nil, errors.New("missing signature field")
+		}
+		if event.Signature, err = hex.DecodeString(path[idx+1:]); err != nil {
+			return nil, fmt.Errorf("invalid signature: %w", err)
+		}
+		path = path[:idx]
+	}
+	event.FilePath = path
+
+	switch event.TemplateName {
+	case ImaTemplate:
+		if event.FileDigest, err = hex.DecodeString(parts[3]); err != nil {
+			return nil, fmt.Errorf("invalid file digest: %w", err)
+		}
+		event.FileDigestAlgo = "sha1"
+		event.templateFields = [][]byte{fixedSize(event.FileDigest, imaDigestSize), []byte(path)}
+	case ImaNgTemplate, ImaSigTemplate:
+		algo, digest, ok := strings.Cut(parts[3], ":")
+		if !ok {
+			return nil, fmt.Errorf("file digest %q has no algorithm", parts[3])
+		}
+		if event.FileDigest, err = hex.DecodeString(digest); err != nil {
+			return nil, fmt.Errorf("invalid file digest: %w", err)
+		}
+		event.FileDigestAlgo = algo
+		event.templateFields = [][]byte{digestNgField(algo, event.FileDigest), nameNgField(path)}
+		if event.TemplateName == ImaSigTemplate {
+			event.templateFields = append(event.templateFields, event.Signature)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedTemplate, event.TemplateName)
+	}
+
+	if err := event.verifyTemplateHash(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ParseImaAsciiLog parses all entries of an ascii_runtime_measurements log.
+func ParseImaAsciiLog(r io.Reader) ([]*ImaEvent, error) {
+	var events []*ImaEvent
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		event, err := ParseImaAsciiLine(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse line %d: %w", lineNum, err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read IMA log: %w", err)
+	}
+	return events, nil
+}
+
+// ParseImaBinaryLog parses all entries of a binary_runtime_measurements log whose template hashes use the given
+// algorithm (SHA-1 for the legacy binary_runtime_measurements file).
+func ParseImaBinaryLog(r io.Reader, templateHashAlgo crypto.Hash) ([]*ImaEvent, error) {
+	var events []*ImaEvent
+	reader := bufio.NewReader(r)
+	for {
+		event, err := readImaBinaryEvent(reader, templateHashAlgo.Size())
+		if err == io.EOF {
+			return events, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse entry %d: %w", len(events), err)
+		}
+		events = append(events, event)
+	}
+}
+
+// readImaBinaryEvent reads one binary entry:
+//
+//	u32 pcr | template hash | u32 name length | name | [u32 data length] | template data
+func readImaBinaryEvent(r io.Reader, hashSize int) (*ImaEvent, error) {
+	var pcr uint32
+	if err := binary.Read(r, binary.LittleEndian, &pcr); err != nil {
+		return nil, err
+	}
+
+	event := &ImaEvent{
+		Pcr:          pcr,
+		TemplateHash: make([]byte, hashSize),
+	}
+	if _, err := io.ReadFull(r, event.TemplateHash); err != nil {
+		return nil, fmt.Errorf("failed to read template hash: %w", io.ErrUnexpectedEOF)
+	}
+	name, err := readLengthPrefixed(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template name: %w", err)
+	}
+	event.TemplateName = string(name)
+
+	if event.TemplateName == ImaTemplate {
+		// The legacy template has no data length; the digest has no field length
+		digest := make([]byte, imaDigestSize)
+		if _, err := io.ReadFull(r, digest); err != nil {
+			return nil, fmt.Errorf("failed to read file digest: %w", io.ErrUnexpectedEOF)
+		}
+		path, err := readLengthPrefixed(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file name: %w", err)
+		}
+		event.FileDigestAlgo = "sha1"
+		event.FileDigest = digest
+		event.FilePath = string(path)
+		event.templateFields = [][]byte{digest, path}
+	} else {
+		data, err := readLengthPrefixed(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template data: %w", err)
+		}
+		if event.templateFields, err = splitTemplateFields(data); err != nil {
+			return nil, err
+		}
+		if err := event.decodeNgFields(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := event.verifyTemplateHash(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// decodeNgFields fills the digest, path and signature from the fields of ima-ng and ima-sig entries.
+func (e *ImaEvent) decodeNgFields() error {
+	switch e.TemplateName {
+	case ImaNgTemplate, ImaSigTemplate:
+	default:
+		// Other templates are hashed as is, but their fields are not interpreted
+		return nil
+	}
+
+	if len(e.templateFields) < 2 {
+		return fmt.Errorf("%s entry has %d fields", e.TemplateName, len(e.templateFields))
+	}
+	algo, digest, ok := bytes.Cut(e.templateFields[0], []byte{':', 0})
+	if !ok {
+		return errors.New("file digest has no algorithm")
+	}
+	e.FileDigestAlgo = string(algo)
+	e.FileDigest = digest
+	e.FilePath = string(bytes.TrimSuffix(e.templateFields[1], []byte{0}))
+	if e.TemplateName == ImaSigTemplate && len(e.templateFields) > 2 {
+		e.Signature = e.templateFields[2]
+	}
+	return nil
+}
+
+// splitTemplateFields splits length prefixed template fields.
+func splitTemplateFields(data []byte) ([][]byte, error) {
+	var fields [][]byte
+	r := bytes.NewReader(data)
+	for r.Len() > 0 {
+		field, err := readLengthPrefixed(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template field %d: %w", len(fields), err)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func readLengthPrefixed(r io.Reader) ([]byte, error) {
+	var length uint32
+	if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if length > 1<<20 {
+		return nil, fmt.Errorf("field length %d too large", length)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return data, nil
+}
+
+// digestNgField builds the d-ng field: "<algo>:\0<digest>".
+func digestNgField(algo string, digest []byte) []byte {
+	field := append([]byte(algo), ':', 0)
+	return append(field, digest...)
+}
+
+// nameNgField builds the n-ng field: the null terminated path.
+func nameNgField(path string) []byte {
+	return append([]byte(path), 0)
+}
+
+func hashBySize(size int) (crypto.Hash, bool) {
+	for _, h := range []crypto.Hash{crypto.SHA1, crypto.SHA256, crypto.SHA384, crypto.SHA512} {
+		if h.Size() == size {
+			return h, true
+		}
+	}
+	return 0, false
+}
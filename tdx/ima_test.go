@@ -0,0 +1,107 @@
+package tdx
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Expected RTMR[2] after replaying testdata/ima/ascii_runtime_measurements with SHA-384, computed independently.
+const imaFixtureRtmr = "cc19efef390aa0bdf924ef7cec2f1cf2ea34143a39bd3949d1169e99ab646ab16fc796cdb678da5b9ef9924a6f523afc"
+
+func TestParseImaLogs(t *testing.T) {
+	asciiLog, err := os.Open("testdata/ima/ascii_runtime_measurements")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer asciiLog.Close()
+	asciiEvents, err := ParseImaAsciiLog(asciiLog)
+	if err != nil {
+		t.Fatalf("ParseImaAsciiLog failed: %v", err)
+	}
+
+	binaryLog, err := os.Open("testdata/ima/binary_runtime_measurements")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer binaryLog.Close()
+	binaryEvents, err := ParseImaBinaryLog(binaryLog, crypto.SHA1)
+	if err != nil {
+		t.Fatalf("ParseImaBinaryLog failed: %v", err)
+	}
+
+	if len(asciiEvents) != 6 || len(binaryEvents) != len(asciiEvents) {
+		t.Fatalf("got %d ascii and %d binary events, want 6", len(asciiEvents), len(binaryEvents))
+	}
+	for i, a := range asciiEvents {
+		b := binaryEvents[i]
+		if a.TemplateName != b.TemplateName || a.FilePath != b.FilePath || a.FileDigestAlgo != b.FileDigestAlgo ||
+			!bytes.Equal(a.FileDigest, b.FileDigest) || !bytes.Equal(a.Signature, b.Signature) ||
+			!bytes.Equal(a.TemplateData(), b.TemplateData()) {
+			t.Errorf("event %d differs between ascii and binary logs: %+v, %+v", i, a, b)
+		}
+	}
+
+	if got := asciiEvents[2].FilePath; got != "/opt/lightbulb/bin/server with space" {
+		t.Errorf("file path = %q", got)
+	}
+	if got := asciiEvents[4].Signature; len(got) == 0 {
+		t.Errorf("ima-sig signature is empty")
+	}
+	if !asciiEvents[5].IsViolation() {
+		t.Errorf("last event is not a violation")
+	}
+	if got := asciiEvents[5].Digest(crypto.SHA384); !bytes.Equal(got, bytes.Repeat([]byte{0xff}, 48)) {
+		t.Errorf("violation digest = %x", got)
+	}
+}
+
+func TestParseImaAsciiLineErrors(t *testing.T) {
+	valid := "10 473d8838615859c27cb6be09fbe011e194c7a34f ima-ng sha256:bb54068aea85faa7e487530083366be9962390af822e4c71ef1aca7033c83e66 /usr/lib/systemd/systemd"
+	if _, err := ParseImaAsciiLine(valid); err != nil {
+		t.Fatalf("ParseImaAsciiLine failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		line string
+		want error
+	}{
+		{"tampered path", strings.Replace(valid, "systemd/systemd", "systemd/evil", 1), ErrTemplateHashMismatch},
+		{"unknown template", strings.Replace(valid, "ima-ng", "ima-buf", 1), ErrUnsupportedTemplate},
+		{"truncated", "10 473d8838615859c27cb6be09fbe011e194c7a34f ima-ng", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseImaAsciiLine(tt.line)
+			if err == nil {
+				t.Fatal("ParseImaAsciiLine succeeded")
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateRtmrs(t *testing.T) {
+	provider := NewRtmrProvider("testdata/ima/ascii_runtime_measurements", crypto.SHA384)
+	if err := provider.UpdateRtmrs(); err != nil {
+		t.Fatalf("UpdateRtmrs failed: %v", err)
+	}
+	if got := hex.EncodeToString(provider.GetRtmrValues()[ImaRtmrIndex]); got != imaFixtureRtmr {
+		t.Errorf("RTMR[%d] = %s, want %s", ImaRtmrIndex, got, imaFixtureRtmr)
+	}
+
+	// A second update does not extend already processed entries
+	if err := provider.UpdateRtmrs(); err != nil {
+		t.Fatalf("UpdateRtmrs failed: %v", err)
+	}
+	if got := hex.EncodeToString(provider.GetRtmrValues()[ImaRtmrIndex]); got != imaFixtureRtmr {
+		t.Errorf("RTMR[%d] changed after second update: %s", ImaRtmrIndex, got)
+	}
+}
@@ -0,0 +1,88 @@
+package tdx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+const (
+	LaunchConfigRtmrIndex = 3               // RTMR extended with the launch configuration when MRCONFIGID is not set
+	LaunchConfigEventType = "launch_config" // Event type of the launch configuration in the event log
+)
+
+// LaunchConfigKeys are the environment variables that make up the launch configuration.
+var LaunchConfigKeys = []string{"ENV", "TDX_VERSION", "IMA_LOG_PATH", "PORT"}
+
+// ErrLaunchConfigMismatch is returned when MRCONFIGID is set to the digest of another launch configuration.
+var ErrLaunchConfigMismatch = errors.New("launch configuration does not match MRCONFIGID")
+
+// LaunchConfig is the configuration the server was started with, and where its digest is measured.
+type LaunchConfig struct {
+	Document  []byte                       // Canonical JSON document of the configuration values
+	Digest    []byte                       // SHA-384 digest of Document
+	Binding   attestpb.LaunchConfigBinding // MRCONFIGID or RTMR
+	RtmrIndex int                          // RTMR extended with Document, if Binding is RTMR
+}
+
+// LaunchConfigDocument returns the canonical document of the LaunchConfigKeys values of the environment. Unset
+// variables are recorded as empty.
+func LaunchConfigDocument() ([]byte, error) {
+	values := make(map[string]string, len(LaunchConfigKeys))
+	for _, key := range LaunchConfigKeys {
+		values[key] = os.Getenv(key)
+	}
+	return verifier.CanonicalLaunchConfig(values)
+}
+
+// BindLaunchConfig measures the launch configuration of the environment. If the TD was created with MRCONFIGID set,
+// it must be the digest of the configuration; otherwise the configuration is extended into LaunchConfigRtmrIndex.
+func BindLaunchConfig(client TDXClientInterface) (*LaunchConfig, error) {
+	document, err := LaunchConfigDocument()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode launch configuration: %w", err)
+	}
+	config := &LaunchConfig{
+		Document: document,
+		Digest:   verifier.LaunchConfigDigest(document),
+	}
+
+	quote, _, err := GetQuoteWithReportData(client, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quote: %w", err)
+	}
+	mrConfigId := utils.GetTDQuoteBody(quote).GetMrConfigId()
+	if len(mrConfigId) > 0 && !bytes.Equal(mrConfigId, make([]byte, len(mrConfigId))) {
+		if !bytes.Equal(mrConfigId, config.Digest) {
+			return nil, fmt.Errorf("%w: MRCONFIGID is %x, launch configuration %s has digest %x", ErrLaunchConfigMismatch, mrConfigId, document, config.Digest)
+		}
+		config.Binding = attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_MR_CONFIG_ID
+		return config, nil
+	}
+
+	if err := client.ExtendRtmr(LaunchConfigRtmrIndex, LaunchConfigEventType, document); err != nil {
+		return nil, fmt.Errorf("failed to measure launch configuration: %w", err)
+	}
+	config.Binding = attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_RTMR
+	config.RtmrIndex = LaunchConfigRtmrIndex
+	return config, nil
+}
+
+// ConvertLaunchConfigToProtobuf converts a LaunchConfig to its protobuf representation.
+func ConvertLaunchConfigToProtobuf(config *LaunchConfig) *attestpb.LaunchConfig {
+	if config == nil {
+		return nil
+	}
+	return &attestpb.LaunchConfig{
+		Document:  config.Document,
+		Digest:    config.Digest,
+		Binding:   config.Binding,
+		RtmrIndex: uint32(config.RtmrIndex),
+	}
+}
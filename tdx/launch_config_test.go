@@ -0,0 +1,105 @@
+package tdx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+func setLaunchConfigEnv(t *testing.T) {
+	t.Helper()
+	tdxtest.ClearEnv(t)
+	t.Setenv("ENV", "MOCK_TDX")
+	t.Setenv("PORT", "50051")
+}
+
+func TestBindLaunchConfigToRtmr(t *testing.T) {
+	setLaunchConfigEnv(t)
+	client := NewMockTDXClient()
+	server := NewServer(client, nil)
+
+	config, err := BindLaunchConfig(client)
+	if err != nil {
+		t.Fatalf("BindLaunchConfig failed: %v", err)
+	}
+	if config.Binding != attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_RTMR || config.RtmrIndex != LaunchConfigRtmrIndex {
+		t.Errorf("launch configuration bound by %v to RTMR[%d]", config.Binding, config.RtmrIndex)
+	}
+	want := `{"ENV":"MOCK_TDX","IMA_LOG_PATH":"","PORT":"50051","TDX_VERSION":""}`
+	if string(config.Document) != want {
+		t.Errorf("Document = %s, want %s", config.Document, want)
+	}
+	server.SetLaunchConfig(config)
+
+	// The configuration in the evidence is measured in the quote
+	challenge, err := server.GetChallenge(context.Background(), &attestpb.GetChallengeRequest{})
+	if err != nil {
+		t.Fatalf("GetChallenge failed: %v", err)
+	}
+	resp, err := server.GetQuote(context.Background(), &attestpb.GetQuoteRequest{Challenge: challenge.GetChallenge()})
+	if err != nil {
+		t.Fatalf("GetQuote failed: %v", err)
+	}
+	events, err := server.GetEventLog(context.Background(), &attestpb.GetEventLogRequest{})
+	if err != nil {
+		t.Fatalf("GetEventLog failed: %v", err)
+	}
+	values, err := verifier.CheckLaunchConfig(resp.GetQuote(), resp.GetLaunchConfig(), events.GetEvents())
+	if err != nil {
+		t.Fatalf("CheckLaunchConfig failed: %v", err)
+	}
+	if values["PORT"] != "50051" {
+		t.Errorf("PORT = %q, want 50051", values["PORT"])
+	}
+
+	status, err := server.GetStatus(context.Background(), &attestpb.GetStatusRequest{})
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if !bytes.Equal(status.GetLaunchConfig().GetDocument(), config.Document) {
+		t.Errorf("GetStatus launch configuration = %s, want %s", status.GetLaunchConfig().GetDocument(), config.Document)
+	}
+}
+
+func TestBindLaunchConfigToMrConfigId(t *testing.T) {
+	setLaunchConfigEnv(t)
+	document, err := LaunchConfigDocument()
+	if err != nil {
+		t.Fatalf("LaunchConfigDocument failed: %v", err)
+	}
+	digest := verifier.LaunchConfigDigest(document)
+
+	for _, version := range []uint16{utils.QuoteVersion4, utils.QuoteVersion5} {
+		t.Run(fmt.Sprintf("version %d", version), func(t *testing.T) {
+			client := NewMockTDXClientWithConfig(MockQuoteConfig{MrConfigId: digest, QuoteVersion: version})
+			config, err := BindLaunchConfig(client)
+			if err != nil {
+				t.Fatalf("BindLaunchConfig failed: %v", err)
+			}
+			if config.Binding != attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_MR_CONFIG_ID {
+				t.Errorf("launch configuration bound by %v, want MRCONFIGID", config.Binding)
+			}
+			page, err := client.GetEventLog(0, 0)
+			if err != nil {
+				t.Fatalf("GetEventLog failed: %v", err)
+			}
+			if len(page.Events) != 0 {
+				t.Errorf("launch configuration bound to MRCONFIGID was also extended: %+v", page.Events)
+			}
+
+			// A TD created for another configuration refuses it
+			t.Setenv("PORT", "50052")
+			if _, err := BindLaunchConfig(client); !errors.Is(err, ErrLaunchConfigMismatch) {
+				t.Errorf("BindLaunchConfig with another configuration = %v, want ErrLaunchConfigMismatch", err)
+			}
+		})
+	}
+}
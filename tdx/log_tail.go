@@ -0,0 +1,104 @@
+package tdx
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+var (
+	// ErrLogReplaced is returned when a tailed log file was replaced by a different file.
+	ErrLogReplaced = errors.New("log file was replaced")
+	// ErrLogTruncated is returned when a tailed log file went backwards: it shrank or its processed part was rewritten.
+	ErrLogTruncated = errors.New("log file went backwards")
+)
+
+// logTail reads the complete lines appended to a log file since the previous read. Measurement logs are append only,
+// so a log that is replaced, shrinks or rewrites its processed part is a hard error: the measurements extended from
+// it can no longer be trusted to match the log.
+type logTail struct {
+	path     string      // Path to the log file
+	info     os.FileInfo // File info at the previous read, nil before the first read
+	offset   int64       // Offset of the first unprocessed byte
+	line     int         // Number of the last processed line
+	lastLine []byte      // Last processed line including the newline, to detect rewrites
+}
+
+func newLogTail(path string) *logTail {
+	return &logTail{path: path}
+}
+
+// readLines calls fn for every complete line after the last processed one. A line without a trailing newline is left
+// for the next read. If fn fails, the lines before are kept as processed.
+func (t *logTail) readLines(fn func(line []byte, lineNum int) error) error {
+	file, err := os.Open(t.path)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	if err := t.checkUnchanged(file, info); err != nil {
+		return err
+	}
+	t.info = info
+
+	if _, err := file.Seek(t.offset, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek log file: %w", err)
+	}
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// Partial lines are processed once complete
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read log file: %w", err)
+		}
+
+		if err := fn(bytes.TrimSuffix(line, []byte{'\n'}), t.line+1); err != nil {
+			return err
+		}
+		t.offset += int64(len(line))
+		t.line++
+		t.lastLine = line
+	}
+}
+
+// checkUnchanged checks that the processed part of the log is still the one that was read before.
+func (t *logTail) checkUnchanged(file *os.File, info os.FileInfo) error {
+	if t.info == nil {
+		return nil
+	}
+	if !os.SameFile(t.info, info) {
+		return fmt.Errorf("%w: %s", ErrLogReplaced, t.path)
+	}
+	// Pseudo files such as the securityfs IMA log report a size of 0
+	if info.Mode().IsRegular() && info.Size() > 0 && info.Size() < t.offset {
+		return fmt.Errorf("%w: %s shrank from %d to %d bytes", ErrLogTruncated, t.path, t.offset, info.Size())
+	}
+	if len(t.lastLine) == 0 {
+		return nil
+	}
+
+	// Compare the last processed line, which catches logs that were truncated and written again
+	buf := make([]byte, len(t.lastLine))
+	if _, err := file.ReadAt(buf, t.offset-int64(len(buf))); err != nil {
+		if err == io.EOF {
+			return fmt.Errorf("%w: %s is shorter than %d bytes", ErrLogTruncated, t.path, t.offset)
+		}
+		return fmt.Errorf("failed to read log file: %w", err)
+	}
+	if !bytes.Equal(buf, t.lastLine) {
+		return fmt.Errorf("%w: line %d of %s was rewritten", ErrLogTruncated, t.line, t.path)
+	}
+	return nil
+}
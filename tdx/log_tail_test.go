@@ -0,0 +1,106 @@
+package tdx
+
+import (
+	"crypto"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func appendFile(t *testing.T, path, content string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func measureCount(t *testing.T, source MeasurementSource) int {
+	t.Helper()
+	events, err := source.Measure()
+	if err != nil {
+		t.Fatalf("Measure failed: %v", err)
+	}
+	return len(events)
+}
+
+func TestLogTailAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	writeFile(t, path, "one\ntwo\nthr")
+	source := NewEventFileSource(path, 3, crypto.SHA384)
+
+	if n := measureCount(t, source); n != 2 {
+		t.Fatalf("got %d events, want 2", n)
+	}
+
+	// The partial line is measured once complete
+	appendFile(t, path, "ee\n\nfour\n")
+	events, err := source.Measure()
+	if err != nil {
+		t.Fatalf("Measure failed: %v", err)
+	}
+	if len(events) != 2 || string(events[0].EventData) != "three" || events[1].SourceLine != 5 {
+		t.Errorf("unexpected events: %+v", events)
+	}
+	if n := measureCount(t, source); n != 0 {
+		t.Errorf("got %d events without changes", n)
+	}
+}
+
+func TestLogTailGoesBackwards(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(t *testing.T, path string)
+		want   error
+	}{
+		{"truncated", func(t *testing.T, path string) {
+			if err := os.Truncate(path, 4); err != nil {
+				t.Fatal(err)
+			}
+		}, ErrLogTruncated},
+		{"rewritten", func(t *testing.T, path string) {
+			f, err := os.OpenFile(path, os.O_WRONLY, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			f.WriteAt([]byte("TWO"), 4)
+		}, ErrLogTruncated},
+		{"replaced", func(t *testing.T, path string) {
+			tmp := path + ".new"
+			writeFile(t, tmp, "one\ntwo\nthree\n")
+			if err := os.Rename(tmp, path); err != nil {
+				t.Fatal(err)
+			}
+		}, ErrLogReplaced},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "events.log")
+			writeFile(t, path, "one\ntwo\n")
+			source := NewEventFileSource(path, 3, crypto.SHA384)
+			if n := measureCount(t, source); n != 2 {
+				t.Fatalf("got %d events, want 2", n)
+			}
+
+			tt.modify(t, path)
+			for i := 0; i < 2; i++ {
+				if _, err := source.Measure(); !errors.Is(err, tt.want) {
+					t.Errorf("Measure returned %v, want %v", err, tt.want)
+				}
+			}
+		})
+	}
+}
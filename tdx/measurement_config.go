@@ -0,0 +1,156 @@
+package tdx
+
+import (
+	"crypto"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Types of measurement sources in a MeasurementConfig.
+const (
+	SourceTypeIma       = "ima"        // IMA ascii_runtime_measurements log
+	SourceTypeEventFile = "event_file" // Application event file, one event per line
+	SourceTypeConfigDir = "config_dir" // Directory of config files
+	SourceTypeCcel      = "ccel"       // CCEL firmware and boot loader event log
+)
+
+// MeasurementConfig declares the sources measured into the RTMRs, in the order they are updated.
+//
+//	hash_algorithm: sha384
+//	sources:
+//	  - type: ccel
+//	    path: /sys/firmware/acpi/tables/data/CCEL
+//	    table_path: /sys/firmware/acpi/tables/CCEL
+//	  - type: ima
+//	    path: /sys/kernel/security/ima/ascii_runtime_measurements
+//	    rtmr_index: 2
+//	  - type: config_dir
+//	    path: /etc/lightbulb
+//	    rtmr_index: 3
+//	    hash_algorithm: sha256
+type MeasurementConfig struct {
+	HashAlgorithm string                    `yaml:"hash_algorithm"` // Algorithm of the RTMR extension, only sha384
+	Sources       []MeasurementSourceConfig `yaml:"sources"`        // Sources measured into the RTMRs
+}
+
+// MeasurementSourceConfig declares a single measurement source.
+type MeasurementSourceConfig struct {
+	Type          string `yaml:"type"`           // One of the SourceType constants
+	Path          string `yaml:"path"`           // Path to the log, file or directory
+	TablePath     string `yaml:"table_path"`     // Path to the CCEL ACPI table (ccel only, optional)
+	RtmrIndex     int    `yaml:"rtmr_index"`     // RTMR index the events are extended into (ignored for ccel)
+	HashAlgorithm string `yaml:"hash_algorithm"` // Algorithm of the source digests recorded with the events, if not sha384
+}
+
+// LoadMeasurementConfig reads a MeasurementConfig from a YAML file.
+func LoadMeasurementConfig(path string) (*MeasurementConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read measurement config: %w", err)
+	}
+	var config MeasurementConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse measurement config %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// DefaultMeasurementConfig returns the configuration used when no config file is given: the CCEL inside a TD or at
+// CCEL_DATA_PATH, followed by the IMA log at IMA_LOG_PATH into RTMR[2].
+func DefaultMeasurementConfig() *MeasurementConfig {
+	config := &MeasurementConfig{}
+
+	// Firmware events are read from the CCEL, which is only exposed inside a TD unless configured explicitly
+	ccelTablePath, ccelDataPath := os.Getenv("CCEL_TABLE_PATH"), os.Getenv("CCEL_DATA_PATH")
+	if ccelDataPath == "" && os.Getenv("ENV") == "TDX" {
+		ccelTablePath, ccelDataPath = DefaultCcelTablePath, DefaultCcelDataPath
+	}
+	if ccelDataPath != "" {
+		config.Sources = append(config.Sources, MeasurementSourceConfig{
+			Type:      SourceTypeCcel,
+			Path:      ccelDataPath,
+			TablePath: ccelTablePath,
+		})
+	}
+
+	if logPath := os.Getenv("IMA_LOG_PATH"); logPath != "" {
+		config.Sources = append(config.Sources, MeasurementSourceConfig{
+			Type:      SourceTypeIma,
+			Path:      logPath,
+			RtmrIndex: ImaRtmrIndex,
+		})
+	}
+	return config
+}
+
+// NewSources creates the measurement sources declared by the config.
+func (c *MeasurementConfig) NewSources() ([]MeasurementSource, error) {
+	rtmrHashAlgo, err := c.RtmrHashAlgo()
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []MeasurementSource
+	for i, sc := range c.Sources {
+		hashAlgo := rtmrHashAlgo
+		if sc.HashAlgorithm != "" {
+			if hashAlgo, err = ParseHashAlgorithm(sc.HashAlgorithm); err != nil {
+				return nil, fmt.Errorf("source %d: %w", i, err)
+			}
+		}
+		if sc.Path == "" {
+			return nil, fmt.Errorf("source %d: missing path", i)
+		}
+		if sc.Type != SourceTypeCcel && (sc.RtmrIndex < 0 || sc.RtmrIndex > 3) {
+			return nil, fmt.Errorf("source %d: invalid RTMR index %d", i, sc.RtmrIndex)
+		}
+
+		switch sc.Type {
+		case SourceTypeIma:
+			sources = append(sources, NewImaSource(sc.Path, sc.RtmrIndex, hashAlgo))
+		case SourceTypeEventFile:
+			sources = append(sources, NewEventFileSource(sc.Path, sc.RtmrIndex, hashAlgo))
+		case SourceTypeConfigDir:
+			sources = append(sources, NewConfigDirSource(sc.Path, sc.RtmrIndex, hashAlgo))
+		case SourceTypeCcel:
+			sources = append(sources, NewCcelSource(sc.TablePath, sc.Path, hashAlgo))
+		default:
+			return nil, fmt.Errorf("source %d: unknown type %q", i, sc.Type)
+		}
+	}
+	return sources, nil
+}
+
+// RtmrHashAlgo returns the algorithm of the RTMR extension. RTMRs are SHA-384 registers, as replayed by verifiers, so
+// any other algorithm is rejected.
+func (c *MeasurementConfig) RtmrHashAlgo() (crypto.Hash, error) {
+	if c.HashAlgorithm == "" {
+		return crypto.SHA384, nil
+	}
+	hashAlgo, err := ParseHashAlgorithm(c.HashAlgorithm)
+	if err != nil {
+		return 0, err
+	}
+	if hashAlgo != crypto.SHA384 {
+		return 0, fmt.Errorf("unsupported RTMR hash algorithm %v, RTMRs are SHA-384", hashAlgo)
+	}
+	return hashAlgo, nil
+}
+
+// ParseHashAlgorithm parses a hash algorithm name such as sha384 or SHA-384.
+func ParseHashAlgorithm(name string) (crypto.Hash, error) {
+	switch strings.ReplaceAll(strings.ToLower(name), "-", "") {
+	case "sha1":
+		return crypto.SHA1, nil
+	case "sha256":
+		return crypto.SHA256, nil
+	case "sha384":
+		return crypto.SHA384, nil
+	case "sha512":
+		return crypto.SHA512, nil
+	}
+	return 0, fmt.Errorf("unsupported hash algorithm %q", name)
+}
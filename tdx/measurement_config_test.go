@@ -0,0 +1,199 @@
+package tdx
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMeasurementConfig(t *testing.T) {
+	dir := t.TempDir()
+	eventFile := filepath.Join(dir, "events.log")
+	configDir := filepath.Join(dir, "config")
+	if err := os.WriteFile(eventFile, []byte("auction started\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(configDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "app.yaml"), []byte("port: 50051\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	configPath := filepath.Join(dir, "measurements.yaml")
+	config := strings.Join([]string{
+		"sources:",
+		"  - type: ima",
+		"    path: testdata/ima/ascii_runtime_measurements",
+		"    rtmr_index: 2",
+		"  - type: config_dir",
+		"    path: " + configDir,
+		"    rtmr_index: 3",
+		"  - type: event_file",
+		"    path: " + eventFile,
+		"    rtmr_index: 3",
+		"    hash_algorithm: sha256",
+	}, "\n")
+	if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("RTMR_CONFIG_PATH", configPath)
+	client := NewMockTDXClient()
+
+	// Replays the expected RTMR[3] from the SHA-384 digests of the config file and the events, whatever the algorithm
+	// of their source
+	want := make([]byte, RtmrSize)
+	extend := func(digest []byte) {
+		sum := sha512.Sum384(append(want, digest...))
+		want = sum[:]
+	}
+	configDigest := sha512.Sum384([]byte("port: 50051\n"))
+	extend(configDigest[:])
+	eventDigest := sha512.Sum384([]byte("auction started"))
+	extend(eventDigest[:])
+
+	rtmrs, err := client.GetRtmrs()
+	if err != nil {
+		t.Fatalf("GetRtmrs failed: %v", err)
+	}
+	if !bytes.Equal(rtmrs[3], want) {
+		t.Errorf("RTMR[3] = %x, want %x", rtmrs[3], want)
+	}
+	if !bytes.Equal(rtmrs[0], make([]byte, RtmrSize)) {
+		t.Errorf("RTMR[0] = %x, want zeros", rtmrs[0])
+	}
+
+	// New events and changed files are extended on the next update; unchanged files are not
+	if err := os.WriteFile(filepath.Join(configDir, "app.yaml"), []byte("port: 50052\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(eventFile, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("auction ended\n")
+	f.Close()
+
+	configDigest = sha512.Sum384([]byte("port: 50052\n"))
+	extend(configDigest[:])
+	eventDigest = sha512.Sum384([]byte("auction ended"))
+	extend(eventDigest[:])
+
+	// The quote reports the same four RTMRs
+	quote, err := GetQuote(client)
+	if err != nil {
+		t.Fatalf("GetQuote failed: %v", err)
+	}
+	rtmrs, err = client.GetRtmrs()
+	if err != nil {
+		t.Fatalf("GetRtmrs failed: %v", err)
+	}
+	for i, rtmr := range quote.GetTdQuoteBody().Rtmrs {
+		if !bytes.Equal(rtmr, rtmrs[i]) {
+			t.Errorf("quote RTMR[%d] = %x, GetRtmrs reports %x", i, rtmr, rtmrs[i])
+		}
+	}
+	if !bytes.Equal(rtmrs[3], want) {
+		t.Errorf("RTMR[3] = %x, want %x", rtmrs[3], want)
+	}
+	if got := hex.EncodeToString(rtmrs[ImaRtmrIndex]); got != imaFixtureRtmr {
+		t.Errorf("RTMR[2] = %s, want %s", got, imaFixtureRtmr)
+	}
+
+	// Removed files are measured by their relative path, and measured again if they come back
+	if err := os.Remove(filepath.Join(configDir, "app.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	removedDigest := sha512.Sum384([]byte("app.yaml"))
+	extend(removedDigest[:])
+	if rtmrs, err = client.GetRtmrs(); err != nil {
+		t.Fatalf("GetRtmrs failed: %v", err)
+	}
+	if !bytes.Equal(rtmrs[3], want) {
+		t.Errorf("RTMR[3] after the removal = %x, want %x", rtmrs[3], want)
+	}
+	page, err := client.GetEventLog(0, 0)
+	if err != nil {
+		t.Fatalf("GetEventLog failed: %v", err)
+	}
+	for _, event := range page.Events {
+		if event.EventType != AppEventType {
+			continue
+		}
+		digest := sha512.Sum384(event.EventData)
+		sourceDigest := sha256.Sum256(event.EventData)
+		if event.HashAlgo != crypto.SHA384 || !bytes.Equal(event.Digest, digest[:]) {
+			t.Errorf("event %d records a %v digest %x, want the SHA-384 digest of its data", event.Sequence, event.HashAlgo, event.Digest)
+		}
+		if event.SourceHashAlgo != crypto.SHA256 || !bytes.Equal(event.SourceDigest, sourceDigest[:]) {
+			t.Errorf("event %d records a %v source digest %x, want the SHA-256 digest of its data", event.Sequence, event.SourceHashAlgo, event.SourceDigest)
+		}
+	}
+	if last := page.Events[len(page.Events)-1]; last.EventType != ConfigRemovedEventType || string(last.EventData) != "app.yaml" {
+		t.Errorf("last event = %s %q, want the removal of app.yaml", last.EventType, last.EventData)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "app.yaml"), []byte("port: 50052\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	extend(configDigest[:])
+	if rtmrs, err = client.GetRtmrs(); err != nil {
+		t.Fatalf("GetRtmrs failed: %v", err)
+	}
+	if !bytes.Equal(rtmrs[3], want) {
+		t.Errorf("RTMR[3] after the file came back = %x, want %x", rtmrs[3], want)
+	}
+}
+
+func TestMeasurementConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config MeasurementConfig
+	}{
+		{"unknown type", MeasurementConfig{Sources: []MeasurementSourceConfig{{Type: "tpm", Path: "x"}}}},
+		{"invalid index", MeasurementConfig{Sources: []MeasurementSourceConfig{{Type: SourceTypeIma, Path: "x", RtmrIndex: 4}}}},
+		{"missing path", MeasurementConfig{Sources: []MeasurementSourceConfig{{Type: SourceTypeIma}}}},
+		{"unknown source algorithm", MeasurementConfig{Sources: []MeasurementSourceConfig{{Type: SourceTypeIma, Path: "x", HashAlgorithm: "md5"}}}},
+		{"unknown algorithm", MeasurementConfig{HashAlgorithm: "md5"}},
+		{"SHA-256 RTMRs", MeasurementConfig{HashAlgorithm: "sha256"}},
+		{"SHA-512 RTMRs", MeasurementConfig{HashAlgorithm: "sha512"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRtmrProviderFromConfig(&tt.config); err == nil {
+				t.Error("NewRtmrProviderFromConfig succeeded")
+			}
+		})
+	}
+
+	// A config file declaring SHA-256 RTMRs is refused rather than reporting RTMRs that no verifier replays
+	sha256Path := filepath.Join(t.TempDir(), "sha256.yaml")
+	if err := os.WriteFile(sha256Path, []byte("hash_algorithm: sha256\nsources: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("RTMR_CONFIG_PATH", sha256Path)
+	if err := DefaultRtmrProvider().UpdateRtmrs(); err == nil {
+		t.Error("UpdateRtmrs succeeded with SHA-256 RTMRs")
+	}
+
+	// Digests are extended as is, so only SHA-384 digests are accepted
+	provider, err := NewRtmrProviderFromConfig(&MeasurementConfig{})
+	if err != nil {
+		t.Fatalf("NewRtmrProviderFromConfig failed: %v", err)
+	}
+	if err := provider.ExtendRtmr(3, make([]byte, sha256.Size)); err == nil {
+		t.Error("ExtendRtmr succeeded with a SHA-256 digest")
+	}
+
+	// An invalid config file fails every update instead of reporting unconfigured measurements
+	t.Setenv("RTMR_CONFIG_PATH", filepath.Join(t.TempDir(), "missing.yaml"))
+	if err := DefaultRtmrProvider().UpdateRtmrs(); err == nil {
+		t.Error("UpdateRtmrs succeeded with a missing config file")
+	}
+}
@@ -0,0 +1,386 @@
+package tdx
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Event types recorded for the measurement sources that do not carry their own.
+const (
+	AppEventType           = "app"                 // Line of an application event file
+	ConfigEventType        = "config_file"         // File of a config directory
+	ConfigRemovedEventType = "config_file_removed" // File removed from a config directory
+)
+
+// MeasurementSource produces the events extended into the RTMRs.
+type MeasurementSource interface {
+	// Name identifies the source in errors and logs.
+	Name() string
+	// Measure returns the events that were not returned by previous calls. On error, the events measured before the
+	// error are returned along with it.
+	Measure() ([]MeasurementEvent, error)
+}
+
+// WatchedSource is a MeasurementSource backed by a file or directory whose changes can be watched.
+type WatchedSource interface {
+	MeasurementSource
+	// WatchPath returns the file or directory to watch.
+	WatchPath() string
+}
+
+// ImaSource measures the entries of an IMA ascii_runtime_measurements log. The log is tailed from the byte offset of
+// the last processed entry.
+type ImaSource struct {
+	rtmrIndex int         // RTMR index the entries are extended into
+	hashAlgo  crypto.Hash // Algorithm of the template hash recorded as source digest
+	mu        sync.Mutex  // Protects tail
+	tail      *logTail    // Position in the IMA log file
+}
+
+// NewImaSource creates a new ImaSource.
+func NewImaSource(path string, rtmrIndex int, hashAlgo crypto.Hash) *ImaSource {
+	return &ImaSource{
+		rtmrIndex: rtmrIndex,
+		hashAlgo:  hashAlgo,
+		tail:      newLogTail(path),
+	}
+}
+
+func (s *ImaSource) Name() string {
+	return "ima:" + s.tail.path
+}
+
+// WatchPath returns the IMA log file.
+func (s *ImaSource) WatchPath() string {
+	return s.tail.path
+}
+
+// Offset returns the byte offset of the first unprocessed entry.
+func (s *ImaSource) Offset() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tail.offset
+}
+
+// Measure reads the IMA log file and returns the entries appended since the last call. It fails without returning
+// any entry if the log was replaced, truncated or rewritten.
+func (s *ImaSource) Measure() ([]MeasurementEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []MeasurementEvent
+	err := s.tail.readLines(func(line []byte, lineNum int) error {
+		// Skip empty lines
+		if len(line) == 0 {
+			return nil
+		}
+
+		// Parse the current line as an IMA measurement entry
+		event, err := ParseImaAsciiLine(string(line))
+		if err != nil {
+			return fmt.Errorf("failed to parse event log on line %d: %w", lineNum, err)
+		}
+
+		// Measure the SHA-384 template hash the kernel extends for the entry
+		measured := MeasurementEvent{
+			RtmrIndex:  s.rtmrIndex,
+			Digest:     event.Digest(crypto.SHA384),
+			HashAlgo:   crypto.SHA384,
+			EventType:  event.TemplateName,
+			EventData:  event.TemplateData(),
+			Source:     s.tail.path,
+			SourceLine: lineNum,
+		}
+		measured.SourceDigest, measured.SourceHashAlgo = sourceDigest(s.hashAlgo, event.Digest)
+		events = append(events, measured)
+		return nil
+	})
+	return events, err
+}
+
+// EventFileSource measures an application event file. Every non-empty line is an event whose digest is the hash of
+// the line. The file is tailed like the IMA log.
+type EventFileSource struct {
+	rtmrIndex int         // RTMR index the events are extended into
+	hashAlgo  crypto.Hash // Algorithm of the source digests
+	mu        sync.Mutex  // Protects tail
+	tail      *logTail    // Position in the event file
+}
+
+// NewEventFileSource creates a new EventFileSource.
+func NewEventFileSource(path string, rtmrIndex int, hashAlgo crypto.Hash) *EventFileSource {
+	return &EventFileSource{
+		rtmrIndex: rtmrIndex,
+		hashAlgo:  hashAlgo,
+		tail:      newLogTail(path),
+	}
+}
+
+func (s *EventFileSource) Name() string {
+	return "event_file:" + s.tail.path
+}
+
+// WatchPath returns the event file.
+func (s *EventFileSource) WatchPath() string {
+	return s.tail.path
+}
+
+// Measure reads the event file and returns the lines appended since the last call.
+func (s *EventFileSource) Measure() ([]MeasurementEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []MeasurementEvent
+	err := s.tail.readLines(func(line []byte, lineNum int) error {
+		if len(line) == 0 {
+			return nil
+		}
+		data := append([]byte(nil), line...)
+		measured := MeasurementEvent{
+			RtmrIndex:  s.rtmrIndex,
+			Digest:     hashBytes(crypto.SHA384, data),
+			HashAlgo:   crypto.SHA384,
+			EventType:  AppEventType,
+			EventData:  data,
+			Source:     s.tail.path,
+			SourceLine: lineNum,
+		}
+		measured.SourceDigest, measured.SourceHashAlgo = sourceDigest(s.hashAlgo, dataDigest(data))
+		events = append(events, measured)
+		return nil
+	})
+	return events, err
+}
+
+// ConfigDirSource measures the regular files of a directory, in lexical order. A file is measured again whenever its
+// content changes. The event data is the path of the file relative to the directory; the digest is the hash of its
+// content. The removal of a measured file is measured as a ConfigRemovedEventType event, whose digest is the hash of
+// its relative path.
+type ConfigDirSource struct {
+	path      string            // Path to the config directory
+	rtmrIndex int               // RTMR index the files are extended into
+	hashAlgo  crypto.Hash       // Algorithm of the source digests
+	mu        sync.Mutex        // Protects measured
+	measured  map[string][]byte // Last measured SHA-384 digest of every file
+}
+
+// NewConfigDirSource creates a new ConfigDirSource.
+func NewConfigDirSource(path string, rtmrIndex int, hashAlgo crypto.Hash) *ConfigDirSource {
+	return &ConfigDirSource{
+		path:      path,
+		rtmrIndex: rtmrIndex,
+		hashAlgo:  hashAlgo,
+		measured:  make(map[string][]byte),
+	}
+}
+
+func (s *ConfigDirSource) Name() string {
+	return "config_dir:" + s.path
+}
+
+// WatchPath returns the config directory.
+func (s *ConfigDirSource) WatchPath() string {
+	return s.path
+}
+
+// Measure returns an event for every file that is new, changed or removed since the last call. Removals follow the
+// other events, in lexical order.
+func (s *ConfigDirSource) Measure() ([]MeasurementEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []MeasurementEvent
+	seen := make(map[string]bool)
+	err := filepath.WalkDir(s.path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(s.path, path)
+		if err != nil {
+			return err
+		}
+
+		seen[relPath] = true
+		digest := hashBytes(crypto.SHA384, content)
+		if previous, ok := s.measured[relPath]; ok && bytes.Equal(previous, digest) {
+			return nil
+		}
+		s.measured[relPath] = digest
+
+		measured := MeasurementEvent{
+			RtmrIndex: s.rtmrIndex,
+			Digest:    digest,
+			HashAlgo:  crypto.SHA384,
+			EventType: ConfigEventType,
+			EventData: []byte(filepath.ToSlash(relPath)),
+			Source:    s.path,
+		}
+		measured.SourceDigest, measured.SourceHashAlgo = sourceDigest(s.hashAlgo, dataDigest(content))
+		events = append(events, measured)
+		return nil
+	})
+	if err != nil {
+		return events, fmt.Errorf("failed to measure config directory: %w", err)
+	}
+
+	// Files missing from a complete walk were removed
+	var removed []string
+	for relPath := range s.measured {
+		if !seen[relPath] {
+			removed = append(removed, relPath)
+		}
+	}
+	sort.Strings(removed)
+	for _, relPath := range removed {
+		delete(s.measured, relPath)
+		data := []byte(filepath.ToSlash(relPath))
+		measured := MeasurementEvent{
+			RtmrIndex: s.rtmrIndex,
+			Digest:    hashBytes(crypto.SHA384, data),
+			HashAlgo:  crypto.SHA384,
+			EventType: ConfigRemovedEventType,
+			EventData: data,
+			Source:    s.path,
+		}
+		measured.SourceDigest, measured.SourceHashAlgo = sourceDigest(s.hashAlgo, dataDigest(data))
+		events = append(events, measured)
+	}
+	return events, nil
+}
+
+// CcelSource measures the firmware (RTMR[0]) and boot loader (RTMR[1] and RTMR[2]) events of the CCEL. The CCEL does
+// not change after boot, so its events are only returned once.
+type CcelSource struct {
+	tablePath string      // Path to the CCEL ACPI table, optional
+	dataPath  string      // Path to the CCEL event log
+	hashAlgo  crypto.Hash // Digest bank of the event log recorded as source digest
+	mu        sync.Mutex  // Protects loaded
+	loaded    bool        // Whether the events were returned
+}
+
+// NewCcelSource creates a new CcelSource. The table is optional; if given, it is validated and bounds the length of
+// the event log.
+func NewCcelSource(tablePath, dataPath string, hashAlgo crypto.Hash) *CcelSource {
+	return &CcelSource{
+		tablePath: tablePath,
+		dataPath:  dataPath,
+		hashAlgo:  hashAlgo,
+	}
+}
+
+func (s *CcelSource) Name() string {
+	return "ccel:" + s.dataPath
+}
+
+// Measure reads the CCEL event log and returns its events on the first call.
+func (s *CcelSource) Measure() ([]MeasurementEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loaded {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(s.dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CCEL event log: %w", err)
+	}
+
+	if s.tablePath != "" {
+		tableBytes, err := os.ReadFile(s.tablePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CCEL table: %w", err)
+		}
+		table, err := ParseCcelTable(tableBytes)
+		if err != nil {
+			return nil, err
+		}
+		if table.CcType != ccelTypeTdx {
+			return nil, fmt.Errorf("%w: CC type %d is not TDX", ErrInvalidCcel, table.CcType)
+		}
+		if table.LogAreaMinimumLength < uint64(len(data)) {
+			data = data[:table.LogAreaMinimumLength]
+		}
+	}
+
+	ccelEvents, err := ParseCcelEventLog(data)
+	if err != nil {
+		return nil, err
+	}
+	events, err := ccelMeasurementEvents(ccelEvents, s.hashAlgo, s.dataPath)
+	if err != nil {
+		return nil, err
+	}
+
+	s.loaded = true
+	return events, nil
+}
+
+// ccelMeasurementEvents converts the CCEL events extended into RTMRs, which are extended with their SHA-384 digest.
+// EV_NO_ACTION events and events measured into MRTD are skipped.
+func ccelMeasurementEvents(ccelEvents []*CcelEvent, hashAlgo crypto.Hash, source string) ([]MeasurementEvent, error) {
+	var events []MeasurementEvent
+	for i, event := range ccelEvents {
+		rtmrIndex, ok := event.RtmrIndex()
+		if !ok || event.EventType == EvNoAction {
+			continue
+		}
+		digest, err := event.Digest(crypto.SHA384)
+		if err != nil {
+			return nil, err
+		}
+		measured := MeasurementEvent{
+			RtmrIndex:  rtmrIndex,
+			Digest:     digest,
+			HashAlgo:   crypto.SHA384,
+			EventType:  event.EventTypeName(),
+			EventData:  event.Data,
+			Source:     source,
+			SourceLine: i + 1,
+		}
+		if hashAlgo != crypto.SHA384 {
+			if measured.SourceDigest, err = event.Digest(hashAlgo); err != nil {
+				return nil, err
+			}
+			measured.SourceHashAlgo = hashAlgo
+		}
+		events = append(events, measured)
+	}
+	return events, nil
+}
+
+// sourceDigest returns the digest of an event in the hash algorithm of its source, recorded as metadata along the
+// SHA-384 digest extended into the RTMR. Sources using SHA-384 record no source digest.
+func sourceDigest(hashAlgo crypto.Hash, digest func(crypto.Hash) []byte) ([]byte, crypto.Hash) {
+	if hashAlgo == crypto.SHA384 {
+		return nil, 0
+	}
+	return digest(hashAlgo), hashAlgo
+}
+
+// dataDigest returns a function hashing the data with the given algorithm.
+func dataDigest(data []byte) func(crypto.Hash) []byte {
+	return func(hashAlgo crypto.Hash) []byte {
+		return hashBytes(hashAlgo, data)
+	}
+}
+
+func hashBytes(hashAlgo crypto.Hash, data []byte) []byte {
+	hasher := hashAlgo.New()
+	hasher.Write(data)
+	return hasher.Sum(nil)
+}
@@ -0,0 +1,383 @@
+package tdx
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Latency distributions of a Latency.
+const (
+	LatencyConstant    = "constant"    // Always Mean
+	LatencyUniform     = "uniform"     // Uniform between Min and Max
+	LatencyNormal      = "normal"      // Normal around Mean with StdDev, never negative
+	LatencyExponential = "exponential" // Exponential with Mean, the long tail of a busy quoting enclave
+)
+
+// ErrInjectedFault is returned by a FaultInjectingTDXClient for the failures it injects.
+var ErrInjectedFault = errors.New("injected fault")
+
+// Latency is a distribution of delays. It is written as "100ms" (constant), "uniform:10ms-200ms",
+// "normal:100ms,20ms" (mean, standard deviation) or "exponential:50ms" (mean).
+type Latency struct {
+	Distribution string
+	Mean         time.Duration
+	StdDev       time.Duration
+	Min          time.Duration
+	Max          time.Duration
+}
+
+// ParseLatency parses a latency distribution, see Latency.
+func ParseLatency(value string) (Latency, error) {
+	distribution, params, found := strings.Cut(strings.TrimSpace(value), ":")
+	if !found {
+		mean, err := time.ParseDuration(distribution)
+		if err != nil {
+			return Latency{}, fmt.Errorf("invalid latency %q: %w", value, err)
+		}
+		return Latency{Distribution: LatencyConstant, Mean: mean}, nil
+	}
+
+	var sep string
+	switch distribution {
+	case LatencyConstant, LatencyExponential:
+	case LatencyUniform:
+		sep = "-"
+	case LatencyNormal:
+		sep = ","
+	default:
+		return Latency{}, fmt.Errorf("invalid latency %q: unknown distribution %q", value, distribution)
+	}
+
+	var durations []time.Duration
+	parts := []string{params}
+	if sep != "" {
+		parts = strings.Split(params, sep)
+		if len(parts) != 2 {
+			return Latency{}, fmt.Errorf("invalid latency %q: want two durations separated by %q", value, sep)
+		}
+	}
+	for _, part := range parts {
+		duration, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil || duration < 0 {
+			return Latency{}, fmt.Errorf("invalid latency %q: bad duration %q", value, part)
+		}
+		durations = append(durations, duration)
+	}
+
+	latency := Latency{Distribution: distribution}
+	switch distribution {
+	case LatencyConstant, LatencyExponential:
+		latency.Mean = durations[0]
+	case LatencyUniform:
+		latency.Min, latency.Max = durations[0], durations[1]
+		if latency.Max < latency.Min {
+			return Latency{}, fmt.Errorf("invalid latency %q: max is below min", value)
+		}
+	case LatencyNormal:
+		latency.Mean, latency.StdDev = durations[0], durations[1]
+	}
+	return latency, nil
+}
+
+// UnmarshalYAML parses a latency written as in ParseLatency.
+func (l *Latency) UnmarshalYAML(node *yaml.Node) error {
+	var value string
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	latency, err := ParseLatency(value)
+	if err != nil {
+		return err
+	}
+	*l = latency
+	return nil
+}
+
+// sample draws a delay from the distribution.
+func (l Latency) sample(rng *rand.Rand) time.Duration {
+	var delay float64
+	switch l.Distribution {
+	case LatencyConstant:
+		delay = float64(l.Mean)
+	case LatencyUniform:
+		delay = float64(l.Min) + rng.Float64()*float64(l.Max-l.Min)
+	case LatencyNormal:
+		delay = math.Max(0, float64(l.Mean)+rng.NormFloat64()*float64(l.StdDev))
+	case LatencyExponential:
+		delay = rng.ExpFloat64() * float64(l.Mean)
+	}
+	return time.Duration(delay)
+}
+
+// MockFaultConfig configures the faults and delays injected by a FaultInjectingTDXClient. Error rates are
+// probabilities between 0 and 1.
+type MockFaultConfig struct {
+	Seed                     int64    `yaml:"seed"`                       // Seed of the fault schedule, random if 0
+	QuoteProviderUnavailable bool     `yaml:"quote_provider_unavailable"` // GetQuoteProvider always fails
+	QuoteProviderErrorRate   float64  `yaml:"quote_provider_error_rate"`  // Rate of GetQuoteProvider failures
+	QuoteErrorRate           float64  `yaml:"quote_error_rate"`           // Rate of GetQuote failures
+	QuoteLatency             Latency  `yaml:"quote_latency"`              // Delay of GetQuote
+	RtmrReadErrorRate        float64  `yaml:"rtmr_read_error_rate"`       // Rate of GetRtmrs failures
+	RtmrExtendErrorRate      float64  `yaml:"rtmr_extend_error_rate"`     // Rate of ExtendRtmr failures
+	RtmrLatency              Latency  `yaml:"rtmr_latency"`               // Delay of GetRtmrs and ExtendRtmr
+	QuoteFixtures            []string `yaml:"quote_fixtures"`             // Raw quote files or directories served in turn by GetQuote
+}
+
+// LoadMockFaultConfig reads a MockFaultConfig from a YAML file. Relative fixture paths are resolved against the
+// directory of the file.
+func LoadMockFaultConfig(path string) (*MockFaultConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mock fault config: %w", err)
+	}
+	var config MockFaultConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse mock fault config %s: %w", path, err)
+	}
+	for i, fixture := range config.QuoteFixtures {
+		if !filepath.IsAbs(fixture) {
+			config.QuoteFixtures[i] = filepath.Join(filepath.Dir(path), fixture)
+		}
+	}
+	return &config, config.validate()
+}
+
+// DefaultMockFaultConfig reads the config file at MOCK_FAULT_CONFIG_PATH, then applies MOCK_QUOTE_ERROR_RATE,
+// MOCK_QUOTE_LATENCY, MOCK_QUOTE_PROVIDER_UNAVAILABLE, MOCK_RTMR_ERROR_RATE (the rate of both RTMR reads and extends)
+// and MOCK_QUOTE_FIXTURES (a comma separated list of paths) on top of it. It returns nil if none of them is set.
+func DefaultMockFaultConfig() (*MockFaultConfig, error) {
+	config := &MockFaultConfig{}
+	set := false
+	if path := os.Getenv("MOCK_FAULT_CONFIG_PATH"); path != "" {
+		loaded, err := LoadMockFaultConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		config, set = loaded, true
+	}
+
+	if value := os.Getenv("MOCK_QUOTE_ERROR_RATE"); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MOCK_QUOTE_ERROR_RATE '%s': %w", value, err)
+		}
+		config.QuoteErrorRate, set = rate, true
+	}
+	if value := os.Getenv("MOCK_QUOTE_LATENCY"); value != "" {
+		latency, err := ParseLatency(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MOCK_QUOTE_LATENCY: %w", err)
+		}
+		config.QuoteLatency, set = latency, true
+	}
+	if value := os.Getenv("MOCK_QUOTE_PROVIDER_UNAVAILABLE"); value != "" {
+		unavailable, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MOCK_QUOTE_PROVIDER_UNAVAILABLE '%s': %w", value, err)
+		}
+		config.QuoteProviderUnavailable, set = unavailable, true
+	}
+	if value := os.Getenv("MOCK_RTMR_ERROR_RATE"); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MOCK_RTMR_ERROR_RATE '%s': %w", value, err)
+		}
+		config.RtmrReadErrorRate, config.RtmrExtendErrorRate, set = rate, rate, true
+	}
+	if value := os.Getenv("MOCK_QUOTE_FIXTURES"); value != "" {
+		config.QuoteFixtures, set = strings.Split(value, ","), true
+	}
+
+	if !set {
+		return nil, nil
+	}
+	return config, config.validate()
+}
+
+// validate checks that the error rates are probabilities.
+func (c *MockFaultConfig) validate() error {
+	rates := map[string]float64{
+		"quote provider error rate": c.QuoteProviderErrorRate,
+		"quote error rate":          c.QuoteErrorRate,
+		"RTMR read error rate":      c.RtmrReadErrorRate,
+		"RTMR extend error rate":    c.RtmrExtendErrorRate,
+	}
+	for name, rate := range rates {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("invalid mock fault config: %s %v is not between 0 and 1", name, rate)
+		}
+	}
+	return nil
+}
+
+// FaultInjectingTDXClient wraps a TDX client, usually a MockTDXClient, to fail and delay its calls as configured. It
+// reproduces the slow and broken quoting stacks of real hosts in tests and local runs.
+type FaultInjectingTDXClient struct {
+	TDXClientInterface
+	config   MockFaultConfig
+	fixtures [][]byte // Raw quotes served instead of the wrapped client's
+
+	mu          sync.Mutex // Protects rng and nextFixture
+	rng         *rand.Rand
+	nextFixture int
+}
+
+// NewFaultInjectingTDXClient wraps a TDX client with the given faults, loading the quote fixtures from disk.
+func NewFaultInjectingTDXClient(client TDXClientInterface, config MockFaultConfig) (*FaultInjectingTDXClient, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	fixtures, err := loadQuoteFixtures(config.QuoteFixtures)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &FaultInjectingTDXClient{
+		TDXClientInterface: client,
+		config:             config,
+		fixtures:           fixtures,
+		rng:                rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// DefaultMockTDXClient creates a MockTDXClient, wrapped with the faults of DefaultMockFaultConfig if any is set. An
+// invalid fault config is an error rather than a client without faults, so that runs meant to be faulty never pass
+// silently.
+func DefaultMockTDXClient() (TDXClientInterface, error) {
+	client := NewMockTDXClient()
+	config, err := DefaultMockFaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return client, nil
+	}
+
+	faulty, err := NewFaultInjectingTDXClient(client, *config)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[Info] Injecting faults into the mock TDX client: %+v", *config)
+	return faulty, nil
+}
+
+// loadQuoteFixtures reads raw quotes from files, and from the files of directories in name order.
+func loadQuoteFixtures(paths []string) ([][]byte, error) {
+	var fixtures [][]byte
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read quote fixture: %w", err)
+		}
+		files := []string{path}
+		if info.IsDir() {
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read quote fixtures: %w", err)
+			}
+			files = files[:0]
+			for _, entry := range entries {
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+			sort.Strings(files)
+		}
+
+		for _, file := range files {
+			rawQuote, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read quote fixture: %w", err)
+			}
+			if _, err := parseRawQuote(rawQuote); err != nil {
+				return nil, fmt.Errorf("invalid quote fixture %s: %w", file, err)
+			}
+			fixtures = append(fixtures, rawQuote)
+		}
+	}
+	return fixtures, nil
+}
+
+// GetQuoteProvider fails if the provider is configured unavailable, or at the configured rate.
+func (c *FaultInjectingTDXClient) GetQuoteProvider() (interface{}, error) {
+	if c.config.QuoteProviderUnavailable {
+		return nil, fmt.Errorf("%w: quote provider unavailable", ErrInjectedFault)
+	}
+	if c.fail(c.config.QuoteProviderErrorRate) {
+		return nil, fmt.Errorf("%w: quote provider error", ErrInjectedFault)
+	}
+	return c.TDXClientInterface.GetQuoteProvider()
+}
+
+// GetQuote delays and fails at the configured rate, then serves the next quote fixture if any, or the quote of the
+// wrapped client. Fixtures do not carry the requested report data.
+func (c *FaultInjectingTDXClient) GetQuote(provider interface{}, reportData [64]byte) (interface{}, error) {
+	c.delay(c.config.QuoteLatency)
+	if c.fail(c.config.QuoteErrorRate) {
+		return nil, fmt.Errorf("%w: quote generation error", ErrInjectedFault)
+	}
+
+	if len(c.fixtures) > 0 {
+		c.mu.Lock()
+		rawQuote := c.fixtures[c.nextFixture%len(c.fixtures)]
+		c.nextFixture++
+		c.mu.Unlock()
+		return parseRawQuote(rawQuote)
+	}
+	return c.TDXClientInterface.GetQuote(provider, reportData)
+}
+
+// GetRtmrs delays and fails at the configured rate.
+func (c *FaultInjectingTDXClient) GetRtmrs() ([4][]byte, error) {
+	c.delay(c.config.RtmrLatency)
+	if c.fail(c.config.RtmrReadErrorRate) {
+		return [4][]byte{}, fmt.Errorf("%w: RTMR read error", ErrInjectedFault)
+	}
+	return c.TDXClientInterface.GetRtmrs()
+}
+
+// ExtendRtmr delays and fails at the configured rate.
+func (c *FaultInjectingTDXClient) ExtendRtmr(index int, eventType string, eventData []byte) error {
+	c.delay(c.config.RtmrLatency)
+	if c.fail(c.config.RtmrExtendErrorRate) {
+		return fmt.Errorf("%w: RTMR extend error", ErrInjectedFault)
+	}
+	return c.TDXClientInterface.ExtendRtmr(index, eventType, eventData)
+}
+
+// fail reports whether the call fails, with the given probability.
+func (c *FaultInjectingTDXClient) fail(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rng.Float64() < rate
+}
+
+// delay sleeps for a delay drawn from the latency distribution.
+func (c *FaultInjectingTDXClient) delay(latency Latency) {
+	if latency.Distribution == "" {
+		return
+	}
+	c.mu.Lock()
+	d := latency.sample(c.rng)
+	c.mu.Unlock()
+	time.Sleep(d)
+}
@@ -0,0 +1,189 @@
+package tdx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+func newFaultInjectingClient(t *testing.T, config MockFaultConfig) *FaultInjectingTDXClient {
+	t.Helper()
+	tdxtest.ClearEnv(t)
+	client, err := NewFaultInjectingTDXClient(NewMockTDXClient(), config)
+	if err != nil {
+		t.Fatalf("NewFaultInjectingTDXClient failed: %v", err)
+	}
+	return client
+}
+
+// getQuote requests a quote from the attestation service with a fresh challenge.
+func getQuote(server *Server, userData []byte) (*attestpb.GetQuoteResponse, error) {
+	challenge, err := server.GetChallenge(context.Background(), &attestpb.GetChallengeRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return server.GetQuote(context.Background(), &attestpb.GetQuoteRequest{Challenge: challenge.GetChallenge(), ReportData: userData})
+}
+
+func TestParseLatency(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Latency
+	}{
+		{"100ms", Latency{Distribution: LatencyConstant, Mean: 100 * time.Millisecond}},
+		{"uniform:10ms-200ms", Latency{Distribution: LatencyUniform, Min: 10 * time.Millisecond, Max: 200 * time.Millisecond}},
+		{"normal:100ms, 20ms", Latency{Distribution: LatencyNormal, Mean: 100 * time.Millisecond, StdDev: 20 * time.Millisecond}},
+		{"exponential:50ms", Latency{Distribution: LatencyExponential, Mean: 50 * time.Millisecond}},
+	}
+	for _, test := range tests {
+		got, err := ParseLatency(test.value)
+		if err != nil {
+			t.Errorf("ParseLatency(%q) failed: %v", test.value, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("ParseLatency(%q) = %+v, want %+v", test.value, got, test.want)
+		}
+	}
+
+	for _, value := range []string{"fast", "uniform:200ms-10ms", "uniform:10ms", "pareto:1s", "exponential:-1s"} {
+		if _, err := ParseLatency(value); err == nil {
+			t.Errorf("ParseLatency(%q) succeeded", value)
+		}
+	}
+}
+
+func TestInjectedFaults(t *testing.T) {
+	t.Run("quote errors", func(t *testing.T) {
+		client := newFaultInjectingClient(t, MockFaultConfig{QuoteErrorRate: 1})
+		_, err := getQuote(NewServer(client, nil), nil)
+		if status.Code(err) != codes.Internal {
+			t.Errorf("GetQuote = %v, want Internal", err)
+		}
+		if _, err := GetQuote(client); !errors.Is(err, ErrInjectedFault) {
+			t.Errorf("GetQuote = %v, want ErrInjectedFault", err)
+		}
+	})
+
+	t.Run("quote provider unavailable", func(t *testing.T) {
+		client := newFaultInjectingClient(t, MockFaultConfig{QuoteProviderUnavailable: true})
+		if _, err := GetQuote(client); !errors.Is(err, ErrInjectedFault) {
+			t.Errorf("GetQuote = %v, want ErrInjectedFault", err)
+		}
+	})
+
+	t.Run("RTMR read errors", func(t *testing.T) {
+		client := newFaultInjectingClient(t, MockFaultConfig{RtmrReadErrorRate: 1})
+		if _, err := client.GetRtmrs(); !errors.Is(err, ErrInjectedFault) {
+			t.Errorf("GetRtmrs = %v, want ErrInjectedFault", err)
+		}
+		// Challenged quotes bind the RTMR digest
+		_, err := getQuote(NewServer(client, nil), []byte("nonce"))
+		if status.Code(err) != codes.Internal {
+			t.Errorf("GetQuote = %v, want Internal", err)
+		}
+	})
+
+	t.Run("error rate", func(t *testing.T) {
+		client := newFaultInjectingClient(t, MockFaultConfig{Seed: 1, RtmrExtendErrorRate: 0.5})
+		failures := 0
+		for i := 0; i < 200; i++ {
+			if err := client.ExtendRtmr(3, "test", []byte{byte(i)}); err != nil {
+				failures++
+			}
+		}
+		if failures < 60 || failures > 140 {
+			t.Errorf("%d of 200 extensions failed, want about 100", failures)
+		}
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		client := newFaultInjectingClient(t, MockFaultConfig{QuoteLatency: Latency{Distribution: LatencyConstant, Mean: 50 * time.Millisecond}})
+		start := time.Now()
+		if _, err := getQuote(NewServer(client, nil), nil); err != nil {
+			t.Fatalf("GetQuote failed: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("GetQuote took %v, want at least 50ms", elapsed)
+		}
+	})
+}
+
+func TestQuoteFixtures(t *testing.T) {
+	tdxtest.ClearEnv(t)
+
+	// Capture a quote to replay
+	quote, _, err := GetQuoteWithReportData(NewMockTDXClient(), []byte("fixture"))
+	if err != nil {
+		t.Fatalf("GetQuoteWithReportData failed: %v", err)
+	}
+	rawQuote, err := utils.ConvertQuoteToRawQuote(quote)
+	if err != nil {
+		t.Fatalf("ConvertQuoteToRawQuote failed: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "quotes"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "quotes", "quote.dat"), rawQuote, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(dir, "faults.yaml")
+	config := "seed: 1\nquote_latency: uniform:1ms-2ms\nquote_fixtures: [quotes]\n"
+	if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("MOCK_FAULT_CONFIG_PATH", configPath)
+	t.Setenv("MOCK_RTMR_ERROR_RATE", "0")
+	defaultClient, err := DefaultMockTDXClient()
+	if err != nil {
+		t.Fatalf("DefaultMockTDXClient failed: %v", err)
+	}
+	client, ok := defaultClient.(*FaultInjectingTDXClient)
+	if !ok {
+		t.Fatal("DefaultMockTDXClient did not inject faults")
+	}
+	if client.config.QuoteLatency.Distribution != LatencyUniform {
+		t.Errorf("quote latency = %+v, want uniform", client.config.QuoteLatency)
+	}
+
+	// The fixture is served whatever the requested report data
+	served, _, err := GetQuoteWithReportData(client, []byte("other"))
+	if err != nil {
+		t.Fatalf("GetQuoteWithReportData failed: %v", err)
+	}
+	if !bytes.Equal(served.GetTdQuoteBody().GetReportData(), quote.GetTdQuoteBody().GetReportData()) {
+		t.Errorf("REPORTDATA = %x, want the fixture's %x", served.GetTdQuoteBody().GetReportData(), quote.GetTdQuoteBody().GetReportData())
+	}
+
+	// MOCK_RTMR_ERROR_RATE fails both RTMR reads and extends
+	t.Setenv("MOCK_RTMR_ERROR_RATE", "0.5")
+	faults, err := DefaultMockFaultConfig()
+	if err != nil {
+		t.Fatalf("DefaultMockFaultConfig failed: %v", err)
+	}
+	if faults.RtmrReadErrorRate != 0.5 || faults.RtmrExtendErrorRate != 0.5 {
+		t.Errorf("RTMR error rates = %v, %v, want 0.5, 0.5", faults.RtmrReadErrorRate, faults.RtmrExtendErrorRate)
+	}
+
+	t.Setenv("MOCK_QUOTE_ERROR_RATE", "2")
+	if _, err := DefaultMockFaultConfig(); err == nil {
+		t.Error("DefaultMockFaultConfig with an error rate of 2 succeeded")
+	}
+	if _, err := DefaultMockTDXClient(); err == nil {
+		t.Error("DefaultMockTDXClient with an invalid config succeeded")
+	}
+}
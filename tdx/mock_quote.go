@@ -0,0 +1,463 @@
+package tdx
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/google/go-tdx-guest/abi"
+	"github.com/google/go-tdx-guest/pcs"
+
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+
+	tdxpb "github.com/google/go-tdx-guest/proto/tdx"
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+const (
+	mockCertValidity = 10 * 365 * 24 * time.Hour // Validity of the generated certificates, outliving any mock server
+
+	certificationDataTypePckChain = 5 // Concatenated PCK Cert Chain
+	certificationDataTypeQeReport = 6 // QE Report Certification Data
+
+	quoteV4AuthDataKnownSize   = 0x80  // Signature and attestation key
+	certificationDataKnownSize = 0x06  // Type and size of certification data
+	qeReportSize               = 0x180 // Size of an enclave report
+	qeReportSignatureSize      = 0x40  // Size of the QE report signature
+	qeAuthDataKnownSize        = 0x02  // Size of the parsed data size field
+	pckChainKnownSize          = 0x06  // Type and size of the PCK chain
+)
+
+var (
+	intelQeVendorID = []byte{0x93, 0x9a, 0x72, 0x33, 0xf7, 0x9c, 0x4c, 0xa9, 0x94, 0x0a, 0x0d, 0xb3, 0x95, 0x7f, 0x06, 0x07}
+	mockFmspc       = []byte{0x00, 0x80, 0x6f, 0x05, 0x00, 0x00}
+	mockPceID       = []byte{0x00, 0x00}
+)
+
+// MockQuoteConfig holds the TD measurements reported by mock quotes. Nil fields use zeros.
+type MockQuoteConfig struct {
+	TeeTcbSvn     []byte    // 16 bytes
+	MrSeam        []byte    // 48 bytes
+	MrSignerSeam  []byte    // 48 bytes
+	TdAttributes  []byte    // 8 bytes
+	Xfam          []byte    // 8 bytes
+	MrTd          []byte    // 48 bytes
+	MrConfigId    []byte    // 48 bytes
+	MrOwner       []byte    // 48 bytes
+	MrOwnerConfig []byte    // 48 bytes
+	Rtmrs         [4][]byte // 48 bytes each; nil RTMRs report the values of the RtmrProvider
+	QuoteVersion  uint16    // 4 or 5; zero produces version 4 quotes
+}
+
+// MockQuoteSigner emits signed v4 and v5 quotes from a throwaway root CA, PCK and attestation key hierarchy.
+type MockQuoteSigner struct {
+	rootCert         *x509.Certificate
+	intermediateCert *x509.Certificate
+	pckCert          *x509.Certificate
+	pckKey           *ecdsa.PrivateKey
+	attestationKey   *ecdsa.PrivateKey
+	pckCertChain     []byte // PEM encoded PCK leaf, intermediate and root certificates
+}
+
+var (
+	defaultMockSignerOnce sync.Once
+	defaultMockSigner     *MockQuoteSigner
+	defaultMockSignerErr  error
+)
+
+// DefaultMockQuoteSigner returns the signer shared by all mock clients of this process.
+func DefaultMockQuoteSigner() (*MockQuoteSigner, error) {
+	defaultMockSignerOnce.Do(func() {
+		defaultMockSigner, defaultMockSignerErr = NewMockQuoteSigner()
+	})
+	return defaultMockSigner, defaultMockSignerErr
+}
+
+// NewMockQuoteSigner generates a new certificate hierarchy shaped like Intel's PCK chain.
+func NewMockQuoteSigner() (*MockQuoteSigner, error) {
+	now := time.Now()
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate root key: %w", err)
+	}
+	rootTemplate, err := caTemplate("Intel SGX Root CA", 1, now)
+	if err != nil {
+		return nil, err
+	}
+	rootCert, err := createCertificate(rootTemplate, rootTemplate, &rootKey.PublicKey, rootKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create root certificate: %w", err)
+	}
+
+	intermediateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate intermediate key: %w", err)
+	}
+	intermediateTemplate, err := caTemplate("Intel SGX PCK Platform CA", 0, now)
+	if err != nil {
+		return nil, err
+	}
+	intermediateCert, err := createCertificate(intermediateTemplate, rootCert, &intermediateKey.PublicKey, rootKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create intermediate certificate: %w", err)
+	}
+
+	pckKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PCK key: %w", err)
+	}
+	pckTemplate, err := pckTemplate(now)
+	if err != nil {
+		return nil, err
+	}
+	pckCert, err := createCertificate(pckTemplate, intermediateCert, &pckKey.PublicKey, intermediateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PCK certificate: %w", err)
+	}
+
+	attestationKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate attestation key: %w", err)
+	}
+
+	var chain []byte
+	for _, cert := range []*x509.Certificate{pckCert, intermediateCert, rootCert} {
+		chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+
+	return &MockQuoteSigner{
+		rootCert:         rootCert,
+		intermediateCert: intermediateCert,
+		pckCert:          pckCert,
+		pckKey:           pckKey,
+		attestationKey:   attestationKey,
+		pckCertChain:     chain,
+	}, nil
+}
+
+// RootCertificate returns the generated root CA certificate.
+func (s *MockQuoteSigner) RootCertificate() *x509.Certificate {
+	return s.rootCert
+}
+
+// RootCertificatePEM returns the generated root CA certificate in PEM format.
+func (s *MockQuoteSigner) RootCertificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.rootCert.Raw})
+}
+
+// SignQuote builds a v4 quote around the TD quote body and signs it.
+func (s *MockQuoteSigner) SignQuote(body *tdxpb.TDQuoteBody) (*tdxpb.QuoteV4, error) {
+	header := &tdxpb.Header{
+		Version:            abi.QuoteVersion,
+		AttestationKeyType: abi.AttestationKeyType,
+		TeeType:            abi.TeeTDX,
+		QeSvn:              []byte{0x08, 0x00},
+		PceSvn:             []byte{0x0d, 0x00},
+		QeVendorId:         intelQeVendorID,
+		UserData:           make([]byte, 20),
+	}
+
+	// Sign the header and the TD quote body with the attestation key
+	headerBytes, err := abi.HeaderToAbiBytes(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize header: %w", err)
+	}
+	bodyBytes, err := abi.TdQuoteBodyToAbiBytes(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize TD quote body: %w", err)
+	}
+	signature, err := signRaw(s.attestationKey, append(headerBytes, bodyBytes...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign quote: %w", err)
+	}
+	attestationKey, err := rawPublicKey(&s.attestationKey.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode attestation key: %w", err)
+	}
+
+	// The QE report binds the attestation key and is signed by the PCK key
+	qeAuthData := &tdxpb.QeAuthData{
+		ParsedDataSize: 32,
+		Data:           make([]byte, 32),
+	}
+	keyHash := sha256.Sum256(append(append([]byte{}, attestationKey...), qeAuthData.Data...))
+	qeReport := &tdxpb.EnclaveReport{
+		CpuSvn:     make([]byte, 16),
+		Reserved1:  make([]byte, 28),
+		Attributes: make([]byte, 16),
+		MrEnclave:  make([]byte, 32),
+		Reserved2:  make([]byte, 32),
+		MrSigner:   make([]byte, 32),
+		Reserved3:  make([]byte, 96),
+		IsvProdId:  2,
+		IsvSvn:     8,
+		Reserved4:  make([]byte, 60),
+		ReportData: append(keyHash[:], make([]byte, 32)...),
+	}
+	qeReportBytes, err := abi.EnclaveReportToAbiBytes(qeReport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize QE report: %w", err)
+	}
+	qeReportSignature, err := signRaw(s.pckKey, qeReportBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign QE report: %w", err)
+	}
+
+	chainData := &tdxpb.PCKCertificateChainData{
+		CertificateDataType: certificationDataTypePckChain,
+		Size:                uint32(len(s.pckCertChain)),
+		PckCertChain:        s.pckCertChain,
+	}
+	certificationDataSize := qeReportSize + qeReportSignatureSize +
+		qeAuthDataKnownSize + qeAuthData.ParsedDataSize +
+		pckChainKnownSize + chainData.Size
+
+	return &tdxpb.QuoteV4{
+		Header:         header,
+		TdQuoteBody:    body,
+		SignedDataSize: quoteV4AuthDataKnownSize + certificationDataKnownSize + certificationDataSize,
+		SignedData: &tdxpb.Ecdsa256BitQuoteV4AuthData{
+			Signature:           signature,
+			EcdsaAttestationKey: attestationKey,
+			CertificationData: &tdxpb.CertificationData{
+				CertificateDataType: certificationDataTypeQeReport,
+				Size:                certificationDataSize,
+				QeReportCertificationData: &tdxpb.QEReportCertificationData{
+					QeReport:                qeReport,
+					QeReportSignature:       qeReportSignature,
+					QeAuthData:              qeAuthData,
+					PckCertificateChainData: chainData,
+				},
+			},
+		},
+	}, nil
+}
+
+// SignQuoteV5 builds a version 5 quote with a TDX 1.5 body around the TD quote body and signs it, as TDX 1.5 hosts
+// do. TEE_TCB_SVN_2 and MRSERVICETD are zeros.
+func (s *MockQuoteSigner) SignQuoteV5(body *tdxpb.TDQuoteBody) (*attestpb.Quote, error) {
+	quoteV4, err := s.SignQuote(body)
+	if err != nil {
+		return nil, err
+	}
+	quote := utils.ConvertQuoteV4ToQuote(quoteV4)
+	quote.Header.Version = utils.QuoteVersion5
+	quote.Body = &attestpb.Quote_TdQuoteBodyV5{TdQuoteBodyV5: &attestpb.TDQuoteBodyV5{
+		BodyType:    utils.BodyTypeTdx15,
+		TdQuoteBody: quote.GetTdQuoteBody(),
+		TeeTcbSvn2:  make([]byte, 16),
+		MrServicetd: make([]byte, 48),
+	}}
+
+	// Sign the version 5 header and body instead
+	raw, err := utils.ConvertQuoteToRawQuote(quote)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize quote: %w", err)
+	}
+	signedData, err := utils.SignedQuoteData(raw)
+	if err != nil {
+		return nil, err
+	}
+	if quote.SignedData.Signature, err = signRaw(s.attestationKey, signedData); err != nil {
+		return nil, fmt.Errorf("failed to sign quote: %w", err)
+	}
+	return quote, nil
+}
+
+// NewTDQuoteBody builds a TD quote body from the config, the RTMR values and the report data.
+func (c MockQuoteConfig) NewTDQuoteBody(rtmrs [4][]byte, reportData [64]byte) *tdxpb.TDQuoteBody {
+	body := &tdxpb.TDQuoteBody{
+		TeeTcbSvn:      fixedSize(c.TeeTcbSvn, 16),
+		MrSeam:         fixedSize(c.MrSeam, 48),
+		MrSignerSeam:   fixedSize(c.MrSignerSeam, 48),
+		SeamAttributes: make([]byte, 8),
+		TdAttributes:   fixedSize(c.TdAttributes, 8),
+		Xfam:           fixedSize(c.Xfam, 8),
+		MrTd:           fixedSize(c.MrTd, 48),
+		MrConfigId:     fixedSize(c.MrConfigId, 48),
+		MrOwner:        fixedSize(c.MrOwner, 48),
+		MrOwnerConfig:  fixedSize(c.MrOwnerConfig, 48),
+		ReportData:     append([]byte{}, reportData[:]...),
+	}
+	for i := range rtmrs {
+		rtmr := c.Rtmrs[i]
+		if rtmr == nil {
+			rtmr = rtmrs[i]
+		}
+		body.Rtmrs = append(body.Rtmrs, fixedSize(rtmr, RtmrSize))
+	}
+	return body
+}
+
+// caTemplate returns a certificate template for a CA with the given common name.
+func caTemplate(commonName string, maxPathLen int, now time.Time) (*x509.Certificate, error) {
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := randomBytes(20)
+	if err != nil {
+		return nil, err
+	}
+	return &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"Intel Corporation"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(mockCertValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLen:            maxPathLen,
+		MaxPathLenZero:        maxPathLen == 0,
+		SubjectKeyId:          keyID,
+	}, nil
+}
+
+// pckTemplate returns a PCK leaf certificate template carrying the SGX extensions.
+func pckTemplate(now time.Time) (*x509.Certificate, error) {
+	sgxExtension, err := marshalSgxExtension()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode SGX extension: %w", err)
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := randomBytes(20)
+	if err != nil {
+		return nil, err
+	}
+	return &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "Intel SGX PCK Certificate", Organization: []string{"Intel Corporation"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(mockCertValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageContentCommitment,
+		BasicConstraintsValid: true,
+		SubjectKeyId:          keyID,
+		CRLDistributionPoints: []string{"https://localhost/sgx/certification/v4/pckcrl?ca=platform&encoding=der"},
+		ExtraExtensions:       []pkix.Extension{{Id: pcs.OidSgxExtension, Value: sgxExtension}},
+	}, nil
+}
+
+type sgxOctetExtension struct {
+	Id    asn1.ObjectIdentifier
+	Value []byte
+}
+
+type sgxIntExtension struct {
+	Id    asn1.ObjectIdentifier
+	Value int
+}
+
+type sgxSequenceExtension struct {
+	Id    asn1.ObjectIdentifier
+	Value []asn1.RawValue
+}
+
+// marshalSgxExtension encodes the PPID, TCB, PCEID and FMSPC entries of a PCK certificate.
+func marshalSgxExtension() ([]byte, error) {
+	var tcb []asn1.RawValue
+	for i := 1; i <= 16; i++ {
+		component := append(append(asn1.ObjectIdentifier{}, pcs.OidTCB...), i)
+		if err := appendRaw(&tcb, sgxIntExtension{Id: component, Value: 0}); err != nil {
+			return nil, err
+		}
+	}
+	if err := appendRaw(&tcb, sgxIntExtension{Id: pcs.OidPCESvn, Value: 13}); err != nil {
+		return nil, err
+	}
+	if err := appendRaw(&tcb, sgxOctetExtension{Id: pcs.OidCPUSvn, Value: make([]byte, 16)}); err != nil {
+		return nil, err
+	}
+
+	ppid, err := randomBytes(16)
+	if err != nil {
+		return nil, err
+	}
+	var entries []asn1.RawValue
+	for _, entry := range []any{
+		sgxOctetExtension{Id: pcs.OidPPID, Value: ppid},
+		sgxSequenceExtension{Id: pcs.OidTCB, Value: tcb},
+		sgxOctetExtension{Id: pcs.OidPCEID, Value: mockPceID},
+		sgxOctetExtension{Id: pcs.OidFMSPC, Value: mockFmspc},
+	} {
+		if err := appendRaw(&entries, entry); err != nil {
+			return nil, err
+		}
+	}
+	return asn1.Marshal(entries)
+}
+
+func appendRaw(values *[]asn1.RawValue, v any) error {
+	der, err := asn1.Marshal(v)
+	if err != nil {
+		return err
+	}
+	*values = append(*values, asn1.RawValue{FullBytes: der})
+	return nil
+}
+
+func createCertificate(template, parent *x509.Certificate, pub *ecdsa.PublicKey, priv *ecdsa.PrivateKey) (*x509.Certificate, error) {
+	template.SignatureAlgorithm = x509.ECDSAWithSHA256
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// signRaw signs the SHA-256 digest of the message and returns the signature as r || s.
+func signRaw(key *ecdsa.PrivateKey, message []byte) ([]byte, error) {
+	digest := sha256.Sum256(message)
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return signature, nil
+}
+
+// rawPublicKey returns the public key as X || Y.
+func rawPublicKey(pub *ecdsa.PublicKey) ([]byte, error) {
+	key, err := pub.ECDH()
+	if err != nil {
+		return nil, err
+	}
+	// Strip the uncompressed point prefix
+	return key.Bytes()[1:], nil
+}
+
+func fixedSize(b []byte, size int) []byte {
+	out := make([]byte, size)
+	copy(out, b)
+	return out
+}
+
+func randomSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	return serial, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,164 @@
+package tdx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-tdx-guest/abi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+func TestMockQuoteVerifies(t *testing.T) {
+	tdxtest.ClearEnv(t)
+	mrTd := bytes.Repeat([]byte{0x11}, 48)
+	rtmr0 := bytes.Repeat([]byte{0x22}, 48)
+	client := NewMockTDXClientWithConfig(MockQuoteConfig{
+		MrTd:         mrTd,
+		TdAttributes: []byte{0x01, 0, 0, 0, 0, 0, 0, 0},
+		Rtmrs:        [4][]byte{rtmr0},
+	})
+
+	quote, err := GetQuote(client)
+	if err != nil {
+		t.Fatalf("GetQuote failed: %v", err)
+	}
+	if !bytes.Equal(quote.GetTdQuoteBody().MrTd, mrTd) {
+		t.Errorf("MRTD = %x, want %x", quote.GetTdQuoteBody().MrTd, mrTd)
+	}
+	if !bytes.Equal(quote.GetTdQuoteBody().Rtmrs[0], rtmr0) {
+		t.Errorf("RTMR[0] = %x, want %x", quote.GetTdQuoteBody().Rtmrs[0], rtmr0)
+	}
+
+	signer, err := DefaultMockQuoteSigner()
+	if err != nil {
+		t.Fatalf("DefaultMockQuoteSigner failed: %v", err)
+	}
+	roots, err := verifier.ParseTrustedRoots(signer.RootCertificatePEM())
+	if err != nil {
+		t.Fatalf("ParseTrustedRoots failed: %v", err)
+	}
+
+	result, err := verifier.NewVerifier(verifier.Options{TrustedRoots: roots}).VerifyQuote(quote)
+	if err != nil {
+		t.Fatalf("VerifyQuote failed: %v", err)
+	}
+	if !result.Verified {
+		t.Errorf("mock quote not verified")
+	}
+	if notAfter := result.PckCertificate.NotAfter; time.Until(notAfter) < 365*24*time.Hour {
+		t.Errorf("PCK certificate expires at %v, want a validity of years", notAfter)
+	}
+
+	// The mock quote must survive serialization to the wire format
+	raw, err := abi.QuoteToAbiBytes(utils.ConvertQuoteToQuoteV4(quote))
+	if err != nil {
+		t.Fatalf("QuoteToAbiBytes failed: %v", err)
+	}
+	if _, err := verifier.NewVerifier(verifier.Options{TrustedRoots: roots}).VerifyRawQuote(raw); err != nil {
+		t.Errorf("VerifyRawQuote failed: %v", err)
+	}
+
+	// Quotes are not trusted under Intel's root
+	if _, err := verifier.NewVerifier(verifier.Options{}).VerifyQuote(quote); err == nil {
+		t.Errorf("mock quote verified against Intel root")
+	}
+}
+
+func TestMockQuoteV5Verifies(t *testing.T) {
+	tdxtest.ClearEnv(t)
+	mrTd := bytes.Repeat([]byte{0x11}, 48)
+	client := NewMockTDXClientWithConfig(MockQuoteConfig{MrTd: mrTd, QuoteVersion: utils.QuoteVersion5})
+
+	quote, _, err := GetQuoteWithReportData(client, []byte("user data"))
+	if err != nil {
+		t.Fatalf("GetQuoteWithReportData failed: %v", err)
+	}
+	if quote.GetHeader().GetVersion() != utils.QuoteVersion5 || quote.GetTdQuoteBodyV5() == nil {
+		t.Fatalf("quote version %d, want a version 5 quote", quote.GetHeader().GetVersion())
+	}
+
+	signer, err := DefaultMockQuoteSigner()
+	if err != nil {
+		t.Fatalf("DefaultMockQuoteSigner failed: %v", err)
+	}
+	roots, err := verifier.ParseTrustedRoots(signer.RootCertificatePEM())
+	if err != nil {
+		t.Fatalf("ParseTrustedRoots failed: %v", err)
+	}
+	v := verifier.NewVerifier(verifier.Options{TrustedRoots: roots})
+
+	result, err := v.VerifyQuote(quote)
+	if err != nil {
+		t.Fatalf("VerifyQuote failed: %v", err)
+	}
+	if !result.Verified || result.Version != utils.QuoteVersion5 {
+		t.Errorf("VerifyQuote = verified %v, version %d", result.Verified, result.Version)
+	}
+	if !bytes.Equal(result.Quote.GetTdQuoteBody().GetMrTd(), mrTd) {
+		t.Errorf("verified MRTD = %x, want %x", result.Quote.GetTdQuoteBody().GetMrTd(), mrTd)
+	}
+	policy := &verifier.Policy{MrTd: []verifier.HexBytes{mrTd}}
+	if err := policy.Check(quote, nil); err != nil {
+		t.Errorf("policy check of the version 5 quote failed: %v", err)
+	}
+
+	raw, err := utils.ConvertQuoteToRawQuote(quote)
+	if err != nil {
+		t.Fatalf("ConvertQuoteToRawQuote failed: %v", err)
+	}
+	if _, err := v.VerifyRawQuote(raw); err != nil {
+		t.Errorf("VerifyRawQuote failed: %v", err)
+	}
+
+	// The signature covers the TDX 1.5 fields, and the TDX 1.0 fields alone are not a valid version 4 quote
+	tampered, err := utils.ConvertRawQuoteToQuote(raw)
+	if err != nil {
+		t.Fatalf("ConvertRawQuoteToQuote failed: %v", err)
+	}
+	tampered.GetTdQuoteBodyV5().MrServicetd[0] ^= 0x01
+	if _, err := v.VerifyQuote(tampered); !errors.Is(err, verifier.ErrVerificationFailed) {
+		t.Errorf("VerifyQuote of a tampered quote = %v, want ErrVerificationFailed", err)
+	}
+	if _, err := v.VerifyQuoteV4(utils.ConvertQuoteV5ToQuoteV4(quote)); !errors.Is(err, verifier.ErrVerificationFailed) {
+		t.Errorf("VerifyQuoteV4 of the TDX 1.0 fields = %v, want ErrVerificationFailed", err)
+	}
+	if _, err := verifier.NewVerifier(verifier.Options{}).VerifyQuote(quote); err == nil {
+		t.Errorf("mock quote verified against Intel root")
+	}
+}
+
+func TestGetRawQuote(t *testing.T) {
+	tdxtest.ClearEnv(t)
+	server := NewServer(NewMockTDXClient(), nil)
+
+	challenge, err := server.GetChallenge(context.Background(), &attestpb.GetChallengeRequest{})
+	if err != nil {
+		t.Fatalf("GetChallenge failed: %v", err)
+	}
+	resp, err := server.GetRawQuote(context.Background(), &attestpb.GetQuoteRequest{ReportData: []byte("nonce"), Challenge: challenge.GetChallenge()})
+	if err != nil {
+		t.Fatalf("GetRawQuote failed: %v", err)
+	}
+	quote, err := utils.ConvertRawQuoteToQuote(resp.RawQuote)
+	if err != nil {
+		t.Fatalf("ConvertRawQuoteToQuote failed: %v", err)
+	}
+	if !bytes.Equal(quote.GetTdQuoteBody().ReportData, resp.ReportDataBinding.ReportData) {
+		t.Errorf("REPORTDATA = %x, want %x", quote.GetTdQuoteBody().ReportData, resp.ReportDataBinding.ReportData)
+	}
+
+	_, err = server.GetRawQuote(context.Background(), &attestpb.GetQuoteRequest{ReportData: make([]byte, 65)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetRawQuote error = %v, want InvalidArgument", err)
+	}
+}
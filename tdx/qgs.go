@@ -0,0 +1,363 @@
+package tdx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-tdx-guest/client"
+	"github.com/google/go-tdx-guest/client/linuxabi"
+)
+
+// Messages of the Quote Generation Service (QGS) protocol of Intel DCAP. Every message starts with a qgsHeader, and
+// is framed on the socket by its size as a 4 byte big endian integer.
+const (
+	QgsMajorVersion = 1 // Major version of the QGS message protocol
+	QgsMinorVersion = 0 // Minor version of the QGS message protocol
+
+	QgsMsgGetQuoteReq  = 0 // GET_QUOTE_REQ message type
+	QgsMsgGetQuoteResp = 1 // GET_QUOTE_RESP message type
+
+	qgsHeaderSize       = 16      // Size of qgsHeader
+	qgsGetQuoteReqSize  = 8       // Size of the fields of a GET_QUOTE_REQ after the header
+	qgsGetQuoteRespSize = 8       // Size of the fields of a GET_QUOTE_RESP after the header
+	qgsMaxMessageSize   = 1 << 20 // Upper bound of the messages accepted from the socket
+)
+
+// Error codes of QGS responses.
+const (
+	QgsSuccess               = 0x00000000
+	QgsErrorUnexpected       = 0x00012000
+	QgsErrorOutOfMemory      = 0x00012001
+	QgsErrorInvalidParameter = 0x00012002
+	QgsErrorInvalidVersion   = 0x00012003
+	QgsErrorInvalidType      = 0x00012004
+	QgsErrorInvalidSize      = 0x00012005
+	QgsErrorInvalidCode      = 0x00012006
+)
+
+const (
+	DefaultQgsAddress = "vsock:2:4050"   // The QGS of the host, as configured in /etc/tdx-attest.conf
+	DefaultQgsTimeout = 30 * time.Second // Quote generation can take seconds on a busy host
+)
+
+// ErrInvalidQgsMessage is returned when a QGS message is malformed.
+var ErrInvalidQgsMessage = errors.New("invalid QGS message")
+
+// QgsError is the error code of a failed QGS request.
+type QgsError struct {
+	Code uint32
+}
+
+func (e *QgsError) Error() string {
+	names := map[uint32]string{
+		QgsErrorUnexpected:       "unexpected error",
+		QgsErrorOutOfMemory:      "out of memory",
+		QgsErrorInvalidParameter: "invalid parameter",
+		QgsErrorInvalidVersion:   "invalid version",
+		QgsErrorInvalidType:      "invalid message type",
+		QgsErrorInvalidSize:      "invalid size",
+		QgsErrorInvalidCode:      "invalid code",
+	}
+	if name, ok := names[e.Code]; ok {
+		return fmt.Sprintf("QGS request failed: %s (0x%x)", name, e.Code)
+	}
+	return fmt.Sprintf("QGS request failed: error 0x%x", e.Code)
+}
+
+// qgsHeader is the header of every QGS message. Size covers the whole message, header included.
+type qgsHeader struct {
+	MajorVersion uint16
+	MinorVersion uint16
+	Type         uint32
+	Size         uint32
+	ErrorCode    uint32
+}
+
+// encodeQgsGetQuoteReq encodes a GET_QUOTE_REQ carrying a TDREPORT and no attestation key ID list.
+func encodeQgsGetQuoteReq(tdReport []byte) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, qgsHeader{
+		MajorVersion: QgsMajorVersion,
+		MinorVersion: QgsMinorVersion,
+		Type:         QgsMsgGetQuoteReq,
+		Size:         uint32(qgsHeaderSize + qgsGetQuoteReqSize + len(tdReport)),
+	})
+	binary.Write(&buf, binary.LittleEndian, uint32(len(tdReport))) // report_size
+	binary.Write(&buf, binary.LittleEndian, uint32(0))             // id_list_size
+	buf.Write(tdReport)
+	return buf.Bytes()
+}
+
+// decodeQgsGetQuoteReq returns the TDREPORT of a GET_QUOTE_REQ, or the QGS error code of a malformed request.
+func decodeQgsGetQuoteReq(msg []byte) ([]byte, uint32) {
+	header, code := decodeQgsHeader(msg, QgsMsgGetQuoteReq)
+	if code != QgsSuccess {
+		return nil, code
+	}
+	if header.Size < qgsHeaderSize+qgsGetQuoteReqSize {
+		return nil, QgsErrorInvalidSize
+	}
+	reportSize := binary.LittleEndian.Uint32(msg[qgsHeaderSize:])
+	idListSize := binary.LittleEndian.Uint32(msg[qgsHeaderSize+4:])
+	if uint64(header.Size) != uint64(qgsHeaderSize+qgsGetQuoteReqSize)+uint64(reportSize)+uint64(idListSize) {
+		return nil, QgsErrorInvalidSize
+	}
+	if reportSize != TdReportSize {
+		return nil, QgsErrorInvalidParameter
+	}
+	offset := qgsHeaderSize + qgsGetQuoteReqSize
+	return msg[offset : offset+TdReportSize], QgsSuccess
+}
+
+// encodeQgsGetQuoteResp encodes a GET_QUOTE_RESP with a quote, or with the error code alone.
+func encodeQgsGetQuoteResp(quote []byte, code uint32) []byte {
+	if code != QgsSuccess {
+		quote = nil
+	}
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, qgsHeader{
+		MajorVersion: QgsMajorVersion,
+		MinorVersion: QgsMinorVersion,
+		Type:         QgsMsgGetQuoteResp,
+		Size:         uint32(qgsHeaderSize + qgsGetQuoteRespSize + len(quote)),
+		ErrorCode:    code,
+	})
+	binary.Write(&buf, binary.LittleEndian, uint32(0))          // selected_id_size
+	binary.Write(&buf, binary.LittleEndian, uint32(len(quote))) // quote_size
+	buf.Write(quote)
+	return buf.Bytes()
+}
+
+// decodeQgsGetQuoteResp returns the quote of a GET_QUOTE_RESP.
+func decodeQgsGetQuoteResp(msg []byte) ([]byte, error) {
+	header, code := decodeQgsHeader(msg, QgsMsgGetQuoteResp)
+	if code != QgsSuccess {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQgsMessage, &QgsError{Code: code})
+	}
+	if header.ErrorCode != QgsSuccess {
+		return nil, &QgsError{Code: header.ErrorCode}
+	}
+	if header.Size < qgsHeaderSize+qgsGetQuoteRespSize {
+		return nil, fmt.Errorf("%w: response of %d bytes", ErrInvalidQgsMessage, header.Size)
+	}
+	selectedIDSize := binary.LittleEndian.Uint32(msg[qgsHeaderSize:])
+	quoteSize := binary.LittleEndian.Uint32(msg[qgsHeaderSize+4:])
+	if uint64(header.Size) != uint64(qgsHeaderSize+qgsGetQuoteRespSize)+uint64(selectedIDSize)+uint64(quoteSize) {
+		return nil, fmt.Errorf("%w: response sizes do not add up", ErrInvalidQgsMessage)
+	}
+	if quoteSize == 0 {
+		return nil, fmt.Errorf("%w: empty quote", ErrInvalidQgsMessage)
+	}
+	offset := qgsHeaderSize + qgsGetQuoteRespSize + int(selectedIDSize)
+	return msg[offset : offset+int(quoteSize)], nil
+}
+
+// decodeQgsHeader checks the header of a message of the expected type and returns it, or the QGS error code
+// describing the problem.
+func decodeQgsHeader(msg []byte, msgType uint32) (qgsHeader, uint32) {
+	var header qgsHeader
+	if len(msg) < qgsHeaderSize {
+		return header, QgsErrorInvalidSize
+	}
+	binary.Read(bytes.NewReader(msg), binary.LittleEndian, &header)
+	if header.MajorVersion != QgsMajorVersion {
+		return header, QgsErrorInvalidVersion
+	}
+	if header.Type != msgType {
+		return header, QgsErrorInvalidType
+	}
+	if uint64(header.Size) != uint64(len(msg)) {
+		return header, QgsErrorInvalidSize
+	}
+	return header, QgsSuccess
+}
+
+// writeQgsMessage writes a message prefixed by its size.
+func writeQgsMessage(w io.Writer, msg []byte) error {
+	frame := make([]byte, 4+len(msg))
+	binary.BigEndian.PutUint32(frame, uint32(len(msg)))
+	copy(frame[4:], msg)
+	_, err := w.Write(frame)
+	return err
+}
+
+// readQgsMessage reads a message prefixed by its size.
+func readQgsMessage(r io.Reader) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n < qgsHeaderSize || n > qgsMaxMessageSize {
+		return nil, fmt.Errorf("%w: message of %d bytes", ErrInvalidQgsMessage, n)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// ParseQgsAddress parses a QGS address, either "unix:<path>" or "vsock:<cid>:<port>".
+func ParseQgsAddress(address string) (network string, addr string, err error) {
+	network, addr, found := strings.Cut(address, ":")
+	if !found || addr == "" {
+		return "", "", fmt.Errorf("invalid QGS address %q", address)
+	}
+	switch network {
+	case "unix":
+	case "vsock":
+		cid, port, found := strings.Cut(addr, ":")
+		if !found {
+			return "", "", fmt.Errorf("invalid QGS address %q: want vsock:<cid>:<port>", address)
+		}
+		if _, err := strconv.ParseUint(cid, 10, 32); err != nil {
+			return "", "", fmt.Errorf("invalid QGS address %q: bad CID", address)
+		}
+		if _, err := strconv.ParseUint(port, 10, 32); err != nil {
+			return "", "", fmt.Errorf("invalid QGS address %q: bad port", address)
+		}
+	default:
+		return "", "", fmt.Errorf("invalid QGS address %q: unsupported network %q", address, network)
+	}
+	return network, addr, nil
+}
+
+// TdReportSource produces the TDREPORT of the TD for the given report data.
+type TdReportSource interface {
+	GetTdReport(reportData [64]byte) ([]byte, error)
+}
+
+// DeviceTdReportSource gets TDREPORTs through the TDX_CMD_GET_REPORT0 ioctl of the TDX guest device.
+type DeviceTdReportSource struct{}
+
+func (DeviceTdReportSource) GetTdReport(reportData [64]byte) ([]byte, error) {
+	device, err := client.OpenDevice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open TDX guest device: %w", err)
+	}
+	defer device.Close()
+
+	req := linuxabi.TdxReportReq{ReportData: reportData}
+	result, err := device.Ioctl(linuxabi.IocTdxGetReport, &req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TDREPORT: %w", err)
+	}
+	if result != uintptr(linuxabi.TdxAttestSuccess) {
+		return nil, fmt.Errorf("failed to get TDREPORT: status %d", result)
+	}
+	return req.TdReport[:], nil
+}
+
+// MockTdReportSource builds TDREPORTs from a MockQuoteConfig and the RTMR values of a TDX client, for use outside of
+// a TD. The MAC of the reports is left zero.
+type MockTdReportSource struct {
+	Config MockQuoteConfig
+	Rtmrs  func() ([4][]byte, error) // Returns the RTMR values reported in the TDREPORT
+}
+
+func (s *MockTdReportSource) GetTdReport(reportData [64]byte) ([]byte, error) {
+	rtmrs, err := s.Rtmrs()
+	if err != nil {
+		return nil, err
+	}
+	return BuildTdReport(s.Config.NewTDQuoteBody(rtmrs, reportData))
+}
+
+// QgsQuoteProvider gets quotes from a Quote Generation Service for the TDREPORTs of a TdReportSource.
+type QgsQuoteProvider struct {
+	Address      string         // QGS address, see ParseQgsAddress
+	Timeout      time.Duration  // Deadline of a quote request
+	ReportSource TdReportSource // Source of the TDREPORTs sent to the QGS
+}
+
+// GetRawQuote sends the TDREPORT for the report data to the QGS and returns the quote of the response.
+func (p *QgsQuoteProvider) GetRawQuote(reportData [64]byte) ([]byte, error) {
+	tdReport, err := p.ReportSource.GetTdReport(reportData)
+	if err != nil {
+		return nil, err
+	}
+
+	network, addr, err := ParseQgsAddress(p.Address)
+	if err != nil {
+		return nil, err
+	}
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultQgsTimeout
+	}
+	conn, err := dialQgs(network, addr, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to QGS at %s: %w", p.Address, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	if err := writeQgsMessage(conn, encodeQgsGetQuoteReq(tdReport)); err != nil {
+		return nil, fmt.Errorf("failed to send QGS request: %w", err)
+	}
+	resp, err := readQgsMessage(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read QGS response: %w", err)
+	}
+	return decodeQgsGetQuoteResp(resp)
+}
+
+// dialQgs connects to a QGS over a Unix socket, or over vsock where supported.
+func dialQgs(network, addr string, timeout time.Duration) (net.Conn, error) {
+	if network == "vsock" {
+		return dialVsock(addr, timeout)
+	}
+	return net.DialTimeout(network, addr, timeout)
+}
+
+// QgsTDXClient is a TDXClient generating quotes through a Quote Generation Service instead of the TDX guest device.
+type QgsTDXClient struct {
+	*TDXClient
+	quoteProvider *QgsQuoteProvider
+}
+
+// NewQgsTDXClient creates a new QgsTDXClient sending the TDREPORTs of the source to the QGS at the address.
+func NewQgsTDXClient(address string, source TdReportSource) *QgsTDXClient {
+	return &QgsTDXClient{
+		TDXClient:     NewTDXClient(),
+		quoteProvider: &QgsQuoteProvider{Address: address, ReportSource: source},
+	}
+}
+
+// DefaultQgsTDXClient creates a QgsTDXClient sending the TDREPORTs of the TDX guest device to the QGS at
+// QGS_ADDRESS, or at DefaultQgsAddress if unset.
+func DefaultQgsTDXClient() *QgsTDXClient {
+	address := os.Getenv("QGS_ADDRESS")
+	if address == "" {
+		address = DefaultQgsAddress
+	}
+	return NewQgsTDXClient(address, DeviceTdReportSource{})
+}
+
+// GetQuoteProvider returns the QGS quote provider.
+func (c *QgsTDXClient) GetQuoteProvider() (interface{}, error) {
+	return c.quoteProvider, nil
+}
+
+// GetQuote gets a raw quote from the QGS and parses it.
+func (c *QgsTDXClient) GetQuote(provider interface{}, reportData [64]byte) (interface{}, error) {
+	qgsProvider, ok := provider.(*QgsQuoteProvider)
+	if !ok {
+		return nil, fmt.Errorf("unexpected quote provider type: %T", provider)
+	}
+
+	rawQuote, err := qgsProvider.GetRawQuote(reportData)
+	if err != nil {
+		return nil, err
+	}
+	return parseRawQuote(rawQuote)
+}
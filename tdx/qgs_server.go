@@ -0,0 +1,146 @@
+package tdx
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net"
+	"sync"
+
+	"github.com/google/go-tdx-guest/abi"
+)
+
+// QgsServer is a stand-in Quote Generation Service. It answers GET_QUOTE_REQ messages with quotes of the TDREPORT
+// fields signed by a MockQuoteSigner, without checking the MAC of the reports. It is meant for tests and local runs
+// of the QGS backend, never for production.
+type QgsServer struct {
+	signer *MockQuoteSigner
+
+	mu        sync.Mutex
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	closed    bool
+	wg        sync.WaitGroup
+}
+
+// NewQgsServer creates a stand-in QGS signing quotes with the signer.
+func NewQgsServer(signer *MockQuoteSigner) *QgsServer {
+	return &QgsServer{
+		signer:    signer,
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}
+}
+
+// Serve accepts connections on the listener until Close is called. Each connection may carry several requests.
+func (s *QgsServer) Serve(lis net.Listener) error {
+	if !s.track(lis, nil) {
+		lis.Close()
+		return net.ErrClosed
+	}
+
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return nil
+			}
+			return err
+		}
+		if !s.track(nil, conn) {
+			conn.Close()
+			return nil
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serveConn(conn)
+		}()
+	}
+}
+
+// Close stops the listeners and the connections, and waits for the requests in flight.
+func (s *QgsServer) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	for lis := range s.listeners {
+		lis.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return nil
+}
+
+// track registers a listener or a connection, unless the server is closed.
+func (s *QgsServer) track(lis net.Listener, conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	if lis != nil {
+		s.listeners[lis] = struct{}{}
+	}
+	if conn != nil {
+		s.conns[conn] = struct{}{}
+	}
+	return true
+}
+
+func (s *QgsServer) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// serveConn answers the requests of a connection until it is closed.
+func (s *QgsServer) serveConn(conn net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		msg, err := readQgsMessage(conn)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !s.isClosed() {
+				log.Printf("[Error] Failed to read QGS request: %v", err)
+			}
+			return
+		}
+		if err := writeQgsMessage(conn, s.handle(msg)); err != nil {
+			log.Printf("[Error] Failed to write QGS response: %v", err)
+			return
+		}
+	}
+}
+
+// handle answers a single message.
+func (s *QgsServer) handle(msg []byte) []byte {
+	tdReport, code := decodeQgsGetQuoteReq(msg)
+	if code != QgsSuccess {
+		return encodeQgsGetQuoteResp(nil, code)
+	}
+
+	body, err := ParseTdReport(tdReport)
+	if err != nil {
+		return encodeQgsGetQuoteResp(nil, QgsErrorInvalidParameter)
+	}
+	quote, err := s.signer.SignQuote(body)
+	if err != nil {
+		log.Printf("[Error] Failed to sign quote: %v", err)
+		return encodeQgsGetQuoteResp(nil, QgsErrorUnexpected)
+	}
+	rawQuote, err := abi.QuoteToAbiBytes(quote)
+	if err != nil {
+		log.Printf("[Error] Failed to serialize quote: %v", err)
+		return encodeQgsGetQuoteResp(nil, QgsErrorUnexpected)
+	}
+	return encodeQgsGetQuoteResp(rawQuote, QgsSuccess)
+}
@@ -0,0 +1,163 @@
+package tdx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+)
+
+// serveQgs serves a stand-in QGS on a Unix socket and returns its address and signer.
+func serveQgs(t *testing.T) (string, *MockQuoteSigner) {
+	t.Helper()
+	// Unix socket paths are limited to about 100 bytes, which t.TempDir may exceed
+	dir, err := os.MkdirTemp("", "qgs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socketPath := filepath.Join(dir, "qgs.sock")
+
+	lis, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	signer, err := NewMockQuoteSigner()
+	if err != nil {
+		t.Fatalf("NewMockQuoteSigner failed: %v", err)
+	}
+	server := NewQgsServer(signer)
+	go server.Serve(lis)
+	t.Cleanup(func() { server.Close() })
+	return "unix:" + socketPath, signer
+}
+
+func TestTdReport(t *testing.T) {
+	config := MockQuoteConfig{
+		MrTd:         bytes.Repeat([]byte{0x11}, 48),
+		MrConfigId:   bytes.Repeat([]byte{0x22}, 48),
+		TdAttributes: []byte{0, 0, 0, 0x10, 0, 0, 0, 0},
+	}
+	rtmrs := [4][]byte{bytes.Repeat([]byte{0xa0}, 48), nil, bytes.Repeat([]byte{0xa2}, 48), nil}
+	var reportData [64]byte
+	copy(reportData[:], "report data")
+	body := config.NewTDQuoteBody(rtmrs, reportData)
+
+	report, err := BuildTdReport(body)
+	if err != nil {
+		t.Fatalf("BuildTdReport failed: %v", err)
+	}
+	if !bytes.Equal(report[tdReportMrTdOffset:tdReportMrTdOffset+48], config.MrTd) {
+		t.Errorf("MRTD not at its TDREPORT offset")
+	}
+	parsed, err := ParseTdReport(report)
+	if err != nil {
+		t.Fatalf("ParseTdReport failed: %v", err)
+	}
+	if !proto.Equal(parsed, body) {
+		t.Errorf("ParseTdReport = %v, want %v", parsed, body)
+	}
+
+	if _, err := ParseTdReport(report[:100]); err == nil {
+		t.Error("ParseTdReport of a short report succeeded")
+	}
+}
+
+func TestQgsTDXClient(t *testing.T) {
+	tdxtest.ClearEnv(t)
+	t.Setenv("IMA_LOG_PATH", "testdata/ima/ascii_runtime_measurements")
+	address, signer := serveQgs(t)
+
+	mrTd := bytes.Repeat([]byte{0x11}, 48)
+	client := NewQgsTDXClient(address, nil)
+	client.quoteProvider.ReportSource = &MockTdReportSource{Config: MockQuoteConfig{MrTd: mrTd}, Rtmrs: client.GetRtmrs}
+
+	quote, binding, err := GetQuoteWithReportData(client, []byte("nonce"))
+	if err != nil {
+		t.Fatalf("GetQuoteWithReportData failed: %v", err)
+	}
+	body := quote.GetTdQuoteBody()
+	if !bytes.Equal(body.GetReportData(), binding.GetReportData()) {
+		t.Errorf("REPORTDATA = %x, want %x", body.GetReportData(), binding.GetReportData())
+	}
+	if !bytes.Equal(body.GetMrTd(), mrTd) {
+		t.Errorf("MRTD = %x, want %x", body.GetMrTd(), mrTd)
+	}
+	rtmrs, err := client.GetRtmrs()
+	if err != nil {
+		t.Fatalf("GetRtmrs failed: %v", err)
+	}
+	if !bytes.Equal(body.GetRtmrs()[ImaRtmrIndex], rtmrs[ImaRtmrIndex]) || bytes.Equal(rtmrs[ImaRtmrIndex], make([]byte, RtmrSize)) {
+		t.Errorf("RTMR[%d] = %x, want the replayed IMA log %x", ImaRtmrIndex, body.GetRtmrs()[ImaRtmrIndex], rtmrs[ImaRtmrIndex])
+	}
+
+	// The quote is signed by the test key of the stand-in
+	roots, err := verifier.ParseTrustedRoots(signer.RootCertificatePEM())
+	if err != nil {
+		t.Fatalf("ParseTrustedRoots failed: %v", err)
+	}
+	if _, err := verifier.NewVerifier(verifier.Options{TrustedRoots: roots}).VerifyQuote(quote); err != nil {
+		t.Errorf("VerifyQuote failed: %v", err)
+	}
+}
+
+func TestQgsErrors(t *testing.T) {
+	address, _ := serveQgs(t)
+	_, socketPath, err := ParseQgsAddress(address)
+	if err != nil {
+		t.Fatalf("ParseQgsAddress failed: %v", err)
+	}
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	request := func(msg []byte) error {
+		t.Helper()
+		if err := writeQgsMessage(conn, msg); err != nil {
+			t.Fatalf("writeQgsMessage failed: %v", err)
+		}
+		resp, err := readQgsMessage(conn)
+		if err != nil {
+			t.Fatalf("readQgsMessage failed: %v", err)
+		}
+		_, err = decodeQgsGetQuoteResp(resp)
+		return err
+	}
+
+	msg := encodeQgsGetQuoteReq(make([]byte, TdReportSize))
+	binary.LittleEndian.PutUint16(msg, 2)
+	var qgsErr *QgsError
+	if err := request(msg); !errors.As(err, &qgsErr) || qgsErr.Code != QgsErrorInvalidVersion {
+		t.Errorf("request of version 2 = %v, want invalid version", err)
+	}
+	if err := request(encodeQgsGetQuoteReq(make([]byte, 100))); !errors.As(err, &qgsErr) || qgsErr.Code != QgsErrorInvalidParameter {
+		t.Errorf("request with a short report = %v, want invalid parameter", err)
+	}
+	// The connection serves further requests
+	if err := request(encodeQgsGetQuoteReq(make([]byte, TdReportSize))); err != nil {
+		t.Errorf("request failed: %v", err)
+	}
+}
+
+func TestParseQgsAddress(t *testing.T) {
+	for _, address := range []string{"unix:/run/qgs.sock", "vsock:2:4050"} {
+		if _, _, err := ParseQgsAddress(address); err != nil {
+			t.Errorf("ParseQgsAddress(%q) failed: %v", address, err)
+		}
+	}
+	for _, address := range []string{"", "unix:", "tcp:localhost:4050", "vsock:2", "vsock:host:4050"} {
+		if _, _, err := ParseQgsAddress(address); err == nil {
+			t.Errorf("ParseQgsAddress(%q) succeeded", address)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+//go:build linux
+
+package tdx
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// vsockAddr is the address of a vsock endpoint.
+type vsockAddr struct {
+	cid  uint32
+	port uint32
+}
+
+func (a *vsockAddr) Network() string { return "vsock" }
+func (a *vsockAddr) String() string  { return fmt.Sprintf("%d:%d", a.cid, a.port) }
+
+// vsockConn is a connected vsock socket. The standard library does not support vsock, so the socket is driven
+// through an os.File, which supports deadlines for non-blocking descriptors.
+type vsockConn struct {
+	*os.File
+	remote *vsockAddr
+}
+
+func (c *vsockConn) LocalAddr() net.Addr  { return &vsockAddr{cid: unix.VMADDR_CID_ANY} }
+func (c *vsockConn) RemoteAddr() net.Addr { return c.remote }
+
+// dialVsock connects to "<cid>:<port>" over vsock.
+func dialVsock(addr string, timeout time.Duration) (net.Conn, error) {
+	cidValue, portValue, _ := strings.Cut(addr, ":")
+	cid, err := strconv.ParseUint(cidValue, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vsock CID %q", cidValue)
+	}
+	port, err := strconv.ParseUint(portValue, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vsock port %q", portValue)
+	}
+
+	fd, err := unix.Socket(unix.AF_VSOCK, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vsock socket: %w", err)
+	}
+	tv := unix.NsecToTimeval(timeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.AF_VSOCK, unix.SO_VM_SOCKETS_CONNECT_TIMEOUT, &tv); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to set vsock connect timeout: %w", err)
+	}
+	if err := unix.Connect(fd, &unix.SockaddrVM{CID: uint32(cid), Port: uint32(port)}); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	if err := unix.SetNonblock(fd, true); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to set vsock socket non-blocking: %w", err)
+	}
+
+	remote := &vsockAddr{cid: uint32(cid), port: uint32(port)}
+	return &vsockConn{File: os.NewFile(uintptr(fd), "vsock:"+remote.String()), remote: remote}, nil
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package tdx
+
+import (
+	"errors"
+	"net"
+	"time"
+)
+
+// dialVsock is not supported outside Linux.
+func dialVsock(addr string, timeout time.Duration) (net.Conn, error) {
+	return nil, errors.New("vsock is only supported on Linux")
+}
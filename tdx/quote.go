@@ -1,9 +1,14 @@
 package tdx
 
 import (
+	"context"
+	"encoding/binary"
 	"fmt"
+	"log"
 	"os"
+	"time"
 
+	"github.com/google/go-tdx-guest/abi"
 	"github.com/google/go-tdx-guest/client"
 
 	"github.com/radiusxyz/lightbulb-tdx/utils"
@@ -16,114 +21,278 @@ import (
 type TDXClientInterface interface {
 	GetQuoteProvider() (interface{}, error)                                  // Returns a quote provider
 	GetQuote(provider interface{}, reportData [64]byte) (interface{}, error) // Returns a quote object
-	GetRtmr() ([]byte, error)                                                // Returns the RTMR value
+	GetRtmrs() ([4][]byte, error)                                            // Returns the RTMR values
+	GetEventLog(start uint64, limit int) (*EventLogPage, error)              // Returns the events extended into the RTMRs
+	ExtendRtmr(index int, eventType string, eventData []byte) error          // Extends the RTMR with the SHA-384 digest of the event data
+	EventRecorded() <-chan struct{}                                          // Returns a channel closed when the next event is recorded
+	WatchRtmrs(ctx context.Context, interval time.Duration) error           // Updates the RTMRs in the background until the context is done
 }
 
 // TDXClient is a wrapper around the tdxClient package.
 type TDXClient struct{
 	rtmrProvider *RtmrProvider
+	rtmrExtender RtmrExtender
 }
 
 // NewTDXClient creates a new TDXClient.
 func NewTDXClient() *TDXClient {
 	return &TDXClient{
 		rtmrProvider: DefaultRtmrProvider(),
+		rtmrExtender: DefaultRtmrExtender(),
 	}
 }
 
+// DefaultTDXClient creates the TDX client matching the ENV environment variable.
+func DefaultTDXClient() (TDXClientInterface, error) {
+	env := os.Getenv("ENV")
+
+	if env == "TDX" {
+		return DefaultQuoteBackendClient(), nil
+	} else if env == "MOCK_TDX" {
+		return DefaultMockTDXClient()
+	}
+	log.Printf("[Warning] Unknown environment '%s'. Defaulting to MockTDXClient.", env)
+	return DefaultMockTDXClient()
+}
+
 // GetQuoteProvider wraps tdxClient.GetQuoteProvider().
 func (w *TDXClient) GetQuoteProvider() (interface{}, error) {
 	return client.GetQuoteProvider()
 }
 
-// GetQuote wraps tdxClient.GetQuote().
+// GetQuote wraps tdxClient.GetRawQuote() and parses the quote, which is a QuoteV4 object for version 4 quotes and a
+// Quote object for version 5 quotes.
 func (w *TDXClient) GetQuote(provider interface{}, reportData [64]byte) (interface{}, error) {
-	return client.GetQuote(provider, reportData)
+	rawQuote, err := client.GetRawQuote(provider, reportData)
+	if err != nil {
+		return nil, err
+	}
+	return parseRawQuote(rawQuote)
+}
+
+// parseRawQuote parses version 4 quotes with go-tdx-guest, which does not support the version 5 layout of TDX 1.5.
+func parseRawQuote(rawQuote []byte) (interface{}, error) {
+	if len(rawQuote) >= 2 && binary.LittleEndian.Uint16(rawQuote) == utils.QuoteVersion5 {
+		return utils.ConvertRawQuoteToQuote(rawQuote)
+	}
+	return abi.QuoteToProto(rawQuote)
 }
 
 type MockTDXClient struct {
 	rtmrProvider *RtmrProvider
+	config       MockQuoteConfig
 }
 
 func NewMockTDXClient() *MockTDXClient {
+	return NewMockTDXClientWithConfig(MockQuoteConfig{})
+}
+
+// NewMockTDXClientWithConfig creates a new MockTDXClient reporting the given measurements.
+func NewMockTDXClientWithConfig(config MockQuoteConfig) *MockTDXClient {
 	return &MockTDXClient{
 		rtmrProvider: DefaultRtmrProvider(),
+		config:       config,
 	}
 }
 
-type MockQuoteProvider struct {}
+// MockQuoteProvider signs mock quotes with a locally generated PCK chain.
+type MockQuoteProvider struct {
+	signer *MockQuoteSigner
+}
 
 func (m *MockTDXClient) GetQuoteProvider() (interface{}, error) {
-	return &MockQuoteProvider{}, nil
+	signer, err := DefaultMockQuoteSigner()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mock quote signer: %w", err)
+	}
+	return &MockQuoteProvider{signer: signer}, nil
 }
 
-func (m *MockTDXClient) GetQuote(_provider interface{}, reportData [64]byte) (interface{}, error) {
-	mockQuote := &tdxpb.QuoteV4{
-		Header: &tdxpb.Header{
-			Version: 1,
-		},
-		TdQuoteBody: &tdxpb.TDQuoteBody{
-			ReportData: reportData[:],
-		},
+func (m *MockTDXClient) GetQuote(provider interface{}, reportData [64]byte) (interface{}, error) {
+	mockProvider, ok := provider.(*MockQuoteProvider)
+	if !ok {
+		return nil, fmt.Errorf("unexpected quote provider type: %T", provider)
 	}
 
-	return mockQuote, nil
+	// Report the RTMRs replayed from the same sources as GetRtmrs
+	rtmrs, err := m.GetRtmrs()
+	if err != nil {
+		return nil, err
+	}
+
+	body := m.config.NewTDQuoteBody(rtmrs, reportData)
+	if m.config.QuoteVersion != utils.QuoteVersion5 {
+		return mockProvider.signer.SignQuote(body)
+	}
+
+	// Return version 5 quotes as TDXClient does, parsed from their raw bytes
+	quote, err := mockProvider.signer.SignQuoteV5(body)
+	if err != nil {
+		return nil, err
+	}
+	rawQuote, err := utils.ConvertQuoteToRawQuote(quote)
+	if err != nil {
+		return nil, err
+	}
+	return parseRawQuote(rawQuote)
 }
 
 // GetQuote retrieves a TDX quote using the given TDX client implementation.
 func GetQuote(tdxClient TDXClientInterface) (*attestpb.Quote, error) {
+	quote, _, err := GetQuoteWithReportData(tdxClient, nil)
+	return quote, err
+}
+
+// GetQuoteWithReportData retrieves a TDX quote whose REPORTDATA binds the given user data to the current RTMR digest.
+func GetQuoteWithReportData(tdxClient TDXClientInterface, userData []byte) (*attestpb.Quote, *attestpb.ReportDataBinding, error) {
+	return GetQuoteWithChallenge(tdxClient, nil, userData)
+}
+
+// GetQuoteWithChallenge retrieves a TDX quote whose REPORTDATA binds the challenge and the user data to the current
+// RTMR digest. Without a challenge, it behaves as GetQuoteWithReportData. The challenge must have been redeemed by the
+// caller.
+func GetQuoteWithChallenge(tdxClient TDXClientInterface, challenge []byte, userData []byte) (*attestpb.Quote, *attestpb.ReportDataBinding, error) {
+	if err := validateUserReportData(userData); err != nil {
+		return nil, nil, err
+	}
+
 	// Get the quote provider
 	quoteProvider, err := tdxClient.GetQuoteProvider()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get quote provider: %w", err)
+		return nil, nil, fmt.Errorf("failed to get quote provider: %w", err)
 	}
 
-	reportDataInput := make([]byte, 64)
-
-	if os.Getenv("TDX_VERSION") == "1.0" {
-		reportDataInput, err = tdxClient.GetRtmr()
+	var rtmrDigest []byte
+	if challenge != nil || needsRtmr(userData) {
+		rtmrs, err := tdxClient.GetRtmrs()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get RTMR: %w", err)
+			return nil, nil, fmt.Errorf("failed to get RTMR: %w", err)
 		}
-	}	
+		rtmrDigest = rtmrs[ImaRtmrIndex]
+	}
 
 	// Prepare reportData array
-	var reportData [64]byte
-	copy(reportData[:], reportDataInput)
+	var reportData [ReportDataSize]byte
+	var binding *attestpb.ReportDataBinding
+	if challenge != nil {
+		reportData, binding, err = BuildChallengeReportData(challenge, userData, rtmrDigest)
+	} else {
+		reportData, binding, err = BuildReportData(userData, rtmrDigest)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validatedQuote, err := getValidatedQuote(tdxClient, quoteProvider, reportData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return validatedQuote, binding, nil
+}
+
+// GetQuoteForReportData retrieves a TDX quote whose REPORTDATA is exactly the given bytes, without binding the RTMR
+// digest. It is meant for protocols that define their own REPORTDATA, such as RA-TLS.
+func GetQuoteForReportData(tdxClient TDXClientInterface, reportData [ReportDataSize]byte) (*attestpb.Quote, error) {
+	quoteProvider, err := tdxClient.GetQuoteProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quote provider: %w", err)
+	}
+	return getValidatedQuote(tdxClient, quoteProvider, reportData)
+}
 
-	// Get the quote
+// getValidatedQuote gets a quote and converts it to a Quote object, validating the layout so that malformed quotes
+// never reach clients.
+func getValidatedQuote(tdxClient TDXClientInterface, quoteProvider interface{}, reportData [ReportDataSize]byte) (*attestpb.Quote, error) {
 	quote, err := tdxClient.GetQuote(quoteProvider, reportData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get quote: %w", err)
 	}
 
-	// Convert and return the Quote
-	convertedQuote, ok := quote.(*tdxpb.QuoteV4)
-	if !ok {
-		return nil, fmt.Errorf("unexpected quote type: %T", quote)
+	switch convertedQuote := quote.(type) {
+	case *tdxpb.QuoteV4:
+		return utils.ConvertQuoteV4ToQuoteStrict(convertedQuote)
+	case *attestpb.Quote:
+		if err := utils.ValidateQuote(convertedQuote); err != nil {
+			return nil, err
+		}
+		return convertedQuote, nil
 	}
-
-	return utils.ConvertQuoteV4ToQuote(convertedQuote), nil
+	return nil, fmt.Errorf("unexpected quote type: %T", quote)
 }
 
-func (c *TDXClient) GetRtmr() ([]byte, error) {
-	// Update RTMR[2] with IMA Event Logs
-	err := c.rtmrProvider.UpdateImaRtmr()
+func (c *TDXClient) GetRtmrs() ([4][]byte, error) {
+	// Update the RTMRs with the measurement sources
+	err := c.rtmrProvider.UpdateRtmrs()
 	if err != nil {
-		return nil, fmt.Errorf("failed to update IMA RTMR: %w", err)
+		return [4][]byte{}, err
 	}
 
-	// Get the RTMR[2] value
-	return c.rtmrProvider.GetRtmrValues()[2], nil;
+	// Get all RTMR values, reporting RTMRs that were never extended as zeros
+	return normalizeRtmrs(c.rtmrProvider.GetRtmrValues()), nil
 }
 
-func (c *MockTDXClient) GetRtmr() ([]byte, error) {
-	// Update RTMR[2] with IMA Event Logs
-	err := c.rtmrProvider.UpdateImaRtmr()
+func (c *MockTDXClient) GetRtmrs() ([4][]byte, error) {
+	// Update the RTMRs with the measurement sources
+	err := c.rtmrProvider.UpdateRtmrs()
 	if err != nil {
-		return nil, fmt.Errorf("failed to update IMA RTMR: %w", err)
+		return [4][]byte{}, err
 	}
 
-	// Get the RTMR[2] value
-	return c.rtmrProvider.GetRtmrValues()[2], nil;
-}
\ No newline at end of file
+	// Get all RTMR values, reporting RTMRs that were never extended as zeros
+	return normalizeRtmrs(c.rtmrProvider.GetRtmrValues()), nil
+}
+
+func (c *TDXClient) GetEventLog(start uint64, limit int) (*EventLogPage, error) {
+	// Record the events not processed yet
+	if err := c.rtmrProvider.UpdateRtmrs(); err != nil {
+		return nil, err
+	}
+	return c.rtmrProvider.GetEventLog(start, limit)
+}
+
+func (c *MockTDXClient) GetEventLog(start uint64, limit int) (*EventLogPage, error) {
+	// Record the events not processed yet
+	if err := c.rtmrProvider.UpdateRtmrs(); err != nil {
+		return nil, err
+	}
+	return c.rtmrProvider.GetEventLog(start, limit)
+}
+
+func (c *TDXClient) EventRecorded() <-chan struct{} {
+	return c.rtmrProvider.EventRecorded()
+}
+
+func (c *MockTDXClient) EventRecorded() <-chan struct{} {
+	return c.rtmrProvider.EventRecorded()
+}
+
+func (c *TDXClient) WatchRtmrs(ctx context.Context, interval time.Duration) error {
+	return c.rtmrProvider.Watch(ctx, interval)
+}
+
+func (c *MockTDXClient) WatchRtmrs(ctx context.Context, interval time.Duration) error {
+	return c.rtmrProvider.Watch(ctx, interval)
+}
+
+func (c *TDXClient) ExtendRtmr(index int, eventType string, eventData []byte) error {
+	if err := validateExtendableRtmr(index); err != nil {
+		return err
+	}
+
+	// Extend the hardware RTMR first, so that the recorded event log never runs ahead of it
+	event := newAppEvent(index, eventType, eventData)
+	if err := c.rtmrExtender.ExtendDigest(index, event.Digest); err != nil {
+		return fmt.Errorf("failed to extend RTMR[%d]: %w", index, err)
+	}
+	return c.rtmrProvider.ExtendEvent(event)
+}
+
+func (c *MockTDXClient) ExtendRtmr(index int, eventType string, eventData []byte) error {
+	if err := validateExtendableRtmr(index); err != nil {
+		return err
+	}
+
+	// The mock RTMRs only live in the provider
+	return c.rtmrProvider.ExtendEvent(newAppEvent(index, eventType, eventData))
+}
@@ -0,0 +1,227 @@
+package tdx
+
+import (
+	"crypto/sha256"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+const (
+	DefaultQuoteCacheTTL        = 10 * time.Second // Lifetime of a cached quote
+	DefaultQuoteCacheMaxEntries = 1024             // Maximum number of cached quotes
+	DefaultMaxConcurrentQuotes  = 1                // Quote generations in flight, the quoting enclave serializes them anyway
+)
+
+// QuoteCacheConfig configures a CachingTDXClient.
+type QuoteCacheConfig struct {
+	TTL           time.Duration // Lifetime of a cached quote, caching is disabled if not positive
+	MaxEntries    int           // Maximum number of cached quotes, the oldest is evicted first
+	MaxConcurrent int           // Maximum number of quote generations in flight
+}
+
+// QuoteCacheStats counts the quote requests served by a CachingTDXClient.
+type QuoteCacheStats struct {
+	Hits      uint64 // Requests served from the cache
+	Misses    uint64 // Requests that generated a quote
+	Coalesced uint64 // Requests that waited for an identical generation in flight
+	Entries   int    // Quotes currently cached
+}
+
+// DefaultQuoteCacheConfig reads the cache configuration from QUOTE_CACHE_TTL, QUOTE_CACHE_MAX_ENTRIES and
+// QUOTE_MAX_CONCURRENT. Invalid values fall back to the defaults.
+func DefaultQuoteCacheConfig() QuoteCacheConfig {
+	config := QuoteCacheConfig{
+		TTL:           DefaultQuoteCacheTTL,
+		MaxEntries:    DefaultQuoteCacheMaxEntries,
+		MaxConcurrent: DefaultMaxConcurrentQuotes,
+	}
+
+	if value := os.Getenv("QUOTE_CACHE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			log.Printf("[Warning] Invalid QUOTE_CACHE_TTL '%s'. Defaulting to %v.", value, DefaultQuoteCacheTTL)
+		} else {
+			config.TTL = ttl
+		}
+	}
+	if value := os.Getenv("QUOTE_CACHE_MAX_ENTRIES"); value != "" {
+		maxEntries, err := strconv.Atoi(value)
+		if err != nil || maxEntries <= 0 {
+			log.Printf("[Warning] Invalid QUOTE_CACHE_MAX_ENTRIES '%s'. Defaulting to %d.", value, DefaultQuoteCacheMaxEntries)
+		} else {
+			config.MaxEntries = maxEntries
+		}
+	}
+	if value := os.Getenv("QUOTE_MAX_CONCURRENT"); value != "" {
+		maxConcurrent, err := strconv.Atoi(value)
+		if err != nil || maxConcurrent <= 0 {
+			log.Printf("[Warning] Invalid QUOTE_MAX_CONCURRENT '%s'. Defaulting to %d.", value, DefaultMaxConcurrentQuotes)
+		} else {
+			config.MaxConcurrent = maxConcurrent
+		}
+	}
+	return config
+}
+
+// CachingTDXClient wraps a TDXClientInterface with a quote cache. Quotes are keyed by the report data and the RTMR
+// values at the time of the request, so any measurement invalidates them. Identical requests share one generation in
+// flight, and the number of generations toward the wrapped client is bounded.
+type CachingTDXClient struct {
+	TDXClientInterface
+	config QuoteCacheConfig
+
+	mu       sync.Mutex               // Protects entries and inflight
+	entries  map[[32]byte]*quoteEntry // Cached quotes by key
+	inflight map[[32]byte]*quoteCall  // Generations in flight by key
+	sem      chan struct{}            // Bounds the generations in flight
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	coalesced atomic.Uint64
+}
+
+type quoteEntry struct {
+	quote   interface{}
+	created time.Time
+}
+
+type quoteCall struct {
+	done  chan struct{}
+	quote interface{}
+	err   error
+}
+
+// NewCachingTDXClient wraps a TDX client with a quote cache.
+func NewCachingTDXClient(client TDXClientInterface, config QuoteCacheConfig) *CachingTDXClient {
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = DefaultQuoteCacheMaxEntries
+	}
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = DefaultMaxConcurrentQuotes
+	}
+	return &CachingTDXClient{
+		TDXClientInterface: client,
+		config:             config,
+		entries:            make(map[[32]byte]*quoteEntry),
+		inflight:           make(map[[32]byte]*quoteCall),
+		sem:                make(chan struct{}, config.MaxConcurrent),
+	}
+}
+
+// GetQuote returns a cached quote for the report data and the current RTMR values, or generates one. Every caller
+// gets its own copy of the quote, so that modifying it does not affect the cache or the other callers.
+func (c *CachingTDXClient) GetQuote(provider interface{}, reportData [64]byte) (interface{}, error) {
+	rtmrs, err := c.GetRtmrs()
+	if err != nil {
+		return nil, err
+	}
+	key := quoteCacheKey(reportData, rtmrs)
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok {
+		if time.Since(entry.created) < c.config.TTL {
+			c.mu.Unlock()
+			c.hits.Add(1)
+			return cloneQuote(entry.quote), nil
+		}
+		delete(c.entries, key)
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		c.coalesced.Add(1)
+		<-call.done
+		return cloneQuote(call.quote), call.err
+	}
+	call := &quoteCall{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+	c.misses.Add(1)
+
+	c.sem <- struct{}{}
+	call.quote, call.err = c.TDXClientInterface.GetQuote(provider, reportData)
+	<-c.sem
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if call.err == nil && c.config.TTL > 0 {
+		c.store(key, call.quote)
+	}
+	c.mu.Unlock()
+	close(call.done)
+
+	return cloneQuote(call.quote), call.err
+}
+
+// cloneQuote returns a deep copy of a quote message, or the quote itself if it is not a message.
+func cloneQuote(quote interface{}) interface{} {
+	if message, ok := quote.(proto.Message); ok {
+		return proto.Clone(message)
+	}
+	return quote
+}
+
+// Stats returns the cache counters.
+func (c *CachingTDXClient) Stats() QuoteCacheStats {
+	c.mu.Lock()
+	entries := len(c.entries)
+	c.mu.Unlock()
+
+	return QuoteCacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Coalesced: c.coalesced.Load(),
+		Entries:   entries,
+	}
+}
+
+// ConvertQuoteCacheStatsToProtobuf converts QuoteCacheStats to their protobuf representation.
+func ConvertQuoteCacheStatsToProtobuf(stats QuoteCacheStats) *attestpb.QuoteCacheStats {
+	return &attestpb.QuoteCacheStats{
+		Hits:      stats.Hits,
+		Misses:    stats.Misses,
+		Coalesced: stats.Coalesced,
+		Entries:   uint32(stats.Entries),
+	}
+}
+
+// store caches a quote, evicting expired quotes and then the oldest ones to stay within MaxEntries. Must be called
+// with mu held.
+func (c *CachingTDXClient) store(key [32]byte, quote interface{}) {
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.Sub(entry.created) >= c.config.TTL {
+			delete(c.entries, k)
+		}
+	}
+	for len(c.entries) >= c.config.MaxEntries {
+		var oldest [32]byte
+		var oldestTime time.Time
+		for k, entry := range c.entries {
+			if oldestTime.IsZero() || entry.created.Before(oldestTime) {
+				oldest, oldestTime = k, entry.created
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[key] = &quoteEntry{quote: quote, created: now}
+}
+
+// quoteCacheKey hashes the report data and the RTMR values.
+func quoteCacheKey(reportData [64]byte, rtmrs [4][]byte) [32]byte {
+	hasher := sha256.New()
+	hasher.Write(reportData[:])
+	for _, rtmr := range normalizeRtmrs(rtmrs) {
+		hasher.Write(rtmr)
+	}
+	var key [32]byte
+	copy(key[:], hasher.Sum(nil))
+	return key
+}
@@ -0,0 +1,165 @@
+package tdx
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+// countingTDXClient counts the quote generations of a MockTDXClient and blocks them until release is closed.
+type countingTDXClient struct {
+	*MockTDXClient
+	release  chan struct{}
+	calls    atomic.Int32
+	inflight atomic.Int32
+	maxSeen  atomic.Int32
+}
+
+func (c *countingTDXClient) GetQuote(provider interface{}, reportData [64]byte) (interface{}, error) {
+	c.calls.Add(1)
+	n := c.inflight.Add(1)
+	defer c.inflight.Add(-1)
+	for {
+		max := c.maxSeen.Load()
+		if n <= max || c.maxSeen.CompareAndSwap(max, n) {
+			break
+		}
+	}
+	<-c.release
+	return c.MockTDXClient.GetQuote(provider, reportData)
+}
+
+func newCountingTDXClient(t *testing.T) *countingTDXClient {
+	t.Setenv("ENV", "")
+	tdxtest.ClearEnv(t)
+	return &countingTDXClient{MockTDXClient: NewMockTDXClient(), release: make(chan struct{})}
+}
+
+func TestQuoteCache(t *testing.T) {
+	inner := newCountingTDXClient(t)
+	close(inner.release)
+	client := NewCachingTDXClient(inner, QuoteCacheConfig{TTL: time.Hour})
+
+	first, _, err := GetQuoteWithReportData(client, []byte("nonce"))
+	if err != nil {
+		t.Fatalf("GetQuoteWithReportData failed: %v", err)
+	}
+	second, _, err := GetQuoteWithReportData(client, []byte("nonce"))
+	if err != nil {
+		t.Fatalf("GetQuoteWithReportData failed: %v", err)
+	}
+	if !bytes.Equal(first.SignedData.GetSignature(), second.SignedData.GetSignature()) {
+		t.Errorf("second quote was not served from the cache")
+	}
+
+	// Cached quotes are copied for every caller
+	if first == second {
+		t.Errorf("cache hit returned the same quote")
+	}
+	signature := append([]byte(nil), second.SignedData.GetSignature()...)
+	first.SignedData.Signature[0] ^= 0xff
+	third, _, err := GetQuoteWithReportData(client, []byte("nonce"))
+	if err != nil {
+		t.Fatalf("GetQuoteWithReportData failed: %v", err)
+	}
+	if !bytes.Equal(third.SignedData.GetSignature(), signature) {
+		t.Errorf("modifying a returned quote changed the cached quote")
+	}
+
+	// Other report data and new measurements miss the cache
+	if _, _, err := GetQuoteWithReportData(client, []byte("other")); err != nil {
+		t.Fatalf("GetQuoteWithReportData failed: %v", err)
+	}
+	if err := client.ExtendRtmr(3, "test", []byte("event")); err != nil {
+		t.Fatalf("ExtendRtmr failed: %v", err)
+	}
+	if _, _, err := GetQuoteWithReportData(client, []byte("nonce")); err != nil {
+		t.Fatalf("GetQuoteWithReportData failed: %v", err)
+	}
+
+	stats := client.Stats()
+	if stats.Hits != 2 || stats.Misses != 3 || stats.Entries != 3 || inner.calls.Load() != 3 {
+		t.Errorf("stats = %+v with %d generations, want 2 hits and 3 misses", stats, inner.calls.Load())
+	}
+
+	// GetStatus reports the same counters
+	resp, err := NewServer(client, nil).GetStatus(context.Background(), &attestpb.GetStatusRequest{})
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if cache := resp.GetQuoteCache(); cache.GetHits() != 2 || cache.GetMisses() != 3 || cache.GetEntries() != 3 {
+		t.Errorf("GetStatus quote cache = %v, want 2 hits, 3 misses and 3 entries", cache)
+	}
+}
+
+func TestQuoteCacheExpiry(t *testing.T) {
+	inner := newCountingTDXClient(t)
+	close(inner.release)
+	client := NewCachingTDXClient(inner, QuoteCacheConfig{TTL: time.Millisecond, MaxEntries: 1})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := GetQuoteWithReportData(client, []byte("nonce")); err != nil {
+			t.Fatalf("GetQuoteWithReportData failed: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if _, _, err := GetQuoteWithReportData(client, []byte("other")); err != nil {
+		t.Fatalf("GetQuoteWithReportData failed: %v", err)
+	}
+
+	stats := client.Stats()
+	if stats.Hits != 0 || stats.Misses != 3 || stats.Entries != 1 {
+		t.Errorf("stats = %+v, want 3 misses and 1 entry", stats)
+	}
+}
+
+func TestQuoteCacheCoalescing(t *testing.T) {
+	inner := newCountingTDXClient(t)
+	client := NewCachingTDXClient(inner, QuoteCacheConfig{TTL: time.Hour, MaxConcurrent: 2})
+
+	// 4 identical requests and 4 distinct ones, all blocked in the generation
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		userData := []byte{'a'}
+		if i >= 4 {
+			userData = []byte{byte(i)}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := GetQuoteWithReportData(client, userData)
+			errs <- err
+		}()
+	}
+
+	// Wait until the generations saturate the concurrency bound, then let them finish
+	deadline := time.Now().Add(5 * time.Second)
+	for inner.inflight.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(inner.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("GetQuoteWithReportData failed: %v", err)
+		}
+	}
+
+	stats := client.Stats()
+	if inner.calls.Load() != 5 || stats.Misses != 5 || stats.Hits+stats.Coalesced != 3 {
+		t.Errorf("stats = %+v with %d generations, want 5 generations", stats, inner.calls.Load())
+	}
+	if inner.maxSeen.Load() > 2 {
+		t.Errorf("%d generations in flight, want at most 2", inner.maxSeen.Load())
+	}
+}
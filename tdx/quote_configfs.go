@@ -0,0 +1,143 @@
+package tdx
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/google/go-configfs-tsm/configfs/configfsi"
+	"github.com/google/go-configfs-tsm/configfs/linuxtsm"
+	"github.com/google/go-configfs-tsm/report"
+)
+
+// Quote backends selected by TDX_QUOTE_BACKEND.
+const (
+	QuoteBackendAuto     = "auto"     // configfs-tsm if the kernel supports it, the TDX guest device otherwise
+	QuoteBackendConfigfs = "configfs" // configfs-tsm report interface at /sys/kernel/config/tsm/report
+	QuoteBackendDevice   = "device"   // go-tdx-guest quote provider, falling back to the /dev/tdx_guest quote ioctls
+	QuoteBackendQgs      = "qgs"      // Quote Generation Service of the host at QGS_ADDRESS
+)
+
+// tdxGuestProvider is the configfs-tsm report provider of TDX guests.
+const tdxGuestProvider = "tdx_guest"
+
+// ErrConfigfsUnsupported is returned when configfs-tsm cannot generate TDX quotes.
+var ErrConfigfsUnsupported = errors.New("configfs-tsm TDX quotes are not supported")
+
+// ConfigfsQuoteProvider generates quotes through the configfs-tsm report interface. It implements the QuoteProvider
+// interface of go-tdx-guest.
+type ConfigfsQuoteProvider struct {
+	client configfsi.Client
+}
+
+// IsSupported checks that a report entry can be created and that its provider is the TDX guest.
+func (p *ConfigfsQuoteProvider) IsSupported() error {
+	entry, err := report.Create(p.client, &report.Request{})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrConfigfsUnsupported, err)
+	}
+	defer entry.Destroy()
+
+	provider, err := entry.ReadOption("provider")
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrConfigfsUnsupported, err)
+	}
+	if name := strings.TrimSpace(string(provider)); name != tdxGuestProvider {
+		return fmt.Errorf("%w: report provider is %q", ErrConfigfsUnsupported, name)
+	}
+	return nil
+}
+
+// GetRawQuote writes the report data to a new report entry and reads the quote back.
+func (p *ConfigfsQuoteProvider) GetRawQuote(reportData [64]byte) ([]byte, error) {
+	response, err := report.Get(p.client, &report.Request{InBlob: reportData[:]})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configfs-tsm report: %w", err)
+	}
+	if name := strings.TrimSpace(response.Provider); name != tdxGuestProvider {
+		return nil, fmt.Errorf("%w: report provider is %q", ErrConfigfsUnsupported, name)
+	}
+	return response.OutBlob, nil
+}
+
+// ConfigfsTDXClient is a TDXClient generating quotes through configfs-tsm instead of the TDX guest device.
+type ConfigfsTDXClient struct {
+	*TDXClient
+	quoteProvider *ConfigfsQuoteProvider
+}
+
+// NewConfigfsTDXClient creates a new ConfigfsTDXClient on top of a configfs client.
+func NewConfigfsTDXClient(client configfsi.Client) *ConfigfsTDXClient {
+	return &ConfigfsTDXClient{
+		TDXClient:     NewTDXClient(),
+		quoteProvider: &ConfigfsQuoteProvider{client: client},
+	}
+}
+
+// GetQuoteProvider returns the configfs-tsm quote provider.
+func (c *ConfigfsTDXClient) GetQuoteProvider() (interface{}, error) {
+	return c.quoteProvider, nil
+}
+
+// GetQuote gets a raw quote from configfs-tsm and parses it.
+func (c *ConfigfsTDXClient) GetQuote(provider interface{}, reportData [64]byte) (interface{}, error) {
+	configfsProvider, ok := provider.(*ConfigfsQuoteProvider)
+	if !ok {
+		return nil, fmt.Errorf("unexpected quote provider type: %T", provider)
+	}
+
+	rawQuote, err := configfsProvider.GetRawQuote(reportData)
+	if err != nil {
+		return nil, err
+	}
+	return parseRawQuote(rawQuote)
+}
+
+// NewTDXClientWithBackend creates the TDX client of a quote backend. The auto backend uses configfs-tsm when the
+// kernel supports it and falls back to the TDX guest device otherwise.
+func NewTDXClientWithBackend(backend string) (TDXClientInterface, error) {
+	return newTDXClientWithBackend(backend, linuxtsm.MakeClient)
+}
+
+func newTDXClientWithBackend(backend string, makeClient func() (configfsi.Client, error)) (TDXClientInterface, error) {
+	switch backend {
+	case QuoteBackendDevice:
+		return NewTDXClient(), nil
+	case QuoteBackendQgs:
+		return DefaultQgsTDXClient(), nil
+	case QuoteBackendConfigfs, QuoteBackendAuto, "":
+	default:
+		return nil, fmt.Errorf("unknown quote backend %q", backend)
+	}
+
+	// Probe configfs-tsm before using it
+	client, err := makeClient()
+	if err == nil {
+		err = (&ConfigfsQuoteProvider{client: client}).IsSupported()
+	} else {
+		err = fmt.Errorf("%w: %v", ErrConfigfsUnsupported, err)
+	}
+	if err == nil {
+		return NewConfigfsTDXClient(client), nil
+	}
+	if backend == QuoteBackendConfigfs {
+		return nil, err
+	}
+
+	log.Printf("[Info] %v. Falling back to the TDX guest device.", err)
+	return NewTDXClient(), nil
+}
+
+// DefaultQuoteBackendClient creates the TDX client of the backend set by TDX_QUOTE_BACKEND, auto if unset. An
+// unusable backend falls back to the TDX guest device.
+func DefaultQuoteBackendClient() TDXClientInterface {
+	backend := os.Getenv("TDX_QUOTE_BACKEND")
+	client, err := NewTDXClientWithBackend(backend)
+	if err != nil {
+		log.Printf("[Warning] %v. Defaulting to the TDX guest device.", err)
+		return NewTDXClient()
+	}
+	return client
+}
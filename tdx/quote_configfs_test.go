@@ -0,0 +1,181 @@
+package tdx
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-configfs-tsm/configfs/configfsi"
+	"github.com/google/go-tdx-guest/abi"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+)
+
+// fakeTsmTree is a configfs client on a directory tree, emulating the kernel side of the report subsystem: entries
+// are created with their attributes, and writing inblob bumps the generation and produces a mock quote in outblob.
+type fakeTsmTree struct {
+	t        *testing.T
+	root     string
+	provider string
+	signer   *MockQuoteSigner
+}
+
+func newFakeTsmTree(t *testing.T, provider string) *fakeTsmTree {
+	signer, err := DefaultMockQuoteSigner()
+	if err != nil {
+		t.Fatalf("DefaultMockQuoteSigner failed: %v", err)
+	}
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "report"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return &fakeTsmTree{t: t, root: root, provider: provider, signer: signer}
+}
+
+func (f *fakeTsmTree) path(name string) string {
+	return filepath.Join(f.root, strings.TrimPrefix(name, configfsi.TsmPrefix))
+}
+
+func (f *fakeTsmTree) MkdirTemp(dir, pattern string) (string, error) {
+	entry, err := os.MkdirTemp(f.path(dir), pattern)
+	if err != nil {
+		return "", err
+	}
+	for attr, content := range map[string]string{
+		"provider":        f.provider + "\n",
+		"generation":      "0\n",
+		"privlevel_floor": "0\n",
+	} {
+		if err := os.WriteFile(filepath.Join(entry, attr), []byte(content), 0644); err != nil {
+			return "", err
+		}
+	}
+	return path.Join(dir, filepath.Base(entry)), nil
+}
+
+func (f *fakeTsmTree) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(f.path(name))
+}
+
+func (f *fakeTsmTree) ReadDir(dirname string) ([]os.DirEntry, error) {
+	return os.ReadDir(f.path(dirname))
+}
+
+func (f *fakeTsmTree) WriteFile(name string, contents []byte) error {
+	attrPath := f.path(name)
+	if err := os.WriteFile(attrPath, contents, 0644); err != nil {
+		return err
+	}
+	if filepath.Base(attrPath) != "inblob" {
+		return nil
+	}
+
+	entry := filepath.Dir(attrPath)
+	generation, err := os.ReadFile(filepath.Join(entry, "generation"))
+	if err != nil {
+		return err
+	}
+	value, err := strconv.Atoi(strings.TrimSpace(string(generation)))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(entry, "generation"), []byte(strconv.Itoa(value+1)+"\n"), 0644); err != nil {
+		return err
+	}
+
+	var reportData [64]byte
+	copy(reportData[:], contents)
+	quote, err := f.signer.SignQuote(MockQuoteConfig{}.NewTDQuoteBody([4][]byte{}, reportData))
+	if err != nil {
+		return err
+	}
+	rawQuote, err := abi.QuoteToAbiBytes(quote)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(entry, "outblob"), rawQuote, 0644)
+}
+
+func (f *fakeTsmTree) RemoveAll(name string) error {
+	return os.RemoveAll(f.path(name))
+}
+
+func TestConfigfsTDXClient(t *testing.T) {
+	t.Setenv("ENV", "")
+	tdxtest.ClearEnv(t)
+	fake := newFakeTsmTree(t, tdxGuestProvider)
+
+	client, err := newTDXClientWithBackend(QuoteBackendAuto, func() (configfsi.Client, error) { return fake, nil })
+	if err != nil {
+		t.Fatalf("newTDXClientWithBackend failed: %v", err)
+	}
+	if _, ok := client.(*ConfigfsTDXClient); !ok {
+		t.Fatalf("auto backend selected %T, want *ConfigfsTDXClient", client)
+	}
+
+	userData := []byte("nonce")
+	quote, binding, err := GetQuoteWithReportData(client, userData)
+	if err != nil {
+		t.Fatalf("GetQuoteWithReportData failed: %v", err)
+	}
+	if !bytes.Equal(quote.GetTdQuoteBody().ReportData, binding.ReportData) {
+		t.Errorf("REPORTDATA = %x, want %x", quote.GetTdQuoteBody().ReportData, binding.ReportData)
+	}
+
+	// Report entries are removed after use
+	entries, err := os.ReadDir(filepath.Join(fake.root, "report"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("%d report entries left behind", len(entries))
+	}
+}
+
+func TestQuoteBackendSelection(t *testing.T) {
+	t.Setenv("ENV", "")
+	tdxtest.ClearEnv(t)
+	missing := func() (configfsi.Client, error) { return nil, os.ErrNotExist }
+	sevGuest := func() (configfsi.Client, error) { return newFakeTsmTree(t, "sev_guest"), nil }
+
+	tests := []struct {
+		name       string
+		backend    string
+		makeClient func() (configfsi.Client, error)
+		wantErr    bool
+	}{
+		{"auto without configfs", QuoteBackendAuto, missing, false},
+		{"auto with another provider", "", sevGuest, false},
+		{"device", QuoteBackendDevice, sevGuest, false},
+		{"configfs without configfs", QuoteBackendConfigfs, missing, true},
+		{"configfs with another provider", QuoteBackendConfigfs, sevGuest, true},
+		{"unknown", "ioctl", missing, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := newTDXClientWithBackend(tt.backend, tt.makeClient)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got %T, want an error", client)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newTDXClientWithBackend failed: %v", err)
+			}
+			if _, ok := client.(*TDXClient); !ok {
+				t.Errorf("selected %T, want the device *TDXClient", client)
+			}
+		})
+	}
+
+	_, err := newTDXClientWithBackend(QuoteBackendConfigfs, sevGuest)
+	if !errors.Is(err, ErrConfigfsUnsupported) {
+		t.Errorf("error = %v, want ErrConfigfsUnsupported", err)
+	}
+}
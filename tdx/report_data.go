@@ -0,0 +1,109 @@
+package tdx
+
+import (
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"os"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+const (
+	ReportDataSize        = 64 // Size of the REPORTDATA field of a TD quote
+	MaxUserReportDataSize = 64 // Maximum size of caller supplied report data
+	RtmrSize              = 48 // Size of a single RTMR value
+	ImaRtmrIndex          = 2  // RTMR index extended with IMA event logs
+)
+
+// ErrReportDataTooLong is returned when the caller supplied report data exceeds MaxUserReportDataSize.
+var ErrReportDataTooLong = errors.New("report data too long")
+
+// BuildReportData composes the 64 bytes of REPORTDATA from the caller supplied data and the current RTMR digest.
+//
+// The composition depends on the input:
+//   - user data given: SHA-512(userData || rtmrDigest)
+//   - no user data, TDX_VERSION=1.0: rtmrDigest followed by zero padding
+//   - otherwise: all zeros
+func BuildReportData(userData []byte, rtmrDigest []byte) ([ReportDataSize]byte, *attestpb.ReportDataBinding, error) {
+	var reportData [ReportDataSize]byte
+
+	if err := validateUserReportData(userData); err != nil {
+		return reportData, nil, err
+	}
+
+	binding := &attestpb.ReportDataBinding{
+		UserData:  userData,
+		RtmrIndex: ImaRtmrIndex,
+	}
+
+	switch {
+	case len(userData) > 0:
+		digest := normalizeRtmr(rtmrDigest)
+		hasher := sha512.New()
+		hasher.Write(userData)
+		hasher.Write(digest)
+		copy(reportData[:], hasher.Sum(nil))
+		binding.Scheme = attestpb.ReportDataScheme_REPORT_DATA_SCHEME_SHA512_USER_DATA_RTMR
+		binding.RtmrDigest = digest
+	case os.Getenv("TDX_VERSION") == "1.0":
+		digest := normalizeRtmr(rtmrDigest)
+		copy(reportData[:], digest)
+		binding.Scheme = attestpb.ReportDataScheme_REPORT_DATA_SCHEME_RTMR
+		binding.RtmrDigest = digest
+	default:
+		binding.Scheme = attestpb.ReportDataScheme_REPORT_DATA_SCHEME_ZERO
+	}
+
+	binding.ReportData = reportData[:]
+	return reportData, binding, nil
+}
+
+// BuildChallengeReportData composes the 64 bytes of REPORTDATA as SHA-512(challenge || userData || rtmrDigest). The
+// challenge has a fixed size, so the composition is unambiguous.
+func BuildChallengeReportData(challenge []byte, userData []byte, rtmrDigest []byte) ([ReportDataSize]byte, *attestpb.ReportDataBinding, error) {
+	var reportData [ReportDataSize]byte
+
+	if len(challenge) != ChallengeSize {
+		return reportData, nil, fmt.Errorf("invalid challenge: got %d bytes, want %d", len(challenge), ChallengeSize)
+	}
+	if err := validateUserReportData(userData); err != nil {
+		return reportData, nil, err
+	}
+
+	digest := normalizeRtmr(rtmrDigest)
+	hasher := sha512.New()
+	hasher.Write(challenge)
+	hasher.Write(userData)
+	hasher.Write(digest)
+	copy(reportData[:], hasher.Sum(nil))
+
+	return reportData, &attestpb.ReportDataBinding{
+		Scheme:     attestpb.ReportDataScheme_REPORT_DATA_SCHEME_SHA512_CHALLENGE_USER_DATA_RTMR,
+		UserData:   userData,
+		RtmrIndex:  ImaRtmrIndex,
+		RtmrDigest: digest,
+		ReportData: reportData[:],
+		Challenge:  challenge,
+	}, nil
+}
+
+// validateUserReportData checks that the caller supplied report data fits into the composition.
+func validateUserReportData(userData []byte) error {
+	if len(userData) > MaxUserReportDataSize {
+		return fmt.Errorf("%w: got %d bytes, max %d", ErrReportDataTooLong, len(userData), MaxUserReportDataSize)
+	}
+	return nil
+}
+
+// needsRtmr reports whether BuildReportData will use the RTMR digest for the given user data.
+func needsRtmr(userData []byte) bool {
+	return len(userData) > 0 || os.Getenv("TDX_VERSION") == "1.0"
+}
+
+// normalizeRtmr returns the RTMR digest as exactly RtmrSize bytes. An RTMR that was never extended is all zeros.
+func normalizeRtmr(rtmr []byte) []byte {
+	digest := make([]byte, RtmrSize)
+	copy(digest, rtmr)
+	return digest
+}
@@ -0,0 +1,67 @@
+package tdx
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+	"testing"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+func TestBuildReportData(t *testing.T) {
+	rtmr := bytes.Repeat([]byte{0xab}, RtmrSize)
+	nonce := []byte("nonce")
+
+	t.Run("user data", func(t *testing.T) {
+		reportData, binding, err := BuildReportData(nonce, rtmr)
+		if err != nil {
+			t.Fatalf("BuildReportData failed: %v", err)
+		}
+		want := sha512.Sum512(append(append([]byte{}, nonce...), rtmr...))
+		if reportData != want {
+			t.Errorf("report data = %x, want %x", reportData, want)
+		}
+		if binding.Scheme != attestpb.ReportDataScheme_REPORT_DATA_SCHEME_SHA512_USER_DATA_RTMR {
+			t.Errorf("scheme = %v", binding.Scheme)
+		}
+		if !bytes.Equal(binding.ReportData, want[:]) {
+			t.Errorf("binding report data does not match")
+		}
+	})
+
+	t.Run("legacy rtmr", func(t *testing.T) {
+		t.Setenv("TDX_VERSION", "1.0")
+		reportData, binding, err := BuildReportData(nil, rtmr)
+		if err != nil {
+			t.Fatalf("BuildReportData failed: %v", err)
+		}
+		if !bytes.Equal(reportData[:RtmrSize], rtmr) || !bytes.Equal(reportData[RtmrSize:], make([]byte, ReportDataSize-RtmrSize)) {
+			t.Errorf("report data = %x", reportData)
+		}
+		if binding.Scheme != attestpb.ReportDataScheme_REPORT_DATA_SCHEME_RTMR {
+			t.Errorf("scheme = %v", binding.Scheme)
+		}
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		t.Setenv("TDX_VERSION", "1.5")
+		reportData, binding, err := BuildReportData(nil, nil)
+		if err != nil {
+			t.Fatalf("BuildReportData failed: %v", err)
+		}
+		if reportData != [ReportDataSize]byte{} {
+			t.Errorf("report data = %x, want zeros", reportData)
+		}
+		if binding.Scheme != attestpb.ReportDataScheme_REPORT_DATA_SCHEME_ZERO {
+			t.Errorf("scheme = %v", binding.Scheme)
+		}
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		_, _, err := BuildReportData(make([]byte, MaxUserReportDataSize+1), rtmr)
+		if !errors.Is(err, ErrReportDataTooLong) {
+			t.Errorf("err = %v, want ErrReportDataTooLong", err)
+		}
+	})
+}
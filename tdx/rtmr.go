@@ -1,107 +1,167 @@
 package tdx
 
 import (
-	"bufio"
 	"crypto"
 	"fmt"
+	"log"
 	"os"
 	"sync"
 )
 
-// RtmrProvider is a provider for RTMR values.
+// RtmrProvider is a provider for RTMR values. It replays the events of its measurement sources into the RTMRs and
+// records them for verifiers.
 type RtmrProvider struct {
-	rtmrs			  [4][]byte   // RTMR values
-	mu                sync.Mutex  // Protects lastProcessedLine
-	lastProcessedLine int         // The last processed line number
-	logPath           string      // Path to the IMA log file
-	hashAlgo          crypto.Hash // Hash algorithm to use
+	rtmrs     [4][]byte           // RTMR values
+	events    []MeasurementEvent  // Events extended into the RTMRs, in order
+	mu        sync.Mutex          // Protects rtmrs and events
+	updateMu  sync.Mutex          // Serializes updates from the sources
+	sources   []MeasurementSource // Sources measured into the RTMRs, in update order
+	configErr error               // Error of the measurement configuration, reported on update
+	hashAlgo  crypto.Hash         // Hash algorithm to use
+	recorded  chan struct{}       // Closed when the next event is recorded, created on demand
 }
 
-// NewRtmrProvider creates a new RtmrProvider.
+// NewRtmrProvider creates a new RtmrProvider extending the IMA log at logPath into RTMR[2].
 func NewRtmrProvider(logPath string, hashAlgo crypto.Hash) *RtmrProvider {
+	var sources []MeasurementSource
+	if logPath != "" {
+		sources = append(sources, NewImaSource(logPath, ImaRtmrIndex, hashAlgo))
+	}
+	return NewRtmrProviderWithSources(hashAlgo, sources...)
+}
+
+// NewRtmrProviderWithSources creates a new RtmrProvider extending the given sources, in order.
+func NewRtmrProviderWithSources(hashAlgo crypto.Hash, sources ...MeasurementSource) *RtmrProvider {
 	return &RtmrProvider{
-		lastProcessedLine: 0,
-		logPath:           logPath,
-		hashAlgo:          hashAlgo,
+		sources:  sources,
+		hashAlgo: hashAlgo,
 	}
 }
 
-// DefaultRtmrProvider creates a new IMARtmrProvider with default settings.
-func DefaultRtmrProvider() *RtmrProvider {
-	logPath := os.Getenv("IMA_LOG_PATH")
-	return NewRtmrProvider(logPath, crypto.SHA384)
+// NewRtmrProviderFromConfig creates a new RtmrProvider extending the sources declared by the config.
+func NewRtmrProviderFromConfig(config *MeasurementConfig) (*RtmrProvider, error) {
+	hashAlgo, err := config.RtmrHashAlgo()
+	if err != nil {
+		return nil, err
+	}
+	sources, err := config.NewSources()
+	if err != nil {
+		return nil, fmt.Errorf("invalid measurement config: %w", err)
+	}
+	return NewRtmrProviderWithSources(hashAlgo, sources...), nil
 }
 
-// UpdateImaRtmr reads the IMA log file and updates the RTMR values.
-func (e *RtmrProvider) UpdateImaRtmr() error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+// DefaultRtmrProvider creates a new RtmrProvider from the config file at RTMR_CONFIG_PATH, or from
+// DefaultMeasurementConfig if unset. An invalid config file is reported by every update, so that no quote is issued
+// with measurements that do not follow it.
+func DefaultRtmrProvider() *RtmrProvider {
+	config := DefaultMeasurementConfig()
+	if path := os.Getenv("RTMR_CONFIG_PATH"); path != "" {
+		var err error
+		if config, err = LoadMeasurementConfig(path); err != nil {
+			log.Printf("[Error] %v", err)
+			return &RtmrProvider{configErr: err, hashAlgo: crypto.SHA384}
+		}
+	}
 
-	// Open the IMA log file
-	file, err := os.Open(e.logPath)
+	provider, err := NewRtmrProviderFromConfig(config)
 	if err != nil {
-		return fmt.Errorf("failed to open log file: %w", err)
+		log.Printf("[Error] %v", err)
+		return &RtmrProvider{configErr: err, hashAlgo: crypto.SHA384}
 	}
-	defer file.Close()
+	return provider
+}
 
-	// Read the file line by line
-	scanner := bufio.NewScanner(file)
-	currentLine := 0
-	rtmrIndex := 2
-	for scanner.Scan() {
-		currentLine++
+// AddSource appends a measurement source, updated after the existing ones.
+func (e *RtmrProvider) AddSource(source MeasurementSource) {
+	e.updateMu.Lock()
+	defer e.updateMu.Unlock()
+	e.sources = append(e.sources, source)
+}
 
-		// Skip lines that have already been processed
-		if currentLine <= e.lastProcessedLine {
-			continue
-		}
+// UpdateRtmrs extends the new events of every source, in order.
+func (e *RtmrProvider) UpdateRtmrs() error {
+	e.updateMu.Lock()
+	defer e.updateMu.Unlock()
 
-		// Process the current line
-		eventLog := scanner.Bytes()
-		
-		// Compute the hash of the event log
-		hasher := e.hashAlgo.New()
-		hasher.Write(eventLog)
-		digest := hasher.Sum(nil)
-
-		// Extend the RTMR with the new digest
-		err := e.ExtendRtmr(rtmrIndex, digest)
-		if err != nil {
-			return fmt.Errorf("failed to extend event log on line %d: %w", currentLine, err)
-		}
+	if e.configErr != nil {
+		return e.configErr
 	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("failed to read log file: %w", err)
+	for _, source := range e.sources {
+		// Events measured before an error are extended, since the source will not return them again
+		events, measureErr := source.Measure()
+		for _, event := range events {
+			if err := e.ExtendEvent(event); err != nil {
+				return fmt.Errorf("failed to extend event of %s: %w", source.Name(), err)
+			}
+		}
+		if measureErr != nil {
+			return fmt.Errorf("failed to measure %s: %w", source.Name(), measureErr)
+		}
 	}
-
-	// Update the last processed line
-	e.lastProcessedLine = currentLine
 	return nil
 }
 
-// GetLastProcessedLine retrieves the last processed line number.
-func (e *RtmrProvider) GetLastProcessedLine() int {
+// GetRtmrValues returns the RTMR values. RTMRs that were never extended are nil.
+func (e *RtmrProvider) GetRtmrValues() [4][]byte {
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	return e.lastProcessedLine
+	return e.rtmrs
 }
 
-// SetLastProcessedLine sets the last processed line number manually.
-func (e *RtmrProvider) SetLastProcessedLine(line int) {
+// GetEventLog returns up to limit recorded events starting from the given sequence number. A limit of 0 returns all
+// remaining events.
+func (e *RtmrProvider) GetEventLog(start uint64, limit int) (*EventLogPage, error) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	e.lastProcessedLine = line
+
+	total := uint64(len(e.events))
+	if start > total {
+		return nil, fmt.Errorf("%w: start %d, %d events recorded", ErrSequenceOutOfRange, start, total)
+	}
+
+	end := total
+	if limit > 0 && start+uint64(limit) < end {
+		end = start + uint64(limit)
+	}
+
+	return &EventLogPage{
+		Start:       start,
+		Events:      append([]MeasurementEvent(nil), e.events[start:end]...),
+		TotalEvents: total,
+		Rtmrs:       e.rtmrs,
+	}, nil
 }
 
-func (e *RtmrProvider) GetRtmrValues() [4][]byte {
+// EventRecorded returns a channel that is closed when the next event is recorded. Callers read the event log after
+// getting the channel, so that no event recorded in between is missed.
+func (e *RtmrProvider) EventRecorded() <-chan struct{} {
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	return e.rtmrs
+	if e.recorded == nil {
+		e.recorded = make(chan struct{})
+	}
+	return e.recorded
 }
 
 // ExtendRtmr concatenates the current RTMR value with the new digest and updates the RTMR with the hash of the result.
 func (e *RtmrProvider) ExtendRtmr(index int, digest []byte) error {
+	return e.ExtendEvent(MeasurementEvent{RtmrIndex: index, Digest: digest})
+}
+
+// ExtendEvent extends the digest of the event into its RTMR and records the event.
+func (e *RtmrProvider) ExtendEvent(event MeasurementEvent) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.extendEvent(event)
+}
+
+// extendEvent extends the digest of the event into its RTMR and records the event. The caller must hold e.mu.
+func (e *RtmrProvider) extendEvent(event MeasurementEvent) error {
+	index := event.RtmrIndex
+	digest := event.Digest
+
 	// Validate RTMR index
 	if index < 0 || index >= len(e.rtmrs) {
 		return fmt.Errorf("invalid index: %d", index)
@@ -112,11 +172,20 @@ func (e *RtmrProvider) ExtendRtmr(index int, digest []byte) error {
 		return fmt.Errorf("hash algorithm %v is not available", e.hashAlgo)
 	}
 
+	// As in the TCG event logs, the RTMR is extended with the digest of the event in the RTMR algorithm. Digests of
+	// other algorithms are event metadata and are not extended.
+	if len(digest) != e.hashAlgo.Size() {
+		return fmt.Errorf("digest of %d bytes is not a %v digest", len(digest), e.hashAlgo)
+	}
+
 	// Create a new hash instance
 	hasher := e.hashAlgo.New()
 
-	// Concatenate current RTMR value with the new digest
+	// Concatenate current RTMR value with the new digest. An RTMR that was never extended is all zeros.
 	currentRTMR := e.rtmrs[index]
+	if currentRTMR == nil {
+		currentRTMR = make([]byte, e.hashAlgo.Size())
+	}
 	hasher.Write(currentRTMR) // Add the current RTMR
 	hasher.Write(digest)      // Add the new digest
 
@@ -126,5 +195,19 @@ func (e *RtmrProvider) ExtendRtmr(index int, digest []byte) error {
 	// Update the RTMR with the new hash
 	e.rtmrs[index] = newRTMR
 
+	// Record the event for replay by verifiers
+	event.Sequence = uint64(len(e.events))
+	event.RtmrValue = newRTMR
+	if event.HashAlgo == 0 {
+		event.HashAlgo = e.hashAlgo
+	}
+	e.events = append(e.events, event)
+
+	// Wake up the watchers of the event log
+	if e.recorded != nil {
+		close(e.recorded)
+		e.recorded = nil
+	}
+
 	return nil
-}
\ No newline at end of file
+}
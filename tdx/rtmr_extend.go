@@ -0,0 +1,67 @@
+package tdx
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/google/go-configfs-tsm/configfs/configfsi"
+	"github.com/google/go-configfs-tsm/configfs/linuxtsm"
+	"github.com/google/go-configfs-tsm/rtmr"
+)
+
+const (
+	TdxGuestDevicePath = "/dev/tdx_guest" // Device of the TDX guest driver
+	AppEventSource     = "application"    // Source recorded for events extended by the application
+)
+
+// ErrRtmrNotExtendable is returned for RTMRs that the TD cannot extend at runtime.
+var ErrRtmrNotExtendable = errors.New("RTMR cannot be extended at runtime")
+
+// RtmrExtender extends the RTMRs of the TD.
+type RtmrExtender interface {
+	ExtendDigest(index int, digest []byte) error // Extends the RTMR with a SHA-384 digest
+}
+
+// configfsRtmrExtender extends RTMRs through the configfs-tsm rtmrs subsystem.
+type configfsRtmrExtender struct {
+	client configfsi.Client
+}
+
+func (e *configfsRtmrExtender) ExtendDigest(index int, digest []byte) error {
+	return rtmr.ExtendDigest(e.client, index, digest)
+}
+
+// DefaultRtmrExtender returns the configfs-tsm extender if the kernel exposes the rtmrs subsystem, and the
+// /dev/tdx_guest ioctl extender otherwise.
+func DefaultRtmrExtender() RtmrExtender {
+	if client, err := linuxtsm.MakeClient(); err == nil {
+		if _, err := os.Stat(path.Join(configfsi.TsmPrefix, "rtmrs")); err == nil {
+			return &configfsRtmrExtender{client: client}
+		}
+	}
+	return &ioctlRtmrExtender{devicePath: TdxGuestDevicePath}
+}
+
+// validateExtendableRtmr checks that the RTMR can be extended at runtime. RTMR[0] and RTMR[1] belong to the firmware
+// and the boot loader.
+func validateExtendableRtmr(index int) error {
+	if index != 2 && index != 3 {
+		return fmt.Errorf("%w: %d", ErrRtmrNotExtendable, index)
+	}
+	return nil
+}
+
+// newAppEvent creates the event recorded for application data extended into an RTMR.
+func newAppEvent(index int, eventType string, eventData []byte) MeasurementEvent {
+	return MeasurementEvent{
+		RtmrIndex: index,
+		Digest:    hashBytes(crypto.SHA384, eventData),
+		HashAlgo:  crypto.SHA384,
+		EventType: eventType,
+		EventData: eventData,
+		Source:    AppEventSource,
+	}
+}
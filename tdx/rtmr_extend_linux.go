@@ -0,0 +1,44 @@
+//go:build linux
+
+package tdx
+
+import (
+	"fmt"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// tdxExtendRtmrReq is the request of the TDX_CMD_EXTEND_RTMR ioctl of the TDX guest driver.
+type tdxExtendRtmrReq struct {
+	Data  [RtmrSize]byte
+	Index uint8
+}
+
+// iocTdxExtendRtmr is _IOW('T', 3, struct tdx_extend_rtmr_req).
+const iocTdxExtendRtmr = (1 << 30) | (uintptr(unsafe.Sizeof(tdxExtendRtmrReq{})) << 16) | ('T' << 8) | 3
+
+// ioctlRtmrExtender extends RTMRs through the ioctl of TDX guest drivers that predate configfs-tsm.
+type ioctlRtmrExtender struct {
+	devicePath string
+}
+
+func (e *ioctlRtmrExtender) ExtendDigest(index int, digest []byte) error {
+	if len(digest) != RtmrSize {
+		return fmt.Errorf("digest must be %d bytes, got %d", RtmrSize, len(digest))
+	}
+
+	device, err := os.OpenFile(e.devicePath, os.O_RDWR, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open TDX guest device: %w", err)
+	}
+	defer device.Close()
+
+	req := tdxExtendRtmrReq{Index: uint8(index)}
+	copy(req.Data[:], digest)
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, device.Fd(), iocTdxExtendRtmr, uintptr(unsafe.Pointer(&req))); errno != 0 {
+		return fmt.Errorf("failed to extend RTMR[%d]: %w", index, errno)
+	}
+	return nil
+}
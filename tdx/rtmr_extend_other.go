@@ -0,0 +1,14 @@
+//go:build !linux
+
+package tdx
+
+import "errors"
+
+// ioctlRtmrExtender is not supported outside Linux.
+type ioctlRtmrExtender struct {
+	devicePath string
+}
+
+func (e *ioctlRtmrExtender) ExtendDigest(index int, digest []byte) error {
+	return errors.New("extending RTMRs is only supported on Linux")
+}
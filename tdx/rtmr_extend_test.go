@@ -0,0 +1,85 @@
+package tdx
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha512"
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/google/go-configfs-tsm/configfs/configfsi"
+	"github.com/google/go-configfs-tsm/configfs/fakertmr"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+)
+
+func TestExtendRtmr(t *testing.T) {
+	t.Setenv("ENV", "")
+	tdxtest.ClearEnv(t)
+	client := NewMockTDXClient()
+
+	eventData := []byte("auction 1")
+	if err := client.ExtendRtmr(3, "auction_result", eventData); err != nil {
+		t.Fatalf("ExtendRtmr failed: %v", err)
+	}
+	if err := client.ExtendRtmr(0, "auction_result", eventData); !errors.Is(err, ErrRtmrNotExtendable) {
+		t.Errorf("ExtendRtmr(0) error = %v, want ErrRtmrNotExtendable", err)
+	}
+
+	digest := sha512.Sum384(eventData)
+	want := sha512.Sum384(append(make([]byte, 48), digest[:]...))
+	rtmrs, err := client.GetRtmrs()
+	if err != nil {
+		t.Fatalf("GetRtmrs failed: %v", err)
+	}
+	if !bytes.Equal(rtmrs[3], want[:]) {
+		t.Errorf("RTMR[3] = %x, want %x", rtmrs[3], want)
+	}
+
+	page, err := client.GetEventLog(0, 0)
+	if err != nil {
+		t.Fatalf("GetEventLog failed: %v", err)
+	}
+	if len(page.Events) != 1 {
+		t.Fatalf("got %d events, want 1", len(page.Events))
+	}
+	event := page.Events[0]
+	if event.RtmrIndex != 3 || event.EventType != "auction_result" || !bytes.Equal(event.EventData, eventData) ||
+		!bytes.Equal(event.Digest, digest[:]) || event.Source != AppEventSource {
+		t.Errorf("unexpected event %+v", event)
+	}
+}
+
+func TestExtendRtmrConfigfs(t *testing.T) {
+	fake := fakertmr.CreateRtmrSubsystem(t.TempDir())
+	client := &TDXClient{
+		rtmrProvider: NewRtmrProviderWithSources(crypto.SHA384),
+		rtmrExtender: &configfsRtmrExtender{client: fake},
+	}
+
+	eventData := []byte("auction 1")
+	if err := client.ExtendRtmr(2, "auction_result", eventData); err != nil {
+		t.Fatalf("ExtendRtmr failed: %v", err)
+	}
+
+	// The fake does not fold the written digest into its value, so only check that the RTMR entry was extended
+	digest := sha512.Sum384(eventData)
+	entries, err := fake.ReadDir(path.Join(configfsi.TsmPrefix, "rtmrs"))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("ReadDir returned %d entries, %v", len(entries), err)
+	}
+	extended, err := fake.ReadFile(path.Join(configfsi.TsmPrefix, "rtmrs", entries[0].Name(), "digest"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if len(extended) != crypto.SHA384.Size() {
+		t.Errorf("configfs RTMR[2] = %x, want an extended SHA-384 digest", extended)
+	}
+
+	rtmrs := client.rtmrProvider.GetRtmrValues()
+	recorded := sha512.Sum384(append(make([]byte, 48), digest[:]...))
+	if !bytes.Equal(rtmrs[2], recorded[:]) {
+		t.Errorf("recorded RTMR[2] = %x, want %x", rtmrs[2], recorded)
+	}
+}
@@ -0,0 +1,71 @@
+package tdx
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+)
+
+// DefaultWatchInterval is the period of the updates of the background updater when no change is reported.
+const DefaultWatchInterval = 30 * time.Second
+
+// DefaultRtmrWatch returns the interval of the background updates when RTMR_WATCH=true, and whether they are enabled.
+// The interval is RTMR_WATCH_INTERVAL, or DefaultWatchInterval if unset.
+func DefaultRtmrWatch() (time.Duration, bool) {
+	if os.Getenv("RTMR_WATCH") != "true" {
+		return 0, false
+	}
+	interval := DefaultWatchInterval
+	if value := os.Getenv("RTMR_WATCH_INTERVAL"); value != "" {
+		var err error
+		if interval, err = time.ParseDuration(value); err != nil || interval <= 0 {
+			log.Printf("[Warning] Invalid RTMR_WATCH_INTERVAL '%s'. Defaulting to %v.", value, DefaultWatchInterval)
+			interval = DefaultWatchInterval
+		}
+	}
+	return interval, true
+}
+
+// Watch updates the RTMRs in the background whenever the file of a WatchedSource changes, and at least every
+// interval, so that quote requests only extend the events appended since. Files that do not report changes, such as
+// the securityfs IMA log, are covered by the periodic update. Watch blocks until the context is done.
+func (e *RtmrProvider) Watch(ctx context.Context, interval time.Duration) error {
+	e.updateMu.Lock()
+	var paths []string
+	for _, source := range e.sources {
+		if watched, ok := source.(WatchedSource); ok {
+			paths = append(paths, watched.WatchPath())
+		}
+	}
+	e.updateMu.Unlock()
+
+	var changes <-chan struct{}
+	watcher, err := newFileWatcher(paths)
+	if err != nil {
+		log.Printf("[Warning] Failed to watch measurement sources, updating every %v: %v", interval, err)
+	} else {
+		defer watcher.Close()
+		changes = watcher.Changes()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.UpdateRtmrs(); err != nil {
+			log.Printf("[Error] Failed to update RTMRs: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-changes:
+			if !ok {
+				// The watcher failed; keep the periodic updates
+				changes = nil
+			}
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package tdx
+
+import (
+	"context"
+	"crypto"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestWatchUpdatesOnChange(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("file watching is only supported on Linux")
+	}
+
+	path := filepath.Join(t.TempDir(), "events.log")
+	writeFile(t, path, "one\n")
+	provider := NewRtmrProviderWithSources(crypto.SHA384, NewEventFileSource(path, 3, crypto.SHA384))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- provider.Watch(ctx, time.Hour) }()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	waitForEvents := func(n uint64) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			page, err := provider.GetEventLog(0, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if page.TotalEvents == n {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("provider did not record %d events", n)
+	}
+
+	waitForEvents(1)
+	appendFile(t, path, "two\n")
+	waitForEvents(2)
+}
+
+func TestDefaultRtmrWatch(t *testing.T) {
+	tests := []struct {
+		watch, interval string
+		want            time.Duration
+		enabled         bool
+	}{
+		{"", "", 0, false},
+		{"false", "1s", 0, false},
+		{"true", "", DefaultWatchInterval, true},
+		{"true", "5s", 5 * time.Second, true},
+		{"true", "-5s", DefaultWatchInterval, true},
+		{"true", "soon", DefaultWatchInterval, true},
+	}
+	for _, test := range tests {
+		t.Setenv("RTMR_WATCH", test.watch)
+		t.Setenv("RTMR_WATCH_INTERVAL", test.interval)
+		interval, enabled := DefaultRtmrWatch()
+		if interval != test.want || enabled != test.enabled {
+			t.Errorf("DefaultRtmrWatch() with RTMR_WATCH=%q RTMR_WATCH_INTERVAL=%q = %v, %v, want %v, %v", test.watch, test.interval, interval, enabled, test.want, test.enabled)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package tdx
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+// Values of SELF_ATTEST_ON_FAILURE.
+const (
+	SelfAttestOnFailureRefuse  = "refuse"  // The server refuses to start
+	SelfAttestOnFailureDegrade = "degrade" // The server starts with AuctionService disabled
+)
+
+// selfAttestationAttempts bounds the attempts to get a quote while the event log is growing.
+const selfAttestationAttempts = 3
+
+// ErrNoExpectedMrTd is returned when the self-attestation policy does not list the expected MRTD.
+var ErrNoExpectedMrTd = errors.New("self-attestation policy has no expected mr_td")
+
+// SelfAttestationConfig configures the check of the server's own quote at startup.
+type SelfAttestationConfig struct {
+	Enabled bool             // Whether the quote is checked
+	Policy  *verifier.Policy // Policy the quote must satisfy; it must list the expected MRTD
+	Degrade bool             // On failure, disables AuctionService instead of refusing to start
+}
+
+// DefaultSelfAttestationConfig reads the self-attestation configuration. SELF_ATTEST enables it (true, false or
+// auto, which enables it when ENV is TDX), SELF_ATTEST_POLICY_PATH is the policy file and SELF_ATTEST_ON_FAILURE is
+// either refuse or degrade. It returns an error if the policy file is set but invalid.
+func DefaultSelfAttestationConfig() (SelfAttestationConfig, error) {
+	config := SelfAttestationConfig{Enabled: os.Getenv("ENV") == "TDX"}
+
+	switch value := os.Getenv("SELF_ATTEST"); value {
+	case "", "auto":
+	case "true":
+		config.Enabled = true
+	case "false":
+		config.Enabled = false
+	default:
+		log.Printf("[Warning] Invalid SELF_ATTEST '%s'. Defaulting to auto.", value)
+	}
+	switch value := os.Getenv("SELF_ATTEST_ON_FAILURE"); value {
+	case "", SelfAttestOnFailureRefuse:
+	case SelfAttestOnFailureDegrade:
+		config.Degrade = true
+	default:
+		log.Printf("[Warning] Invalid SELF_ATTEST_ON_FAILURE '%s'. Defaulting to %s.", value, SelfAttestOnFailureRefuse)
+	}
+
+	if path := os.Getenv("SELF_ATTEST_POLICY_PATH"); path != "" {
+		policy, err := verifier.LoadPolicy(path)
+		if err != nil {
+			return config, err
+		}
+		config.Policy = policy
+	}
+	return config, nil
+}
+
+// SelfAttestation is the outcome of the check of the server's own quote.
+type SelfAttestation struct {
+	State      attestpb.SelfAttestationState // Skipped, passed or failed
+	CheckedAt  time.Time                     // When the quote was checked
+	Err        error                         // Reason of the failure
+	Violations []verifier.Violation          // Policy violations, if the quote violated the policy
+	Quote      *attestpb.Quote               // The checked quote
+	Degraded   bool                          // Whether the server runs with AuctionService disabled
+}
+
+// Passed reports whether the server may serve normally: the quote was checked and satisfied the policy, or
+// self-attestation is disabled.
+func (a *SelfAttestation) Passed() bool {
+	return a.State != attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_FAILED
+}
+
+// SelfAttest gets a quote of the TD and checks it: its signature chain must verify, TDATTRIBUTES.DEBUG must be clear,
+// MRTD must be one the policy expects, the RTMRs with recorded events must replay from the event log and the rest
+// of the policy must hold. v may be nil to skip the signature check.
+func SelfAttest(client TDXClientInterface, v *verifier.Verifier, config SelfAttestationConfig) *SelfAttestation {
+	result := &SelfAttestation{
+		State:     attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_SKIPPED,
+		CheckedAt: time.Now(),
+	}
+	if !config.Enabled {
+		return result
+	}
+
+	if err := selfAttest(client, v, config.Policy, result); err != nil {
+		result.State = attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_FAILED
+		result.Err = err
+		result.Degraded = config.Degrade
+		return result
+	}
+	result.State = attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_PASSED
+	return result
+}
+
+func selfAttest(client TDXClientInterface, v *verifier.Verifier, policy *verifier.Policy, result *SelfAttestation) error {
+	if policy == nil || len(policy.MrTd) == 0 {
+		return ErrNoExpectedMrTd
+	}
+
+	quote, events, err := selfAttestationEvidence(client)
+	if err != nil {
+		return err
+	}
+	result.Quote = quote
+
+	if v != nil {
+		if _, err := v.VerifyQuote(quote); err != nil {
+			return err
+		}
+	}
+
+	err = startupPolicy(policy, events).Check(quote, events)
+	var policyErr *verifier.PolicyError
+	if errors.As(err, &policyErr) {
+		result.Violations = policyErr.Violations
+	}
+	return err
+}
+
+// selfAttestationEvidence gets a fresh quote and the event log it reflects. The event log is read before and after
+// the quote, and the quote is taken again if events were recorded in between.
+func selfAttestationEvidence(client TDXClientInterface) (*attestpb.Quote, []*attestpb.MeasurementEvent, error) {
+	nonce := make([]byte, ChallengeSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	for attempt := 0; attempt < selfAttestationAttempts; attempt++ {
+		before, err := client.GetEventLog(0, 0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get event log: %w", err)
+		}
+		quote, _, err := GetQuoteWithReportData(client, nonce)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get quote: %w", err)
+		}
+		after, err := client.GetEventLog(0, 0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get event log: %w", err)
+		}
+		if after.TotalEvents == before.TotalEvents {
+			return quote, ConvertEventLogPageToProtobuf(after).GetEvents(), nil
+		}
+	}
+	return nil, nil, errors.New("event log kept changing while getting a quote")
+}
+
+// startupPolicy extends the policy to forbid debug mode and to require every RTMR with recorded events to replay
+// from the event log.
+func startupPolicy(policy *verifier.Policy, events []*attestpb.MeasurementEvent) *verifier.Policy {
+	startup := *policy
+
+	attributes := verifier.TdAttributesPolicy{}
+	if policy.TdAttributes != nil {
+		attributes = *policy.TdAttributes
+	}
+	if !containsFold(attributes.Forbidden, "debug") {
+		attributes.Forbidden = append(append([]string{}, attributes.Forbidden...), "debug")
+	}
+	startup.TdAttributes = &attributes
+
+	startup.EventLog = append([]verifier.EventLogPolicy{}, policy.EventLog...)
+	for _, event := range events {
+		index := int(event.GetRtmrIndex())
+		covered := false
+		for _, eventLog := range startup.EventLog {
+			covered = covered || eventLog.RtmrIndex == index
+		}
+		if !covered {
+			startup.EventLog = append(startup.EventLog, verifier.EventLogPolicy{RtmrIndex: index})
+		}
+	}
+	return &startup
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConvertSelfAttestationToProtobuf converts a SelfAttestation to a GetStatusResponse.
+func ConvertSelfAttestationToProtobuf(a *SelfAttestation) *attestpb.GetStatusResponse {
+	if a == nil {
+		return &attestpb.GetStatusResponse{}
+	}
+	resp := &attestpb.GetStatusResponse{
+		SelfAttestation: a.State,
+		CheckedAt:       a.CheckedAt.UnixMilli(),
+		Degraded:        a.Degraded,
+		Quote:           a.Quote,
+	}
+	if a.Err != nil {
+		resp.FailureReason = a.Err.Error()
+	}
+	for _, violation := range a.Violations {
+		resp.Violations = append(resp.Violations, violation.String())
+	}
+	return resp
+}
@@ -0,0 +1,210 @@
+package tdx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/radiusxyz/lightbulb-tdx/tdx/tdxtest"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+	"github.com/radiusxyz/lightbulb-tdx/verifier"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+// tamperedEventLogClient reports an event log whose first event has another digest than the one extended.
+type tamperedEventLogClient struct {
+	TDXClientInterface
+}
+
+func (c *tamperedEventLogClient) GetEventLog(start uint64, limit int) (*EventLogPage, error) {
+	page, err := c.TDXClientInterface.GetEventLog(start, limit)
+	if err != nil || len(page.Events) == 0 {
+		return page, err
+	}
+	page.Events = append([]MeasurementEvent{}, page.Events...)
+	page.Events[0].Digest = bytes.Repeat([]byte{0xee}, len(page.Events[0].Digest))
+	return page, nil
+}
+
+func TestSelfAttest(t *testing.T) {
+	tdxtest.ClearEnv(t)
+	t.Setenv("IMA_LOG_PATH", "testdata/ima/ascii_runtime_measurements")
+
+	signer, err := DefaultMockQuoteSigner()
+	if err != nil {
+		t.Fatalf("DefaultMockQuoteSigner failed: %v", err)
+	}
+	roots, err := verifier.ParseTrustedRoots(signer.RootCertificatePEM())
+	if err != nil {
+		t.Fatalf("ParseTrustedRoots failed: %v", err)
+	}
+	v := verifier.NewVerifier(verifier.Options{TrustedRoots: roots})
+
+	mrTd := bytes.Repeat([]byte{0x11}, 48)
+	debug := []byte{0x01, 0, 0, 0, 0, 0, 0, 0}
+	policy := &verifier.Policy{MrTd: []verifier.HexBytes{mrTd}}
+	enabled := SelfAttestationConfig{Enabled: true, Policy: policy}
+
+	tests := []struct {
+		name          string
+		client        TDXClientInterface
+		verifier      *verifier.Verifier
+		config        SelfAttestationConfig
+		wantState     attestpb.SelfAttestationState
+		wantErr       error
+		wantViolation string
+	}{
+		{
+			name:      "disabled",
+			client:    NewMockTDXClientWithConfig(MockQuoteConfig{TdAttributes: debug}),
+			config:    SelfAttestationConfig{},
+			wantState: attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_SKIPPED,
+		},
+		{
+			name:      "passed",
+			client:    NewMockTDXClientWithConfig(MockQuoteConfig{MrTd: mrTd}),
+			verifier:  v,
+			config:    enabled,
+			wantState: attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_PASSED,
+		},
+		{
+			name:      "passed with a version 5 quote",
+			client:    NewMockTDXClientWithConfig(MockQuoteConfig{MrTd: mrTd, QuoteVersion: utils.QuoteVersion5}),
+			verifier:  v,
+			config:    enabled,
+			wantState: attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_PASSED,
+		},
+		{
+			name:          "debug with a version 5 quote",
+			client:        NewMockTDXClientWithConfig(MockQuoteConfig{MrTd: mrTd, TdAttributes: debug, QuoteVersion: utils.QuoteVersion5}),
+			verifier:      v,
+			config:        enabled,
+			wantState:     attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_FAILED,
+			wantErr:       verifier.ErrPolicyViolation,
+			wantViolation: "td_attributes",
+		},
+		{
+			name:      "no expected MRTD",
+			client:    NewMockTDXClientWithConfig(MockQuoteConfig{MrTd: mrTd}),
+			config:    SelfAttestationConfig{Enabled: true, Policy: &verifier.Policy{}},
+			wantState: attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_FAILED,
+			wantErr:   ErrNoExpectedMrTd,
+		},
+		{
+			name:          "unexpected MRTD",
+			client:        NewMockTDXClientWithConfig(MockQuoteConfig{MrTd: bytes.Repeat([]byte{0x22}, 48)}),
+			config:        enabled,
+			wantState:     attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_FAILED,
+			wantErr:       verifier.ErrPolicyViolation,
+			wantViolation: "mr_td",
+		},
+		{
+			name:          "debug",
+			client:        NewMockTDXClientWithConfig(MockQuoteConfig{MrTd: mrTd, TdAttributes: debug}),
+			config:        enabled,
+			wantState:     attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_FAILED,
+			wantErr:       verifier.ErrPolicyViolation,
+			wantViolation: "td_attributes",
+		},
+		{
+			name:          "event log inconsistent with RTMRs",
+			client:        &tamperedEventLogClient{NewMockTDXClientWithConfig(MockQuoteConfig{MrTd: mrTd})},
+			config:        enabled,
+			wantState:     attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_FAILED,
+			wantErr:       verifier.ErrPolicyViolation,
+			wantViolation: "rtmrs[2]",
+		},
+		{
+			name:      "untrusted signer",
+			client:    NewMockTDXClientWithConfig(MockQuoteConfig{MrTd: mrTd}),
+			verifier:  verifier.NewVerifier(verifier.Options{}),
+			config:    SelfAttestationConfig{Enabled: true, Policy: policy, Degrade: true},
+			wantState: attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_FAILED,
+			wantErr:   verifier.ErrVerificationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SelfAttest(tt.client, tt.verifier, tt.config)
+			if result.State != tt.wantState {
+				t.Errorf("State = %v, want %v (err: %v)", result.State, tt.wantState, result.Err)
+			}
+			if !errors.Is(result.Err, tt.wantErr) {
+				t.Errorf("Err = %v, want %v", result.Err, tt.wantErr)
+			}
+			if result.Passed() != (result.State != attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_FAILED) {
+				t.Errorf("Passed = %v with state %v", result.Passed(), result.State)
+			}
+			if result.Degraded != (tt.config.Degrade && !result.Passed()) {
+				t.Errorf("Degraded = %v", result.Degraded)
+			}
+			if tt.wantViolation != "" {
+				found := false
+				for _, violation := range result.Violations {
+					found = found || violation.Field == tt.wantViolation
+				}
+				if !found {
+					t.Errorf("Violations = %v, want one of %s", result.Violations, tt.wantViolation)
+				}
+			}
+		})
+	}
+}
+
+func TestGetStatus(t *testing.T) {
+	tdxtest.ClearEnv(t)
+	client := NewMockTDXClientWithConfig(MockQuoteConfig{MrTd: bytes.Repeat([]byte{0x22}, 48)})
+	server := NewServer(client, nil)
+
+	policy := &verifier.Policy{MrTd: []verifier.HexBytes{bytes.Repeat([]byte{0x11}, 48)}}
+	server.SetSelfAttestation(SelfAttest(client, nil, SelfAttestationConfig{Enabled: true, Policy: policy, Degrade: true}))
+
+	resp, err := server.GetStatus(context.Background(), &attestpb.GetStatusRequest{})
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if resp.GetSelfAttestation() != attestpb.SelfAttestationState_SELF_ATTESTATION_STATE_FAILED || !resp.GetDegraded() {
+		t.Errorf("GetStatus = %v, want failed and degraded", resp)
+	}
+	if resp.GetFailureReason() == "" || len(resp.GetViolations()) == 0 || resp.GetQuote() == nil {
+		t.Errorf("GetStatus = %v, want the failure reason, the violations and the quote", resp)
+	}
+	if resp.GetQuoteCache() != nil {
+		t.Errorf("GetStatus quote cache = %v without a cache", resp.GetQuoteCache())
+	}
+}
+
+func TestDefaultSelfAttestationConfig(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyPath, []byte("mr_td: ["+string(bytes.Repeat([]byte("11"), 48))+"]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ENV", "TDX")
+	t.Setenv("SELF_ATTEST", "")
+	t.Setenv("SELF_ATTEST_ON_FAILURE", "degrade")
+	t.Setenv("SELF_ATTEST_POLICY_PATH", policyPath)
+
+	config, err := DefaultSelfAttestationConfig()
+	if err != nil {
+		t.Fatalf("DefaultSelfAttestationConfig failed: %v", err)
+	}
+	if !config.Enabled || !config.Degrade || config.Policy == nil || len(config.Policy.MrTd) != 1 {
+		t.Errorf("DefaultSelfAttestationConfig = %+v", config)
+	}
+
+	t.Setenv("ENV", "MOCK_TDX")
+	if config, _ := DefaultSelfAttestationConfig(); config.Enabled {
+		t.Error("self-attestation enabled outside of a TD")
+	}
+
+	if err := os.WriteFile(policyPath, []byte("mr_td: [zz]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DefaultSelfAttestationConfig(); err == nil {
+		t.Error("DefaultSelfAttestationConfig with an invalid policy succeeded")
+	}
+}
@@ -0,0 +1,88 @@
+package tdx
+
+import (
+	"fmt"
+
+	tdxpb "github.com/google/go-tdx-guest/proto/tdx"
+)
+
+// TdReportSize is the size of a TDREPORT_STRUCT, as returned by TDG.MR.REPORT.
+const TdReportSize = 1024
+
+// Offsets of the fields of a TDREPORT_STRUCT. It is made of REPORTMACSTRUCT (256 bytes), TEE_TCB_INFO (239 bytes and
+// 17 reserved) and TDINFO_STRUCT (512 bytes).
+const (
+	tdReportReportDataOffset     = 128 // REPORTMACSTRUCT.REPORTDATA
+	tdReportTeeTcbSvnOffset      = 264 // TEE_TCB_INFO.TEE_TCB_SVN
+	tdReportMrSeamOffset         = 280 // TEE_TCB_INFO.MRSEAM
+	tdReportMrSignerSeamOffset   = 328 // TEE_TCB_INFO.MRSIGNERSEAM
+	tdReportSeamAttributesOffset = 376 // TEE_TCB_INFO.ATTRIBUTES
+	tdReportTdAttributesOffset   = 512 // TDINFO_STRUCT.ATTRIBUTES
+	tdReportXfamOffset           = 520 // TDINFO_STRUCT.XFAM
+	tdReportMrTdOffset           = 528 // TDINFO_STRUCT.MRTD
+	tdReportMrConfigIdOffset     = 576 // TDINFO_STRUCT.MRCONFIGID
+	tdReportMrOwnerOffset        = 624 // TDINFO_STRUCT.MROWNER
+	tdReportMrOwnerConfigOffset  = 672 // TDINFO_STRUCT.MROWNERCONFIG
+	tdReportRtmrsOffset          = 720 // TDINFO_STRUCT.RTMR[4]
+)
+
+// tdReportField is a field of a TDREPORT mapped to a TD quote body.
+type tdReportField struct {
+	offset int
+	size   int
+	get    func(body *tdxpb.TDQuoteBody) []byte
+	set    func(body *tdxpb.TDQuoteBody, value []byte)
+}
+
+var tdReportFields = []tdReportField{
+	{tdReportReportDataOffset, ReportDataSize, (*tdxpb.TDQuoteBody).GetReportData, func(b *tdxpb.TDQuoteBody, v []byte) { b.ReportData = v }},
+	{tdReportTeeTcbSvnOffset, 16, (*tdxpb.TDQuoteBody).GetTeeTcbSvn, func(b *tdxpb.TDQuoteBody, v []byte) { b.TeeTcbSvn = v }},
+	{tdReportMrSeamOffset, 48, (*tdxpb.TDQuoteBody).GetMrSeam, func(b *tdxpb.TDQuoteBody, v []byte) { b.MrSeam = v }},
+	{tdReportMrSignerSeamOffset, 48, (*tdxpb.TDQuoteBody).GetMrSignerSeam, func(b *tdxpb.TDQuoteBody, v []byte) { b.MrSignerSeam = v }},
+	{tdReportSeamAttributesOffset, 8, (*tdxpb.TDQuoteBody).GetSeamAttributes, func(b *tdxpb.TDQuoteBody, v []byte) { b.SeamAttributes = v }},
+	{tdReportTdAttributesOffset, 8, (*tdxpb.TDQuoteBody).GetTdAttributes, func(b *tdxpb.TDQuoteBody, v []byte) { b.TdAttributes = v }},
+	{tdReportXfamOffset, 8, (*tdxpb.TDQuoteBody).GetXfam, func(b *tdxpb.TDQuoteBody, v []byte) { b.Xfam = v }},
+	{tdReportMrTdOffset, 48, (*tdxpb.TDQuoteBody).GetMrTd, func(b *tdxpb.TDQuoteBody, v []byte) { b.MrTd = v }},
+	{tdReportMrConfigIdOffset, 48, (*tdxpb.TDQuoteBody).GetMrConfigId, func(b *tdxpb.TDQuoteBody, v []byte) { b.MrConfigId = v }},
+	{tdReportMrOwnerOffset, 48, (*tdxpb.TDQuoteBody).GetMrOwner, func(b *tdxpb.TDQuoteBody, v []byte) { b.MrOwner = v }},
+	{tdReportMrOwnerConfigOffset, 48, (*tdxpb.TDQuoteBody).GetMrOwnerConfig, func(b *tdxpb.TDQuoteBody, v []byte) { b.MrOwnerConfig = v }},
+}
+
+// BuildTdReport lays out the fields of a TD quote body as a TDREPORT. The MAC and the hashes are left zero.
+func BuildTdReport(body *tdxpb.TDQuoteBody) ([]byte, error) {
+	report := make([]byte, TdReportSize)
+	for _, field := range tdReportFields {
+		value := field.get(body)
+		if len(value) != field.size {
+			return nil, fmt.Errorf("invalid TD quote body: field at TDREPORT offset %d is %d bytes, want %d", field.offset, len(value), field.size)
+		}
+		copy(report[field.offset:], value)
+	}
+	if len(body.GetRtmrs()) != 4 {
+		return nil, fmt.Errorf("invalid TD quote body: %d RTMRs, want 4", len(body.GetRtmrs()))
+	}
+	for i, rtmr := range body.GetRtmrs() {
+		if len(rtmr) != RtmrSize {
+			return nil, fmt.Errorf("invalid TD quote body: RTMR[%d] is %d bytes, want %d", i, len(rtmr), RtmrSize)
+		}
+		copy(report[tdReportRtmrsOffset+i*RtmrSize:], rtmr)
+	}
+	return report, nil
+}
+
+// ParseTdReport extracts the fields of a TDREPORT that make up a TD quote body.
+func ParseTdReport(report []byte) (*tdxpb.TDQuoteBody, error) {
+	if len(report) != TdReportSize {
+		return nil, fmt.Errorf("invalid TDREPORT: got %d bytes, want %d", len(report), TdReportSize)
+	}
+
+	body := &tdxpb.TDQuoteBody{}
+	for _, field := range tdReportFields {
+		field.set(body, append([]byte{}, report[field.offset:field.offset+field.size]...))
+	}
+	for i := 0; i < 4; i++ {
+		offset := tdReportRtmrsOffset + i*RtmrSize
+		body.Rtmrs = append(body.Rtmrs, append([]byte{}, report[offset:offset+RtmrSize]...))
+	}
+	return body, nil
+}
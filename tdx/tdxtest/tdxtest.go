@@ -0,0 +1,14 @@
+// Package tdxtest provides utilities for testing code built on the TDX clients.
+package tdxtest
+
+import "testing"
+
+// ClearEnv unsets the environment variables read by the mock TDX clients for the duration of the test, so that the
+// RTMRs measure no source of the host and report data is bound as on TDX 1.5, whatever the shell of the developer.
+// Tests measuring a source set its variable after calling ClearEnv.
+func ClearEnv(t testing.TB) {
+	t.Helper()
+	for _, name := range []string{"TDX_VERSION", "RTMR_CONFIG_PATH", "CCEL_DATA_PATH", "IMA_LOG_PATH"} {
+		t.Setenv(name, "")
+	}
+}
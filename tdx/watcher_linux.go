@@ -0,0 +1,65 @@
+//go:build linux
+
+package tdx
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+const inotifyMask = unix.IN_MODIFY | unix.IN_CLOSE_WRITE | unix.IN_CREATE | unix.IN_DELETE | unix.IN_MOVED_TO |
+	unix.IN_MOVE_SELF | unix.IN_DELETE_SELF | unix.IN_ATTRIB
+
+// fileWatcher reports changes of files and directories with inotify.
+type fileWatcher struct {
+	file    *os.File      // inotify instance
+	changes chan struct{} // Coalesced change notifications
+}
+
+func newFileWatcher(paths []string) (*fileWatcher, error) {
+	// A non-blocking descriptor is handled by the runtime poller, so that Close interrupts pending reads
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize inotify: %w", err)
+	}
+	for _, path := range paths {
+		if _, err := unix.InotifyAddWatch(fd, path, inotifyMask); err != nil {
+			unix.Close(fd)
+			return nil, fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+	}
+
+	w := &fileWatcher{
+		file:    os.NewFile(uintptr(fd), "inotify"),
+		changes: make(chan struct{}, 1),
+	}
+	go w.run()
+	return w, nil
+}
+
+func (w *fileWatcher) run() {
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		if _, err := w.file.Read(buf); err != nil {
+			close(w.changes)
+			return
+		}
+
+		// The events are not inspected: any change triggers a full update of the sources
+		select {
+		case w.changes <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Changes returns a channel receiving a value after the watched files changed. It is closed when the watcher is.
+func (w *fileWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *fileWatcher) Close() error {
+	return w.file.Close()
+}
@@ -0,0 +1,20 @@
+//go:build !linux
+
+package tdx
+
+import "errors"
+
+// fileWatcher is not supported outside Linux; the background updater falls back to periodic updates.
+type fileWatcher struct{}
+
+func newFileWatcher(paths []string) (*fileWatcher, error) {
+	return nil, errors.New("file watching is only supported on Linux")
+}
+
+func (w *fileWatcher) Changes() <-chan struct{} {
+	return nil
+}
+
+func (w *fileWatcher) Close() error {
+	return nil
+}
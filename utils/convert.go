@@ -1,6 +1,10 @@
 package utils
 
 import (
+	"fmt"
+
+	"github.com/google/go-tdx-guest/abi"
+
 	tdxpb "github.com/google/go-tdx-guest/proto/tdx"
 	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
 )
@@ -11,13 +15,16 @@ func ConvertQuoteV4ToQuote(qv4 *tdxpb.QuoteV4) *attestpb.Quote {
 		return nil
 	}
 
-	return &attestpb.Quote{
+	quote := &attestpb.Quote{
 		Header:        convertHeader(qv4.Header),
-		TdQuoteBody:   convertTDQuoteBody(qv4.TdQuoteBody),
 		SignedDataSize: qv4.SignedDataSize,
 		SignedData:    convertSignedData(qv4.SignedData),
 		ExtraBytes:    qv4.ExtraBytes,
 	}
+	if qv4.TdQuoteBody != nil {
+		quote.Body = &attestpb.Quote_TdQuoteBody{TdQuoteBody: convertTDQuoteBody(qv4.TdQuoteBody)}
+	}
+	return quote
 }
 
 func convertHeader(h *tdxpb.Header) *attestpb.Header {
@@ -128,4 +135,162 @@ func convertPckCertificateChainData(pcc *tdxpb.PCKCertificateChainData) *attestp
 		Size:                pcc.Size,
 		PckCertChain:        pcc.PckCertChain,
 	}
-}
\ No newline at end of file
+}
+
+// ConvertQuoteToQuoteV4 converts a Quote object back to a QuoteV4 object. The body of a version 5 quote is left
+// out, as QuoteV4 cannot hold it.
+func ConvertQuoteToQuoteV4(q *attestpb.Quote) *tdxpb.QuoteV4 {
+	if q == nil {
+		return nil
+	}
+
+	return &tdxpb.QuoteV4{
+		Header:         convertHeaderToV4(q.Header),
+		TdQuoteBody:    convertTDQuoteBodyToV4(q.GetTdQuoteBody()),
+		SignedDataSize: q.SignedDataSize,
+		SignedData:     convertSignedDataToV4(q.SignedData),
+		ExtraBytes:     q.ExtraBytes,
+	}
+}
+
+func convertHeaderToV4(h *attestpb.Header) *tdxpb.Header {
+	if h == nil {
+		return nil
+	}
+	return &tdxpb.Header{
+		Version:            h.Version,
+		AttestationKeyType: h.AttestationKeyType,
+		TeeType:            h.TeeType,
+		QeSvn:              h.QeSvn,
+		PceSvn:             h.PceSvn,
+		QeVendorId:         h.QeVendorId,
+		UserData:           h.UserData,
+	}
+}
+
+func convertTDQuoteBodyToV4(tb *attestpb.TDQuoteBody) *tdxpb.TDQuoteBody {
+	if tb == nil {
+		return nil
+	}
+	return &tdxpb.TDQuoteBody{
+		TeeTcbSvn:      tb.TeeTcbSvn,
+		MrSeam:         tb.MrSeam,
+		MrSignerSeam:   tb.MrSignerSeam,
+		SeamAttributes: tb.SeamAttributes,
+		TdAttributes:   tb.TdAttributes,
+		Xfam:           tb.Xfam,
+		MrTd:           tb.MrTd,
+		MrConfigId:     tb.MrConfigId,
+		MrOwner:        tb.MrOwner,
+		MrOwnerConfig:  tb.MrOwnerConfig,
+		Rtmrs:          tb.Rtmrs,
+		ReportData:     tb.ReportData,
+	}
+}
+
+func convertSignedDataToV4(sd *attestpb.Ecdsa256BitQuoteV4AuthData) *tdxpb.Ecdsa256BitQuoteV4AuthData {
+	if sd == nil {
+		return nil
+	}
+	return &tdxpb.Ecdsa256BitQuoteV4AuthData{
+		Signature:           sd.Signature,
+		EcdsaAttestationKey: sd.EcdsaAttestationKey,
+		CertificationData:   convertCertificationDataToV4(sd.CertificationData),
+	}
+}
+
+func convertCertificationDataToV4(cd *attestpb.CertificationData) *tdxpb.CertificationData {
+	if cd == nil {
+		return nil
+	}
+	return &tdxpb.CertificationData{
+		CertificateDataType:       cd.CertificateDataType,
+		Size:                      cd.Size,
+		QeReportCertificationData: convertQEReportCertificationDataToV4(cd.QeReportCertificationData),
+	}
+}
+
+func convertQEReportCertificationDataToV4(qe *attestpb.QEReportCertificationData) *tdxpb.QEReportCertificationData {
+	if qe == nil {
+		return nil
+	}
+	return &tdxpb.QEReportCertificationData{
+		QeReport:                convertEnclaveReportToV4(qe.QeReport),
+		QeReportSignature:       qe.QeReportSignature,
+		QeAuthData:              convertQeAuthDataToV4(qe.QeAuthData),
+		PckCertificateChainData: convertPckCertificateChainDataToV4(qe.PckCertificateChainData),
+	}
+}
+
+func convertEnclaveReportToV4(er *attestpb.EnclaveReport) *tdxpb.EnclaveReport {
+	if er == nil {
+		return nil
+	}
+	return &tdxpb.EnclaveReport{
+		CpuSvn:     er.CpuSvn,
+		MiscSelect: er.MiscSelect,
+		Reserved1:  er.Reserved1,
+		Attributes: er.Attributes,
+		MrEnclave:  er.MrEnclave,
+		Reserved2:  er.Reserved2,
+		MrSigner:   er.MrSigner,
+		Reserved3:  er.Reserved3,
+		IsvProdId:  er.IsvProdId,
+		IsvSvn:     er.IsvSvn,
+		Reserved4:  er.Reserved4,
+		ReportData: er.ReportData,
+	}
+}
+
+func convertQeAuthDataToV4(qa *attestpb.QeAuthData) *tdxpb.QeAuthData {
+	if qa == nil {
+		return nil
+	}
+	return &tdxpb.QeAuthData{
+		ParsedDataSize: qa.ParsedDataSize,
+		Data:           qa.Data,
+	}
+}
+
+func convertPckCertificateChainDataToV4(pcc *attestpb.PCKCertificateChainData) *tdxpb.PCKCertificateChainData {
+	if pcc == nil {
+		return nil
+	}
+	return &tdxpb.PCKCertificateChainData{
+		CertificateDataType: pcc.CertificateDataType,
+		Size:                pcc.Size,
+		PckCertChain:        pcc.PckCertChain,
+	}
+}
+
+// ConvertQuoteToRawQuote serializes a version 4 or 5 Quote object to the wire format of Intel DCAP.
+func ConvertQuoteToRawQuote(q *attestpb.Quote) ([]byte, error) {
+	if q == nil {
+		return nil, fmt.Errorf("quote is nil")
+	}
+	if q.GetHeader().GetVersion() == QuoteVersion5 {
+		return convertQuoteV5ToRawQuote(q)
+	}
+	return abi.QuoteToAbiBytes(ConvertQuoteToQuoteV4(q))
+}
+
+// ConvertRawQuoteToQuote parses a version 4 or 5 quote in the wire format of Intel DCAP into a Quote object.
+func ConvertRawQuoteToQuote(raw []byte) (*attestpb.Quote, error) {
+	version, err := rawQuoteVersion(raw)
+	if err != nil {
+		return nil, err
+	}
+	if version == QuoteVersion5 {
+		return convertRawQuoteV5ToQuote(raw)
+	}
+
+	quote, err := abi.QuoteToProto(raw)
+	if err != nil {
+		return nil, err
+	}
+	quoteV4, ok := quote.(*tdxpb.QuoteV4)
+	if !ok {
+		return nil, fmt.Errorf("unexpected quote type: %T", quote)
+	}
+	return ConvertQuoteV4ToQuote(quoteV4), nil
+}
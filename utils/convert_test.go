@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/google/go-tdx-guest/testing/testdata"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRawQuoteRoundTrip(t *testing.T) {
+	cosQuote, err := os.ReadFile("../tdx/testdata/ccel/cos-113-tdx-quote.dat")
+	if err != nil {
+		t.Fatalf("failed to read sample quote: %v", err)
+	}
+
+	for name, raw := range map[string][]byte{
+		"tdx_prod_quote_SPR_E4": testdata.RawQuote,
+		"cos-113-tdx-quote":     cosQuote,
+	} {
+		t.Run(name, func(t *testing.T) {
+			quote, err := ConvertRawQuoteToQuote(raw)
+			if err != nil {
+				t.Fatalf("ConvertRawQuoteToQuote failed: %v", err)
+			}
+
+			// Bytes -> protobuf -> bytes
+			serialized, err := ConvertQuoteToRawQuote(quote)
+			if err != nil {
+				t.Fatalf("ConvertQuoteToRawQuote failed: %v", err)
+			}
+			if !bytes.Equal(serialized, raw) {
+				t.Fatalf("serialized quote differs from the sample (%d bytes, want %d)", len(serialized), len(raw))
+			}
+
+			// Protobuf -> bytes -> protobuf, including the wire encoding of the message
+			parsed, err := ConvertRawQuoteToQuote(serialized)
+			if err != nil {
+				t.Fatalf("ConvertRawQuoteToQuote failed: %v", err)
+			}
+			if !proto.Equal(parsed, quote) {
+				t.Errorf("round-tripped quote differs")
+			}
+			want, _ := proto.MarshalOptions{Deterministic: true}.Marshal(quote)
+			got, _ := proto.MarshalOptions{Deterministic: true}.Marshal(parsed)
+			if !bytes.Equal(got, want) {
+				t.Errorf("round-tripped quote encodes differently")
+			}
+		})
+	}
+}
+
+func TestConvertRawQuoteInvalid(t *testing.T) {
+	if _, err := ConvertRawQuoteToQuote(testdata.RawQuote[:100]); err == nil {
+		t.Errorf("ConvertRawQuoteToQuote succeeded on a truncated quote")
+	}
+	if _, err := ConvertQuoteToRawQuote(nil); err == nil {
+		t.Errorf("ConvertQuoteToRawQuote succeeded on a nil quote")
+	}
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/go-tdx-guest/abi"
+
+	tdxpb "github.com/google/go-tdx-guest/proto/tdx"
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+const (
+	QuoteVersion5 = 5 // Version of the quote layout with a body descriptor, introduced with TDX 1.5
+
+	BodyTypeTdx10 = 2 // Body descriptor type of a TDX 1.0 TD report
+	BodyTypeTdx15 = 3 // Body descriptor type of a TDX 1.5 TD report
+
+	quoteHeaderSize    = 48  // Size of the quote header
+	tdQuoteBodySize    = 584 // Size of a TDX 1.0 TD report
+	tdQuoteBodyV15Size = 648 // Size of a TDX 1.5 TD report
+	bodyDescriptorSize = 6   // Size of the type and size of a version 5 body
+	teeTcbSvn2Size     = 16  // Size of TEE_TCB_SVN_2
+	mrServicetdSize    = 48  // Size of MRSERVICETD
+)
+
+// GetTDQuoteBody returns the TD report fields shared by version 4 and version 5 quotes.
+func GetTDQuoteBody(q *attestpb.Quote) *attestpb.TDQuoteBody {
+	if body := q.GetTdQuoteBody(); body != nil {
+		return body
+	}
+	return q.GetTdQuoteBodyV5().GetTdQuoteBody()
+}
+
+// rawQuoteVersion reads the version from the header of a raw quote.
+func rawQuoteVersion(raw []byte) (uint16, error) {
+	if len(raw) < quoteHeaderSize {
+		return 0, fmt.Errorf("quote is %d bytes, shorter than its header", len(raw))
+	}
+	return binary.LittleEndian.Uint16(raw[0:2]), nil
+}
+
+// convertRawQuoteV5ToQuote parses a version 5 quote. The signature data follows the same layout as in version 4
+// quotes, so it is parsed by go-tdx-guest from an equivalent version 4 quote.
+func convertRawQuoteV5ToQuote(raw []byte) (*attestpb.Quote, error) {
+	if len(raw) < quoteHeaderSize+bodyDescriptorSize {
+		return nil, fmt.Errorf("quote is %d bytes, shorter than its body descriptor", len(raw))
+	}
+	bodyType := binary.LittleEndian.Uint16(raw[quoteHeaderSize:])
+	bodySize := binary.LittleEndian.Uint32(raw[quoteHeaderSize+2:])
+	switch {
+	case bodyType == BodyTypeTdx10 && bodySize == tdQuoteBodySize:
+	case bodyType == BodyTypeTdx15 && bodySize == tdQuoteBodyV15Size:
+	default:
+		return nil, fmt.Errorf("unsupported quote body type %d of %d bytes", bodyType, bodySize)
+	}
+	bodyStart := quoteHeaderSize + bodyDescriptorSize
+	bodyEnd := bodyStart + int(bodySize)
+	if len(raw) < bodyEnd {
+		return nil, fmt.Errorf("quote is %d bytes, shorter than its body", len(raw))
+	}
+
+	// Parse the header, the TDX 1.0 fields and the signature data as a version 4 quote
+	var v4 bytes.Buffer
+	v4.Write(raw[:quoteHeaderSize])
+	v4.Write(raw[bodyStart : bodyStart+tdQuoteBodySize])
+	v4.Write(raw[bodyEnd:])
+	v4Raw := v4.Bytes()
+	binary.LittleEndian.PutUint16(v4Raw[0:2], QuoteVersion4)
+
+	quote, err := abi.QuoteToProto(v4Raw)
+	if err != nil {
+		return nil, err
+	}
+	quoteV4, ok := quote.(*tdxpb.QuoteV4)
+	if !ok {
+		return nil, fmt.Errorf("unexpected quote type: %T", quote)
+	}
+
+	converted := ConvertQuoteV4ToQuote(quoteV4)
+	converted.Header.Version = QuoteVersion5
+	bodyV5 := &attestpb.TDQuoteBodyV5{
+		BodyType:    uint32(bodyType),
+		TdQuoteBody: converted.GetTdQuoteBody(),
+	}
+	if bodyType == BodyTypeTdx15 {
+		extension := raw[bodyStart+tdQuoteBodySize : bodyEnd]
+		bodyV5.TeeTcbSvn2 = bytes.Clone(extension[:teeTcbSvn2Size])
+		bodyV5.MrServicetd = bytes.Clone(extension[teeTcbSvn2Size:])
+	}
+	converted.Body = &attestpb.Quote_TdQuoteBodyV5{TdQuoteBodyV5: bodyV5}
+	return converted, nil
+}
+
+// convertQuoteV5ToRawQuote serializes a version 5 quote, the reverse of convertRawQuoteV5ToQuote.
+func convertQuoteV5ToRawQuote(q *attestpb.Quote) ([]byte, error) {
+	bodyV5 := q.GetTdQuoteBodyV5()
+	if bodyV5 == nil {
+		return nil, fmt.Errorf("version 5 quote without a version 5 body")
+	}
+	bodySize := tdQuoteBodySize
+	switch bodyV5.GetBodyType() {
+	case BodyTypeTdx10:
+	case BodyTypeTdx15:
+		if len(bodyV5.GetTeeTcbSvn2()) != teeTcbSvn2Size || len(bodyV5.GetMrServicetd()) != mrServicetdSize {
+			return nil, fmt.Errorf("invalid TDX 1.5 fields: tee_tcb_svn2 is %d bytes, mr_servicetd is %d bytes",
+				len(bodyV5.GetTeeTcbSvn2()), len(bodyV5.GetMrServicetd()))
+		}
+		bodySize = tdQuoteBodyV15Size
+	default:
+		return nil, fmt.Errorf("unsupported quote body type %d", bodyV5.GetBodyType())
+	}
+
+	// Serialize the header, the TDX 1.0 fields and the signature data as a version 4 quote
+	v4Raw, err := abi.QuoteToAbiBytes(ConvertQuoteV5ToQuoteV4(q))
+	if err != nil {
+		return nil, err
+	}
+
+	var raw bytes.Buffer
+	raw.Write(v4Raw[:quoteHeaderSize])
+	binary.Write(&raw, binary.LittleEndian, uint16(bodyV5.GetBodyType()))
+	binary.Write(&raw, binary.LittleEndian, uint32(bodySize))
+	raw.Write(v4Raw[quoteHeaderSize : quoteHeaderSize+tdQuoteBodySize])
+	if bodyV5.GetBodyType() == BodyTypeTdx15 {
+		raw.Write(bodyV5.GetTeeTcbSvn2())
+		raw.Write(bodyV5.GetMrServicetd())
+	}
+	raw.Write(v4Raw[quoteHeaderSize+tdQuoteBodySize:])
+
+	rawBytes := raw.Bytes()
+	binary.LittleEndian.PutUint16(rawBytes[0:2], QuoteVersion5)
+	return rawBytes, nil
+}
+
+// ConvertQuoteV5ToQuoteV4 returns the version 4 quote with the same header, TDX 1.0 fields and signature data as a
+// version 5 quote. Its signature does not cover its own layout, but the rest of the quote can be checked with
+// go-tdx-guest, and its body with the policies written for version 4 quotes.
+func ConvertQuoteV5ToQuoteV4(q *attestpb.Quote) *tdxpb.QuoteV4 {
+	quoteV4 := ConvertQuoteToQuoteV4(q)
+	if quoteV4 == nil {
+		return nil
+	}
+	quoteV4.TdQuoteBody = convertTDQuoteBodyToV4(GetTDQuoteBody(q))
+	if quoteV4.Header != nil {
+		quoteV4.Header.Version = QuoteVersion4
+	}
+	return quoteV4
+}
+
+// SignedQuoteData returns the header and the body of a raw version 4 or 5 quote, which are the bytes signed by the
+// attestation key.
+func SignedQuoteData(raw []byte) ([]byte, error) {
+	version, err := rawQuoteVersion(raw)
+	if err != nil {
+		return nil, err
+	}
+	size := quoteHeaderSize + tdQuoteBodySize
+	if version == QuoteVersion5 {
+		if len(raw) < quoteHeaderSize+bodyDescriptorSize {
+			return nil, fmt.Errorf("quote is %d bytes, shorter than its body descriptor", len(raw))
+		}
+		size = quoteHeaderSize + bodyDescriptorSize + int(binary.LittleEndian.Uint32(raw[quoteHeaderSize+2:]))
+	}
+	if len(raw) < size {
+		return nil, fmt.Errorf("quote is %d bytes, shorter than its body", len(raw))
+	}
+	return raw[:size], nil
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/google/go-tdx-guest/testing/testdata"
+)
+
+// rawQuoteV5 rewrites the sample version 4 quote into the version 5 layout with the given body type.
+func rawQuoteV5(bodyType uint16, teeTcbSvn2, mrServicetd []byte) []byte {
+	bodySize := uint32(tdQuoteBodySize)
+	if bodyType == BodyTypeTdx15 {
+		bodySize = tdQuoteBodyV15Size
+	}
+
+	var raw bytes.Buffer
+	raw.Write(testdata.RawQuote[:quoteHeaderSize])
+	binary.Write(&raw, binary.LittleEndian, bodyType)
+	binary.Write(&raw, binary.LittleEndian, bodySize)
+	raw.Write(testdata.RawQuote[quoteHeaderSize : quoteHeaderSize+tdQuoteBodySize])
+	raw.Write(teeTcbSvn2)
+	raw.Write(mrServicetd)
+	raw.Write(testdata.RawQuote[quoteHeaderSize+tdQuoteBodySize:])
+
+	rawBytes := raw.Bytes()
+	binary.LittleEndian.PutUint16(rawBytes[0:2], QuoteVersion5)
+	return rawBytes
+}
+
+func TestRawQuoteV5RoundTrip(t *testing.T) {
+	teeTcbSvn2 := bytes.Repeat([]byte{0x02}, teeTcbSvn2Size)
+	mrServicetd := bytes.Repeat([]byte{0x5e}, mrServicetdSize)
+
+	for name, raw := range map[string][]byte{
+		"tdx_1_0_body": rawQuoteV5(BodyTypeTdx10, nil, nil),
+		"tdx_1_5_body": rawQuoteV5(BodyTypeTdx15, teeTcbSvn2, mrServicetd),
+	} {
+		t.Run(name, func(t *testing.T) {
+			quote, err := ConvertRawQuoteToQuote(raw)
+			if err != nil {
+				t.Fatalf("ConvertRawQuoteToQuote failed: %v", err)
+			}
+			if quote.GetHeader().GetVersion() != QuoteVersion5 {
+				t.Errorf("version = %d, want %d", quote.GetHeader().GetVersion(), QuoteVersion5)
+			}
+			if quote.GetTdQuoteBody() != nil || quote.GetTdQuoteBodyV5() == nil {
+				t.Fatalf("version 5 quote was not parsed into a version 5 body")
+			}
+			if err := ValidateQuote(quote); err != nil {
+				t.Errorf("ValidateQuote failed: %v", err)
+			}
+			if _, err := ConvertQuoteToQuoteV4Strict(quote); !errors.Is(err, ErrUnsupportedVersion) {
+				t.Errorf("ConvertQuoteToQuoteV4Strict = %v, want ErrUnsupportedVersion", err)
+			}
+
+			serialized, err := ConvertQuoteToRawQuote(quote)
+			if err != nil {
+				t.Fatalf("ConvertQuoteToRawQuote failed: %v", err)
+			}
+			if !bytes.Equal(serialized, raw) {
+				t.Fatalf("serialized quote differs from the sample (%d bytes, want %d)", len(serialized), len(raw))
+			}
+		})
+	}
+
+	quote, err := ConvertRawQuoteToQuote(rawQuoteV5(BodyTypeTdx15, teeTcbSvn2, mrServicetd))
+	if err != nil {
+		t.Fatalf("ConvertRawQuoteToQuote failed: %v", err)
+	}
+	bodyV5 := quote.GetTdQuoteBodyV5()
+	if !bytes.Equal(bodyV5.GetTeeTcbSvn2(), teeTcbSvn2) {
+		t.Errorf("tee_tcb_svn2 = %x, want %x", bodyV5.GetTeeTcbSvn2(), teeTcbSvn2)
+	}
+	if !bytes.Equal(bodyV5.GetMrServicetd(), mrServicetd) {
+		t.Errorf("mr_servicetd = %x, want %x", bodyV5.GetMrServicetd(), mrServicetd)
+	}
+
+	v4, err := ConvertRawQuoteToQuote(testdata.RawQuote)
+	if err != nil {
+		t.Fatalf("ConvertRawQuoteToQuote failed: %v", err)
+	}
+	if !bytes.Equal(GetTDQuoteBody(quote).GetMrTd(), GetTDQuoteBody(v4).GetMrTd()) {
+		t.Errorf("MRTD of the version 5 quote differs from the version 4 sample")
+	}
+}
+
+func TestValidateQuoteV5(t *testing.T) {
+	quote, err := ConvertRawQuoteToQuote(rawQuoteV5(BodyTypeTdx15, make([]byte, teeTcbSvn2Size), make([]byte, mrServicetdSize)))
+	if err != nil {
+		t.Fatalf("ConvertRawQuoteToQuote failed: %v", err)
+	}
+	quote.GetTdQuoteBodyV5().MrServicetd = quote.GetTdQuoteBodyV5().MrServicetd[:47]
+	quote.GetTdQuoteBodyV5().GetTdQuoteBody().MrTd = nil
+
+	err = ValidateQuote(quote)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ValidateQuote = %v, want a ValidationError", err)
+	}
+	fields := map[string]bool{}
+	for _, field := range validationErr.Fields {
+		fields[field.Field] = true
+	}
+	for _, want := range []string{"td_quote_body_v5.mr_servicetd", "td_quote_body_v5.td_quote_body.mr_td"} {
+		if !fields[want] {
+			t.Errorf("ValidateQuote did not report %s: %v", want, err)
+		}
+	}
+
+	quote.GetTdQuoteBodyV5().BodyType = 7
+	if err := ValidateQuote(quote); !errors.Is(err, ErrInvalidFieldValue) {
+		t.Errorf("ValidateQuote with body type 7 = %v, want ErrInvalidFieldValue", err)
+	}
+}
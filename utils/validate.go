@@ -0,0 +1,260 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tdxpb "github.com/google/go-tdx-guest/proto/tdx"
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+const (
+	QuoteVersion4      = 4          // Version of the TDX 1.0 quote layout
+	AttestationKeyType = 2          // ECDSA-256-with-P-256 curve
+	TeeTypeTdx         = 0x00000081 // TEE type of TDX quotes
+
+	qeReportCertificationDataType = 6 // Certification data type of QE report certification data
+	pckCertChainDataType          = 5 // Certification data type of a PEM PCK certificate chain
+	rtmrCount                     = 4 // Number of RTMRs in a TD quote body
+)
+
+var (
+	// ErrInvalidFieldLength is wrapped by a FieldError for a field of the wrong length.
+	ErrInvalidFieldLength = errors.New("invalid field length")
+	// ErrMissingField is wrapped by a FieldError for a mandatory substructure that is nil.
+	ErrMissingField = errors.New("missing field")
+	// ErrInvalidFieldValue is wrapped by a FieldError for a field whose value is out of range or inconsistent.
+	ErrInvalidFieldValue = errors.New("invalid field value")
+	// ErrUnsupportedVersion is wrapped by a FieldError for a quote version that is not supported.
+	ErrUnsupportedVersion = errors.New("unsupported quote version")
+	// ErrUnsupportedTeeType is wrapped by a FieldError for a TEE type other than TDX.
+	ErrUnsupportedTeeType = errors.New("unsupported TEE type")
+)
+
+// FieldError describes a single invalid field of a quote.
+type FieldError struct {
+	Field string // Path of the field, such as td_quote_body.mr_td
+	Err   error  // One of the ErrX sentinels
+	Got   int    // Length or value found, if relevant
+	Want  int    // Length or value expected, if relevant
+}
+
+func (e *FieldError) Error() string {
+	switch e.Err {
+	case ErrInvalidFieldLength:
+		return fmt.Sprintf("%s: %v: got %d bytes, want %d", e.Field, e.Err, e.Got, e.Want)
+	case ErrUnsupportedVersion, ErrUnsupportedTeeType, ErrInvalidFieldValue:
+		return fmt.Sprintf("%s: %v: got %#x, want %#x", e.Field, e.Err, e.Got, e.Want)
+	}
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError lists every invalid field of a quote. errors.Is matches any of the sentinels of its fields.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+	return "invalid quote: " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, field := range e.Fields {
+		errs[i] = field
+	}
+	return errs
+}
+
+// ConvertQuoteV4ToQuoteStrict converts a QuoteV4 object to a Quote object, failing with a ValidationError if the
+// quote does not follow the TDX quote layout.
+func ConvertQuoteV4ToQuoteStrict(qv4 *tdxpb.QuoteV4) (*attestpb.Quote, error) {
+	quote := ConvertQuoteV4ToQuote(qv4)
+	if err := ValidateQuote(quote); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// ConvertQuoteToQuoteV4Strict converts a Quote object back to a QuoteV4 object, failing with a ValidationError if
+// the quote does not follow the TDX quote layout or is not a version 4 quote.
+func ConvertQuoteToQuoteV4Strict(q *attestpb.Quote) (*tdxpb.QuoteV4, error) {
+	if err := ValidateQuote(q); err != nil {
+		return nil, err
+	}
+	if version := q.GetHeader().GetVersion(); version != QuoteVersion4 {
+		return nil, &ValidationError{Fields: []*FieldError{{Field: "header.version", Err: ErrUnsupportedVersion, Got: int(version), Want: QuoteVersion4}}}
+	}
+	return ConvertQuoteToQuoteV4(q), nil
+}
+
+// ValidateQuote checks the version 4 or 5 layout of a quote: the version, the TEE type, the presence of the mandatory
+// substructures and the length of every fixed size field. It returns a ValidationError listing all invalid fields, or
+// nil.
+func ValidateQuote(q *attestpb.Quote) error {
+	v := &quoteValidator{}
+	if q == nil {
+		v.missing("quote")
+		return v.err()
+	}
+	v.header(q.GetHeader())
+	v.body(q)
+	v.signedData(q.GetSignedData())
+	return v.err()
+}
+
+// quoteValidator accumulates the invalid fields of a quote.
+type quoteValidator struct {
+	fields []*FieldError
+}
+
+func (v *quoteValidator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
+
+func (v *quoteValidator) missing(field string) {
+	v.fields = append(v.fields, &FieldError{Field: field, Err: ErrMissingField})
+}
+
+func (v *quoteValidator) length(field string, value []byte, want int) {
+	if len(value) != want {
+		v.fields = append(v.fields, &FieldError{Field: field, Err: ErrInvalidFieldLength, Got: len(value), Want: want})
+	}
+}
+
+func (v *quoteValidator) value(field string, err error, got, want uint32) {
+	if got != want {
+		v.fields = append(v.fields, &FieldError{Field: field, Err: err, Got: int(got), Want: int(want)})
+	}
+}
+
+func (v *quoteValidator) header(h *attestpb.Header) {
+	if h == nil {
+		v.missing("header")
+		return
+	}
+	if version := h.GetVersion(); version != QuoteVersion4 && version != QuoteVersion5 {
+		v.fields = append(v.fields, &FieldError{Field: "header.version", Err: ErrUnsupportedVersion, Got: int(version), Want: QuoteVersion4})
+	}
+	v.value("header.attestation_key_type", ErrInvalidFieldValue, h.GetAttestationKeyType(), AttestationKeyType)
+	v.value("header.tee_type", ErrUnsupportedTeeType, h.GetTeeType(), TeeTypeTdx)
+	v.length("header.qe_svn", h.GetQeSvn(), 2)
+	v.length("header.pce_svn", h.GetPceSvn(), 2)
+	v.length("header.qe_vendor_id", h.GetQeVendorId(), 16)
+	v.length("header.user_data", h.GetUserData(), 20)
+}
+
+// body checks that the body matches the header version: a TDQuoteBody for version 4, a TDQuoteBodyV5 for version 5.
+func (v *quoteValidator) body(q *attestpb.Quote) {
+	if q.GetHeader().GetVersion() != QuoteVersion5 {
+		v.tdQuoteBody("td_quote_body", q.GetTdQuoteBody())
+		return
+	}
+
+	bodyV5 := q.GetTdQuoteBodyV5()
+	if bodyV5 == nil {
+		v.missing("td_quote_body_v5")
+		return
+	}
+	v.tdQuoteBody("td_quote_body_v5.td_quote_body", bodyV5.GetTdQuoteBody())
+	switch bodyV5.GetBodyType() {
+	case BodyTypeTdx10:
+		v.length("td_quote_body_v5.tee_tcb_svn2", bodyV5.GetTeeTcbSvn2(), 0)
+		v.length("td_quote_body_v5.mr_servicetd", bodyV5.GetMrServicetd(), 0)
+	case BodyTypeTdx15:
+		v.length("td_quote_body_v5.tee_tcb_svn2", bodyV5.GetTeeTcbSvn2(), teeTcbSvn2Size)
+		v.length("td_quote_body_v5.mr_servicetd", bodyV5.GetMrServicetd(), mrServicetdSize)
+	default:
+		v.fields = append(v.fields, &FieldError{Field: "td_quote_body_v5.body_type", Err: ErrInvalidFieldValue, Got: int(bodyV5.GetBodyType()), Want: BodyTypeTdx15})
+	}
+}
+
+func (v *quoteValidator) tdQuoteBody(field string, tb *attestpb.TDQuoteBody) {
+	if tb == nil {
+		v.missing(field)
+		return
+	}
+	v.length(field+".tee_tcb_svn", tb.GetTeeTcbSvn(), 16)
+	v.length(field+".mr_seam", tb.GetMrSeam(), 48)
+	v.length(field+".mr_signer_seam", tb.GetMrSignerSeam(), 48)
+	v.length(field+".seam_attributes", tb.GetSeamAttributes(), 8)
+	v.length(field+".td_attributes", tb.GetTdAttributes(), 8)
+	v.length(field+".xfam", tb.GetXfam(), 8)
+	v.length(field+".mr_td", tb.GetMrTd(), 48)
+	v.length(field+".mr_config_id", tb.GetMrConfigId(), 48)
+	v.length(field+".mr_owner", tb.GetMrOwner(), 48)
+	v.length(field+".mr_owner_config", tb.GetMrOwnerConfig(), 48)
+	if len(tb.GetRtmrs()) != rtmrCount {
+		v.fields = append(v.fields, &FieldError{Field: field + ".rtmrs", Err: ErrInvalidFieldValue, Got: len(tb.GetRtmrs()), Want: rtmrCount})
+	}
+	for i, rtmr := range tb.GetRtmrs() {
+		v.length(fmt.Sprintf("%s.rtmrs[%d]", field, i), rtmr, 48)
+	}
+	v.length(field+".report_data", tb.GetReportData(), 64)
+}
+
+func (v *quoteValidator) signedData(sd *attestpb.Ecdsa256BitQuoteV4AuthData) {
+	if sd == nil {
+		v.missing("signed_data")
+		return
+	}
+	v.length("signed_data.signature", sd.GetSignature(), 64)
+	v.length("signed_data.ecdsa_attestation_key", sd.GetEcdsaAttestationKey(), 64)
+
+	cd := sd.GetCertificationData()
+	if cd == nil {
+		v.missing("signed_data.certification_data")
+		return
+	}
+	v.value("signed_data.certification_data.certificate_data_type", ErrInvalidFieldValue, cd.GetCertificateDataType(), qeReportCertificationDataType)
+
+	qe := cd.GetQeReportCertificationData()
+	if qe == nil {
+		v.missing("signed_data.certification_data.qe_report_certification_data")
+		return
+	}
+	const prefix = "signed_data.certification_data.qe_report_certification_data."
+	v.enclaveReport(prefix+"qe_report", qe.GetQeReport())
+	v.length(prefix+"qe_report_signature", qe.GetQeReportSignature(), 64)
+
+	if authData := qe.GetQeAuthData(); authData == nil {
+		v.missing(prefix + "qe_auth_data")
+	} else {
+		v.length(prefix+"qe_auth_data.data", authData.GetData(), int(authData.GetParsedDataSize()))
+	}
+
+	if chain := qe.GetPckCertificateChainData(); chain == nil {
+		v.missing(prefix + "pck_certificate_chain_data")
+	} else {
+		v.value(prefix+"pck_certificate_chain_data.certificate_data_type", ErrInvalidFieldValue, chain.GetCertificateDataType(), pckCertChainDataType)
+		v.length(prefix+"pck_certificate_chain_data.pck_cert_chain", chain.GetPckCertChain(), int(chain.GetSize()))
+	}
+}
+
+func (v *quoteValidator) enclaveReport(field string, er *attestpb.EnclaveReport) {
+	if er == nil {
+		v.missing(field)
+		return
+	}
+	v.length(field+".cpu_svn", er.GetCpuSvn(), 16)
+	v.length(field+".reserved1", er.GetReserved1(), 28)
+	v.length(field+".attributes", er.GetAttributes(), 16)
+	v.length(field+".mr_enclave", er.GetMrEnclave(), 32)
+	v.length(field+".reserved2", er.GetReserved2(), 32)
+	v.length(field+".mr_signer", er.GetMrSigner(), 32)
+	v.length(field+".reserved3", er.GetReserved3(), 96)
+	v.length(field+".reserved4", er.GetReserved4(), 60)
+	v.length(field+".report_data", er.GetReportData(), 64)
+}
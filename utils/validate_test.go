@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-tdx-guest/testing/testdata"
+)
+
+func TestValidateQuote(t *testing.T) {
+	quote, err := ConvertRawQuoteToQuote(testdata.RawQuote)
+	if err != nil {
+		t.Fatalf("ConvertRawQuoteToQuote failed: %v", err)
+	}
+	if err := ValidateQuote(quote); err != nil {
+		t.Fatalf("ValidateQuote failed on a sample quote: %v", err)
+	}
+	if _, err := ConvertQuoteToQuoteV4Strict(quote); err != nil {
+		t.Fatalf("ConvertQuoteToQuoteV4Strict failed: %v", err)
+	}
+
+	quote.Header.Version = 3
+	quote.Header.TeeType = 0
+	quote.GetTdQuoteBody().MrTd = quote.GetTdQuoteBody().MrTd[:47]
+	quote.GetTdQuoteBody().Rtmrs = quote.GetTdQuoteBody().Rtmrs[:3]
+	quote.SignedData.CertificationData.QeReportCertificationData.QeReport = nil
+
+	err = ValidateQuote(quote)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ValidateQuote error = %v, want a ValidationError", err)
+	}
+	for _, sentinel := range []error{ErrUnsupportedVersion, ErrUnsupportedTeeType, ErrInvalidFieldLength, ErrMissingField} {
+		if !errors.Is(err, sentinel) {
+			t.Errorf("ValidateQuote error does not wrap %v", sentinel)
+		}
+	}
+
+	want := map[string]error{
+		"header.version":      ErrUnsupportedVersion,
+		"header.tee_type":     ErrUnsupportedTeeType,
+		"td_quote_body.mr_td": ErrInvalidFieldLength,
+		"td_quote_body.rtmrs": ErrInvalidFieldValue,
+		"signed_data.certification_data.qe_report_certification_data.qe_report": ErrMissingField,
+	}
+	if len(validationErr.Fields) != len(want) {
+		t.Errorf("got %d invalid fields, want %d: %v", len(validationErr.Fields), len(want), err)
+	}
+	for _, field := range validationErr.Fields {
+		if want[field.Field] != field.Err {
+			t.Errorf("unexpected invalid field %v", field)
+		}
+	}
+	if field := validationErr.Fields[2]; field.Got != 47 || field.Want != 48 {
+		t.Errorf("mr_td error = %v, want 47 of 48 bytes", field)
+	}
+
+	if _, err := ConvertQuoteV4ToQuoteStrict(nil); !errors.Is(err, ErrMissingField) {
+		t.Errorf("ConvertQuoteV4ToQuoteStrict(nil) error = %v, want ErrMissingField", err)
+	}
+}
@@ -0,0 +1,50 @@
+package verifier
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+// ErrNotFresh is returned when a quote is not bound to the expected challenge.
+var ErrNotFresh = errors.New("quote is not bound to the challenge")
+
+// CheckFreshness checks that the REPORTDATA of a quote is SHA-512(challenge || user_data || rtmr_digest) as described
+// by its binding, for the challenge the caller obtained from GetChallenge, and that rtmr_digest is the RTMR[2] of the
+// quote. As the server accepts a challenge once and before it expires, a quote passing the check was generated after
+// the challenge was issued. It returns the user data bound with the challenge. The quote signature must be verified
+// separately.
+func CheckFreshness(quote *attestpb.Quote, binding *attestpb.ReportDataBinding, challenge []byte) ([]byte, error) {
+	if len(challenge) == 0 {
+		return nil, fmt.Errorf("%w: no challenge given", ErrNotFresh)
+	}
+	if binding.GetScheme() != attestpb.ReportDataScheme_REPORT_DATA_SCHEME_SHA512_CHALLENGE_USER_DATA_RTMR {
+		return nil, fmt.Errorf("%w: report data scheme is %v", ErrNotFresh, binding.GetScheme())
+	}
+	if !bytes.Equal(binding.GetChallenge(), challenge) {
+		return nil, fmt.Errorf("%w: challenge mismatch", ErrNotFresh)
+	}
+
+	hasher := sha512.New()
+	hasher.Write(challenge)
+	hasher.Write(binding.GetUserData())
+	hasher.Write(binding.GetRtmrDigest())
+	reportData := hasher.Sum(nil)
+
+	body := utils.GetTDQuoteBody(quote)
+	if !bytes.Equal(body.GetReportData(), reportData) {
+		return nil, fmt.Errorf("%w: REPORTDATA does not match the binding", ErrNotFresh)
+	}
+
+	// The bound digest must be the RTMR the quote attests, not one chosen by the server
+	rtmrs := body.GetRtmrs()
+	if len(rtmrs) <= imaRtmrIndex || !bytes.Equal(binding.GetRtmrDigest(), rtmrs[imaRtmrIndex]) {
+		return nil, fmt.Errorf("%w: RTMR digest does not match RTMR[%d] of the quote", ErrNotFresh, imaRtmrIndex)
+	}
+	return binding.GetUserData(), nil
+}
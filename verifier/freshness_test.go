@@ -0,0 +1,76 @@
+package verifier
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+	"testing"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+func TestCheckFreshness(t *testing.T) {
+	challenge := bytes.Repeat([]byte{0x01}, 32)
+	userData := []byte("nonce")
+	rtmr := bytes.Repeat([]byte{0xab}, 48)
+	reportData := sha512.Sum512(append(append(append([]byte{}, challenge...), userData...), rtmr...))
+
+	quote := sampleQuote(t)
+	quote.GetTdQuoteBody().ReportData = reportData[:]
+	quote.GetTdQuoteBody().Rtmrs[2] = rtmr
+	binding := &attestpb.ReportDataBinding{
+		Scheme:     attestpb.ReportDataScheme_REPORT_DATA_SCHEME_SHA512_CHALLENGE_USER_DATA_RTMR,
+		UserData:   userData,
+		RtmrIndex:  2,
+		RtmrDigest: rtmr,
+		ReportData: reportData[:],
+		Challenge:  challenge,
+	}
+
+	bound, err := CheckFreshness(quote, binding, challenge)
+	if err != nil {
+		t.Fatalf("CheckFreshness failed: %v", err)
+	}
+	if !bytes.Equal(bound, userData) {
+		t.Errorf("bound user data = %q, want %q", bound, userData)
+	}
+
+	otherChallenge := bytes.Repeat([]byte{0x02}, 32)
+	if _, err := CheckFreshness(quote, binding, otherChallenge); !errors.Is(err, ErrNotFresh) {
+		t.Errorf("CheckFreshness with another challenge = %v, want ErrNotFresh", err)
+	}
+
+	// A binding claiming the challenge without REPORTDATA committing to it
+	forged := &attestpb.ReportDataBinding{
+		Scheme:     binding.Scheme,
+		UserData:   userData,
+		RtmrDigest: rtmr,
+		Challenge:  otherChallenge,
+	}
+	if _, err := CheckFreshness(quote, forged, otherChallenge); !errors.Is(err, ErrNotFresh) {
+		t.Errorf("CheckFreshness with a forged binding = %v, want ErrNotFresh", err)
+	}
+
+	// A binding whose RTMR digest is not the RTMR[2] of the quote
+	staleRtmr := bytes.Repeat([]byte{0xcd}, 48)
+	stale := sha512.Sum512(append(append(append([]byte{}, challenge...), userData...), staleRtmr...))
+	staleQuote := sampleQuote(t)
+	staleQuote.GetTdQuoteBody().ReportData = stale[:]
+	staleQuote.GetTdQuoteBody().Rtmrs[2] = rtmr
+	staleBinding := &attestpb.ReportDataBinding{
+		Scheme:     binding.Scheme,
+		UserData:   userData,
+		RtmrIndex:  2,
+		RtmrDigest: staleRtmr,
+		ReportData: stale[:],
+		Challenge:  challenge,
+	}
+	if _, err := CheckFreshness(staleQuote, staleBinding, challenge); !errors.Is(err, ErrNotFresh) {
+		t.Errorf("CheckFreshness with another RTMR digest = %v, want ErrNotFresh", err)
+	}
+
+	legacy := &attestpb.ReportDataBinding{Scheme: attestpb.ReportDataScheme_REPORT_DATA_SCHEME_SHA512_USER_DATA_RTMR}
+	if _, err := CheckFreshness(quote, legacy, challenge); !errors.Is(err, ErrNotFresh) {
+		t.Errorf("CheckFreshness without a challenge scheme = %v, want ErrNotFresh", err)
+	}
+}
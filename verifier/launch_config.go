@@ -0,0 +1,88 @@
+package verifier
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+)
+
+// ErrLaunchConfigNotBound is returned when a launch configuration is not measured in the quote as it claims.
+var ErrLaunchConfigNotBound = errors.New("launch configuration not bound to quote")
+
+// CanonicalLaunchConfig encodes configuration values as a JSON object with sorted keys, no whitespace and no HTML
+// escaping, so that the same values always have the same digest.
+func CanonicalLaunchConfig(values map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(values); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// LaunchConfigDigest returns the SHA-384 digest of a launch configuration document, as compared with MRCONFIGID or
+// extended into an RTMR.
+func LaunchConfigDigest(document []byte) []byte {
+	digest := sha512.Sum384(document)
+	return digest[:]
+}
+
+// CheckLaunchConfig checks that the launch configuration is canonical and measured in the quote, either in
+// MRCONFIGID or in an RTMR that the events replay to. It returns the configuration values.
+func CheckLaunchConfig(quote *attestpb.Quote, config *attestpb.LaunchConfig, events []*attestpb.MeasurementEvent) (map[string]string, error) {
+	body := utils.GetTDQuoteBody(quote)
+	if body == nil {
+		return nil, fmt.Errorf("%w: missing quote body", ErrLaunchConfigNotBound)
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal(config.GetDocument(), &values); err != nil {
+		return nil, fmt.Errorf("%w: invalid document: %v", ErrLaunchConfigNotBound, err)
+	}
+	if canonical, err := CanonicalLaunchConfig(values); err != nil || !bytes.Equal(canonical, config.GetDocument()) {
+		return nil, fmt.Errorf("%w: document is not canonical", ErrLaunchConfigNotBound)
+	}
+	digest := LaunchConfigDigest(config.GetDocument())
+	if len(config.GetDigest()) > 0 && !bytes.Equal(config.GetDigest(), digest) {
+		return nil, fmt.Errorf("%w: digest %x does not match the document", ErrLaunchConfigNotBound, config.GetDigest())
+	}
+
+	switch config.GetBinding() {
+	case attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_MR_CONFIG_ID:
+		if !bytes.Equal(body.GetMrConfigId(), digest) {
+			return nil, fmt.Errorf("%w: MRCONFIGID is %x, want %x", ErrLaunchConfigNotBound, body.GetMrConfigId(), digest)
+		}
+	case attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_RTMR:
+		if err := checkLaunchConfigEvent(body.GetRtmrs(), int(config.GetRtmrIndex()), digest, events); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown binding %v", ErrLaunchConfigNotBound, config.GetBinding())
+	}
+	return values, nil
+}
+
+// checkLaunchConfigEvent checks that the digest was extended into the RTMR, and that the events replay to the RTMR of
+// the quote.
+func checkLaunchConfigEvent(quoteRtmrs [][]byte, index int, digest []byte, events []*attestpb.MeasurementEvent) error {
+	if index >= len(quoteRtmrs) || index >= rtmrCount {
+		return fmt.Errorf("%w: missing RTMR[%d]", ErrLaunchConfigNotBound, index)
+	}
+	if replayed := ReplayEvents(events)[index]; !bytes.Equal(replayed, quoteRtmrs[index]) {
+		return fmt.Errorf("%w: event log replays RTMR[%d] to %s, quote has %s", ErrLaunchConfigNotBound, index,
+			hex.EncodeToString(replayed), hex.EncodeToString(quoteRtmrs[index]))
+	}
+	for _, event := range events {
+		if int(event.GetRtmrIndex()) == index && bytes.Equal(event.GetDigest(), digest) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no event of RTMR[%d] has digest %x", ErrLaunchConfigNotBound, index, digest)
+}
@@ -0,0 +1,65 @@
+package verifier
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+func TestCanonicalLaunchConfig(t *testing.T) {
+	document, err := CanonicalLaunchConfig(map[string]string{"PORT": "50051", "ENV": "TDX", "IMA_LOG_PATH": "<log>&"})
+	if err != nil {
+		t.Fatalf("CanonicalLaunchConfig failed: %v", err)
+	}
+	if want := `{"ENV":"TDX","IMA_LOG_PATH":"<log>&","PORT":"50051"}`; string(document) != want {
+		t.Errorf("CanonicalLaunchConfig = %s, want %s", document, want)
+	}
+}
+
+func TestCheckLaunchConfig(t *testing.T) {
+	document, err := CanonicalLaunchConfig(map[string]string{"ENV": "TDX", "PORT": "50051"})
+	if err != nil {
+		t.Fatalf("CanonicalLaunchConfig failed: %v", err)
+	}
+	digest := LaunchConfigDigest(document)
+	events := []*attestpb.MeasurementEvent{
+		{Sequence: 0, RtmrIndex: 3, Digest: bytes.Repeat([]byte{0x01}, measurementSize)},
+		{Sequence: 1, RtmrIndex: 3, Digest: digest},
+	}
+	quote := sampleQuote(t)
+	quote.GetTdQuoteBody().Rtmrs[3] = ReplayEvents(events)[3]
+	quote.GetTdQuoteBody().MrConfigId = digest
+
+	tests := []struct {
+		name   string
+		config *attestpb.LaunchConfig
+		events []*attestpb.MeasurementEvent
+		ok     bool
+	}{
+		{"MRCONFIGID", &attestpb.LaunchConfig{Document: document, Binding: attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_MR_CONFIG_ID}, nil, true},
+		{"RTMR", &attestpb.LaunchConfig{Document: document, Digest: digest, Binding: attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_RTMR, RtmrIndex: 3}, events, true},
+		{"RTMR without event", &attestpb.LaunchConfig{Document: document, Binding: attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_RTMR, RtmrIndex: 3}, events[:1], false},
+		{"other RTMR", &attestpb.LaunchConfig{Document: document, Binding: attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_RTMR, RtmrIndex: 2}, events, false},
+		{"other document", &attestpb.LaunchConfig{Document: []byte(`{"ENV":"MOCK_TDX","PORT":"50051"}`), Binding: attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_MR_CONFIG_ID}, nil, false},
+		{"non canonical document", &attestpb.LaunchConfig{Document: []byte(`{"PORT":"50051","ENV":"TDX"}`), Binding: attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_MR_CONFIG_ID}, nil, false},
+		{"wrong digest", &attestpb.LaunchConfig{Document: document, Digest: make([]byte, 48), Binding: attestpb.LaunchConfigBinding_LAUNCH_CONFIG_BINDING_MR_CONFIG_ID}, nil, false},
+		{"no binding", &attestpb.LaunchConfig{Document: document}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := CheckLaunchConfig(quote, tt.config, tt.events)
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("CheckLaunchConfig failed: %v", err)
+				}
+				if values["ENV"] != "TDX" {
+					t.Errorf("ENV = %q, want TDX", values["ENV"])
+				}
+			} else if !errors.Is(err, ErrLaunchConfigNotBound) {
+				t.Errorf("CheckLaunchConfig = %v, want ErrLaunchConfigNotBound", err)
+			}
+		})
+	}
+}
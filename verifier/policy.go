@@ -0,0 +1,437 @@
+package verifier
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+)
+
+const (
+	measurementSize = 48 // Size of MRTD, MRSEAM, MRCONFIGID, MROWNER and the RTMRs
+	teeTcbSvnSize   = 16 // Size of TEE_TCB_SVN
+	rtmrCount       = 4  // Number of RTMRs
+	imaRtmrIndex    = 2  // RTMR index whose digest is bound into REPORTDATA
+)
+
+// Bits of TDATTRIBUTES that can be referred to by name in a policy.
+var tdAttributeBits = map[string]uint{
+	"debug":           0,  // The TD runs in debug mode, its state is visible to the host
+	"sept_ve_disable": 28, // EPT violations are not converted to #VE
+	"pks":             30, // Supervisor protection keys are enabled
+	"kl":              31, // Key locker is enabled
+	"perfmon":         63, // Performance monitoring is enabled
+}
+
+// ErrPolicyViolation is returned when a quote does not satisfy a policy.
+var ErrPolicyViolation = errors.New("policy violation")
+
+// HexBytes is a byte string written in hex in a policy file, with an optional 0x prefix.
+type HexBytes []byte
+
+func (h *HexBytes) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return err
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+	if err != nil {
+		return fmt.Errorf("line %d: invalid hex value %q: %w", value.Line, s, err)
+	}
+	*h = b
+	return nil
+}
+
+func (h HexBytes) MarshalYAML() (interface{}, error) {
+	return hex.EncodeToString(h), nil
+}
+
+// Policy describes the TD identities accepted by a relying party. Every field is optional; a list of values accepts
+// any of them.
+//
+//	mr_td: [<hex>]
+//	mr_seam: [<hex>]
+//	mr_config_id: [<hex>]
+//	mr_owner: [<hex>]
+//	rtmrs:
+//	  - index: 0
+//	    values: [<hex>]
+//	event_log:
+//	  - rtmr_index: 2
+//	    allowed_event_types: [ima-ng]
+//	    allowed_digests: [<hex>]
+//	td_attributes:
+//	  required: [sept_ve_disable]
+//	  forbidden: [debug]
+//	min_tee_tcb_svn: <hex>
+type Policy struct {
+	MrTd         []HexBytes          `yaml:"mr_td,omitempty"`           // Accepted MRTD values
+	MrSeam       []HexBytes          `yaml:"mr_seam,omitempty"`         // Accepted MRSEAM values
+	MrConfigId   []HexBytes          `yaml:"mr_config_id,omitempty"`    // Accepted MRCONFIGID values
+	MrOwner      []HexBytes          `yaml:"mr_owner,omitempty"`        // Accepted MROWNER values
+	Rtmrs        []RtmrPolicy        `yaml:"rtmrs,omitempty"`           // Accepted RTMR values
+	EventLog     []EventLogPolicy    `yaml:"event_log,omitempty"`       // Allowlists of the events replayed into RTMRs
+	TdAttributes *TdAttributesPolicy `yaml:"td_attributes,omitempty"`   // Required and forbidden TDATTRIBUTES bits
+	MinTeeTcbSvn HexBytes            `yaml:"min_tee_tcb_svn,omitempty"` // Minimum of every TEE_TCB_SVN component
+}
+
+// RtmrPolicy lists the accepted values of an RTMR.
+type RtmrPolicy struct {
+	Index  int        `yaml:"index"`  // RTMR index
+	Values []HexBytes `yaml:"values"` // Accepted values
+}
+
+// EventLogPolicy restricts the events replayed into an RTMR. The event log must replay to the RTMR of the quote, and
+// each of its events must match the allowlists that are not empty.
+type EventLogPolicy struct {
+	RtmrIndex         int        `yaml:"rtmr_index"`                    // RTMR index
+	AllowedEventTypes []string   `yaml:"allowed_event_types,omitempty"` // Accepted event types
+	AllowedDigests    []HexBytes `yaml:"allowed_digests,omitempty"`     // Accepted event digests
+}
+
+// TdAttributesPolicy lists TDATTRIBUTES bits by name or bit number.
+type TdAttributesPolicy struct {
+	Required  []string `yaml:"required,omitempty"`  // Bits that must be set
+	Forbidden []string `yaml:"forbidden,omitempty"` // Bits that must be clear
+}
+
+// Violation describes a quote field that does not satisfy the policy.
+type Violation struct {
+	Field    string // Quote field, such as mr_td or rtmrs[2]
+	Expected string // Values accepted by the policy
+	Actual   string // Value found in the quote
+	Message  string // Additional detail
+}
+
+func (v Violation) String() string {
+	s := v.Field + ": " + v.Message
+	if v.Expected != "" || v.Actual != "" {
+		s += fmt.Sprintf(" (expected %s, got %s)", v.Expected, v.Actual)
+	}
+	return s
+}
+
+// PolicyError lists the violations of a policy. It wraps ErrPolicyViolation.
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.String()
+	}
+	return fmt.Sprintf("%v: %s", ErrPolicyViolation, strings.Join(messages, "; "))
+}
+
+func (e *PolicyError) Unwrap() error {
+	return ErrPolicyViolation
+}
+
+// LoadPolicy reads and validates a Policy from a YAML file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	policy, err := ParsePolicy(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// Marshal encodes the policy as YAML that ParsePolicy accepts, leaving out the fields that are not set.
+func (p *Policy) Marshal() ([]byte, error) {
+	return yaml.Marshal(p)
+}
+
+// ParsePolicy parses and validates a Policy from YAML.
+func ParsePolicy(data []byte) (*Policy, error) {
+	var policy Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Validate checks the sizes of the policy values, the RTMR indexes and the attribute names.
+func (p *Policy) Validate() error {
+	for field, values := range map[string][]HexBytes{
+		"mr_td":        p.MrTd,
+		"mr_seam":      p.MrSeam,
+		"mr_config_id": p.MrConfigId,
+		"mr_owner":     p.MrOwner,
+	} {
+		if err := validateSizes(field, values, measurementSize); err != nil {
+			return err
+		}
+	}
+	for _, rtmr := range p.Rtmrs {
+		if rtmr.Index < 0 || rtmr.Index >= rtmrCount {
+			return fmt.Errorf("rtmrs: invalid RTMR index %d", rtmr.Index)
+		}
+		if err := validateSizes(fmt.Sprintf("rtmrs[%d]", rtmr.Index), rtmr.Values, measurementSize); err != nil {
+			return err
+		}
+	}
+	for _, eventLog := range p.EventLog {
+		if eventLog.RtmrIndex < 0 || eventLog.RtmrIndex >= rtmrCount {
+			return fmt.Errorf("event_log: invalid RTMR index %d", eventLog.RtmrIndex)
+		}
+	}
+	if p.TdAttributes != nil {
+		for _, name := range append(p.TdAttributes.Required, p.TdAttributes.Forbidden...) {
+			if _, err := tdAttributeBit(name); err != nil {
+				return err
+			}
+		}
+	}
+	if p.MinTeeTcbSvn != nil && len(p.MinTeeTcbSvn) != teeTcbSvnSize {
+		return fmt.Errorf("min_tee_tcb_svn: got %d bytes, want %d", len(p.MinTeeTcbSvn), teeTcbSvnSize)
+	}
+	return nil
+}
+
+// Check evaluates the policy and returns a PolicyError listing every violation, or nil.
+func (p *Policy) Check(quote *attestpb.Quote, events []*attestpb.MeasurementEvent) error {
+	if violations := p.Evaluate(quote, events); len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// Evaluate checks the body of a quote against the policy and returns every violation. The events are only needed by
+// event log policies; they are replayed into the RTMRs and compared with the quote.
+func (p *Policy) Evaluate(quote *attestpb.Quote, events []*attestpb.MeasurementEvent) []Violation {
+	body := utils.GetTDQuoteBody(quote)
+	if body == nil {
+		return []Violation{{Field: "td_quote_body", Message: "missing quote body"}}
+	}
+
+	var violations []Violation
+	checkOneOf := func(field string, accepted []HexBytes, actual []byte) {
+		if len(accepted) == 0 {
+			return
+		}
+		for _, value := range accepted {
+			if bytes.Equal(value, actual) {
+				return
+			}
+		}
+		violations = append(violations, Violation{
+			Field:    field,
+			Expected: formatHexList(accepted),
+			Actual:   hex.EncodeToString(actual),
+			Message:  "value not accepted",
+		})
+	}
+
+	checkOneOf("mr_td", p.MrTd, body.GetMrTd())
+	checkOneOf("mr_seam", p.MrSeam, body.GetMrSeam())
+	checkOneOf("mr_config_id", p.MrConfigId, body.GetMrConfigId())
+	checkOneOf("mr_owner", p.MrOwner, body.GetMrOwner())
+
+	quoteRtmrs := body.GetRtmrs()
+	for _, rtmr := range p.Rtmrs {
+		if rtmr.Index >= len(quoteRtmrs) {
+			violations = append(violations, Violation{Field: fmt.Sprintf("rtmrs[%d]", rtmr.Index), Message: "missing RTMR"})
+			continue
+		}
+		checkOneOf(fmt.Sprintf("rtmrs[%d]", rtmr.Index), rtmr.Values, quoteRtmrs[rtmr.Index])
+	}
+
+	violations = append(violations, p.evaluateEventLog(quoteRtmrs, events)...)
+	violations = append(violations, p.evaluateTdAttributes(body.GetTdAttributes())...)
+	violations = append(violations, p.evaluateTeeTcbSvn(body.GetTeeTcbSvn())...)
+	return violations
+}
+
+// evaluateEventLog replays the events of the RTMRs with an event log policy and checks them against the allowlists.
+func (p *Policy) evaluateEventLog(quoteRtmrs [][]byte, events []*attestpb.MeasurementEvent) []Violation {
+	if len(p.EventLog) == 0 {
+		return nil
+	}
+
+	var violations []Violation
+	replayed := ReplayEvents(events)
+	for _, eventLog := range p.EventLog {
+		field := fmt.Sprintf("rtmrs[%d]", eventLog.RtmrIndex)
+		if eventLog.RtmrIndex >= len(quoteRtmrs) {
+			violations = append(violations, Violation{Field: field, Message: "missing RTMR"})
+			continue
+		}
+		if !bytes.Equal(replayed[eventLog.RtmrIndex], quoteRtmrs[eventLog.RtmrIndex]) {
+			violations = append(violations, Violation{
+				Field:    field,
+				Expected: hex.EncodeToString(replayed[eventLog.RtmrIndex]),
+				Actual:   hex.EncodeToString(quoteRtmrs[eventLog.RtmrIndex]),
+				Message:  "event log does not replay to the RTMR",
+			})
+		}
+
+		for _, event := range events {
+			if int(event.GetRtmrIndex()) != eventLog.RtmrIndex {
+				continue
+			}
+			eventField := fmt.Sprintf("event_log[%d]", event.GetSequence())
+			if len(eventLog.AllowedEventTypes) > 0 && !containsString(eventLog.AllowedEventTypes, event.GetEventType()) {
+				violations = append(violations, Violation{
+					Field:    eventField,
+					Expected: strings.Join(eventLog.AllowedEventTypes, ", "),
+					Actual:   event.GetEventType(),
+					Message:  fmt.Sprintf("event type not allowed in RTMR[%d]", eventLog.RtmrIndex),
+				})
+			}
+			if len(eventLog.AllowedDigests) > 0 && !containsBytes(eventLog.AllowedDigests, event.GetDigest()) {
+				violations = append(violations, Violation{
+					Field:   eventField,
+					Actual:  hex.EncodeToString(event.GetDigest()),
+					Message: fmt.Sprintf("digest not allowed in RTMR[%d]", eventLog.RtmrIndex),
+				})
+			}
+		}
+	}
+	return violations
+}
+
+func (p *Policy) evaluateTdAttributes(tdAttributes []byte) []Violation {
+	if p.TdAttributes == nil {
+		return nil
+	}
+	if len(tdAttributes) != 8 {
+		return []Violation{{Field: "td_attributes", Actual: hex.EncodeToString(tdAttributes), Message: "expected 8 bytes"}}
+	}
+
+	var violations []Violation
+	attributes := binary.LittleEndian.Uint64(tdAttributes)
+	for _, name := range p.TdAttributes.Required {
+		bit, _ := tdAttributeBit(name)
+		if attributes&(1<<bit) == 0 {
+			violations = append(violations, Violation{
+				Field:   "td_attributes",
+				Actual:  hex.EncodeToString(tdAttributes),
+				Message: fmt.Sprintf("required bit %s (%d) is clear", name, bit),
+			})
+		}
+	}
+	for _, name := range p.TdAttributes.Forbidden {
+		bit, _ := tdAttributeBit(name)
+		if attributes&(1<<bit) != 0 {
+			violations = append(violations, Violation{
+				Field:   "td_attributes",
+				Actual:  hex.EncodeToString(tdAttributes),
+				Message: fmt.Sprintf("forbidden bit %s (%d) is set", name, bit),
+			})
+		}
+	}
+	return violations
+}
+
+// evaluateTeeTcbSvn compares TEE_TCB_SVN component by component.
+func (p *Policy) evaluateTeeTcbSvn(teeTcbSvn []byte) []Violation {
+	if p.MinTeeTcbSvn == nil {
+		return nil
+	}
+	if len(teeTcbSvn) != teeTcbSvnSize {
+		return []Violation{{Field: "tee_tcb_svn", Actual: hex.EncodeToString(teeTcbSvn), Message: "expected 16 bytes"}}
+	}
+
+	var violations []Violation
+	for i, min := range p.MinTeeTcbSvn {
+		if teeTcbSvn[i] < min {
+			violations = append(violations, Violation{
+				Field:    fmt.Sprintf("tee_tcb_svn[%d]", i),
+				Expected: fmt.Sprintf(">= %d", min),
+				Actual:   strconv.Itoa(int(teeTcbSvn[i])),
+				Message:  "SVN below minimum",
+			})
+		}
+	}
+	return violations
+}
+
+// ReplayEvents computes the RTMR values produced by extending the event digests in sequence order. As in the TCG event
+// logs, the digest of an event is the SHA-384 digest extended into its RTMR; source digests are metadata and are not
+// replayed.
+func ReplayEvents(events []*attestpb.MeasurementEvent) [rtmrCount][]byte {
+	sorted := append([]*attestpb.MeasurementEvent(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetSequence() < sorted[j].GetSequence() })
+
+	var rtmrs [rtmrCount][]byte
+	for i := range rtmrs {
+		rtmrs[i] = make([]byte, measurementSize)
+	}
+	for _, event := range sorted {
+		index := int(event.GetRtmrIndex())
+		if index >= rtmrCount {
+			continue
+		}
+		extended := sha512.Sum384(append(rtmrs[index], event.GetDigest()...))
+		rtmrs[index] = extended[:]
+	}
+	return rtmrs
+}
+
+// tdAttributeBit resolves a TDATTRIBUTES bit name or number.
+func tdAttributeBit(name string) (uint, error) {
+	if bit, ok := tdAttributeBits[strings.ToLower(name)]; ok {
+		return bit, nil
+	}
+	bit, err := strconv.ParseUint(name, 10, 8)
+	if err != nil || bit > 63 {
+		return 0, fmt.Errorf("td_attributes: unknown bit %q", name)
+	}
+	return uint(bit), nil
+}
+
+func validateSizes(field string, values []HexBytes, size int) error {
+	for _, value := range values {
+		if len(value) != size {
+			return fmt.Errorf("%s: got %d bytes, want %d", field, len(value), size)
+		}
+	}
+	return nil
+}
+
+func formatHexList(values []HexBytes) string {
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		encoded[i] = hex.EncodeToString(value)
+	}
+	return strings.Join(encoded, " or ")
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsBytes(values []HexBytes, value []byte) bool {
+	for _, v := range values {
+		if bytes.Equal(v, value) {
+			return true
+		}
+	}
+	return false
+}
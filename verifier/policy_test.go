@@ -0,0 +1,144 @@
+package verifier
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-tdx-guest/abi"
+	"github.com/google/go-tdx-guest/testing/testdata"
+
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+
+	tdxpb "github.com/google/go-tdx-guest/proto/tdx"
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+func sampleQuote(t *testing.T) *attestpb.Quote {
+	t.Helper()
+	quote, err := abi.QuoteToProto(testdata.RawQuote)
+	if err != nil {
+		t.Fatalf("QuoteToProto failed: %v", err)
+	}
+	return utils.ConvertQuoteV4ToQuote(quote.(*tdxpb.QuoteV4))
+}
+
+func TestPolicyAccepts(t *testing.T) {
+	quote := sampleQuote(t)
+	body := quote.GetTdQuoteBody()
+
+	policy, err := ParsePolicy([]byte(fmt.Sprintf(`
+mr_td: [%x, 0x%x]
+mr_seam: [%x]
+mr_config_id: [%x]
+mr_owner: [%x]
+rtmrs:
+  - index: 1
+    values: [%x]
+td_attributes:
+  forbidden: [perfmon, "62"]
+min_tee_tcb_svn: %x
+`, bytes.Repeat([]byte{1}, 48), body.MrTd, body.MrSeam, body.MrConfigId, body.MrOwner, body.Rtmrs[1],
+		body.TeeTcbSvn)))
+	if err != nil {
+		t.Fatalf("ParsePolicy failed: %v", err)
+	}
+	if err := policy.Check(quote, nil); err != nil {
+		t.Errorf("Check failed: %v", err)
+	}
+}
+
+func TestPolicyViolations(t *testing.T) {
+	quote := sampleQuote(t)
+	body := quote.GetTdQuoteBody()
+	body.TdAttributes = []byte{0x01, 0, 0, 0, 0, 0, 0, 0}
+	minSvn := append([]byte{}, body.TeeTcbSvn...)
+	minSvn[3]++
+
+	policy, err := ParsePolicy([]byte(fmt.Sprintf(`
+mr_td: [%x]
+mr_seam: [%x]
+rtmrs:
+  - index: 3
+    values: [%x]
+td_attributes:
+  required: [sept_ve_disable]
+  forbidden: [debug]
+min_tee_tcb_svn: %x
+`, bytes.Repeat([]byte{1}, 48), body.MrSeam, bytes.Repeat([]byte{3}, 48), minSvn)))
+	if err != nil {
+		t.Fatalf("ParsePolicy failed: %v", err)
+	}
+
+	err = policy.Check(quote, nil)
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) || !errors.Is(err, ErrPolicyViolation) {
+		t.Fatalf("Check error = %v, want a PolicyError", err)
+	}
+
+	var fields []string
+	for _, v := range policyErr.Violations {
+		fields = append(fields, v.Field)
+	}
+	want := []string{"mr_td", "rtmrs[3]", "td_attributes", "td_attributes", "tee_tcb_svn[3]"}
+	if strings.Join(fields, ",") != strings.Join(want, ",") {
+		t.Errorf("violated fields = %v, want %v", fields, want)
+	}
+	if policyErr.Violations[0].Actual != hex.EncodeToString(body.MrTd) {
+		t.Errorf("mr_td violation reports %s, want %x", policyErr.Violations[0].Actual, body.MrTd)
+	}
+}
+
+func TestPolicyEventLog(t *testing.T) {
+	quote := sampleQuote(t)
+	digest := sha512.Sum384([]byte("app"))
+	events := []*attestpb.MeasurementEvent{
+		{Sequence: 0, RtmrIndex: 3, Digest: digest[:], EventType: "app"},
+		{Sequence: 1, RtmrIndex: 3, Digest: bytes.Repeat([]byte{0xff}, 32), EventType: "config_file"},
+		{Sequence: 2, RtmrIndex: 2, Digest: digest[:], EventType: "ima-ng"},
+	}
+	replayed := ReplayEvents(events)
+	quote.GetTdQuoteBody().Rtmrs[3] = replayed[3]
+
+	policy, err := ParsePolicy([]byte(fmt.Sprintf(`
+event_log:
+  - rtmr_index: 3
+    allowed_event_types: [app, config_file]
+    allowed_digests: [%x]
+  - rtmr_index: 2
+`, digest)))
+	if err != nil {
+		t.Fatalf("ParsePolicy failed: %v", err)
+	}
+
+	violations := policy.Evaluate(quote, events)
+	if len(violations) != 2 {
+		t.Fatalf("got violations %v, want 2", violations)
+	}
+	if violations[0].Field != "event_log[1]" || !strings.Contains(violations[0].Message, "digest") {
+		t.Errorf("violation = %v, want a digest violation of event 1", violations[0])
+	}
+	if violations[1].Field != "rtmrs[2]" || !strings.Contains(violations[1].Message, "replay") {
+		t.Errorf("violation = %v, want a replay violation of RTMR[2]", violations[1])
+	}
+}
+
+func TestParsePolicyErrors(t *testing.T) {
+	for _, policy := range []string{
+		"mr_td: [0102]",
+		"mr_td: [zz]",
+		"rtmrs: [{index: 4}]",
+		"event_log: [{rtmr_index: -1}]",
+		"td_attributes: {forbidden: [unknown]}",
+		"min_tee_tcb_svn: 00",
+		"mr_unknown: []",
+	} {
+		if _, err := ParsePolicy([]byte(policy)); err == nil {
+			t.Errorf("ParsePolicy(%q) succeeded", policy)
+		}
+	}
+}
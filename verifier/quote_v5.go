@@ -0,0 +1,263 @@
+package verifier
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/google/go-tdx-guest/abi"
+	"github.com/google/go-tdx-guest/pcs"
+	"github.com/google/go-tdx-guest/verify"
+
+	"github.com/radiusxyz/lightbulb-tdx/collateral"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+
+	tdxpb "github.com/google/go-tdx-guest/proto/tdx"
+)
+
+// verifyRawQuoteV5 verifies a version 5 quote, which go-tdx-guest cannot parse. Its TDX 1.0 fields and signature data
+// are checked by go-tdx-guest as a version 4 quote, which covers the PCK chain and the collateral. That check must only
+// fail on the quote signature, which covers the version 5 header and body: it is checked here, followed by the checks
+// go-tdx-guest skips after a signature failure.
+func (v *Verifier) verifyRawQuoteV5(raw []byte) (*Result, error) {
+	quote, err := utils.ConvertRawQuoteToQuote(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuote, err)
+	}
+	signedData, err := utils.SignedQuoteData(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuote, err)
+	}
+	quoteV4 := utils.ConvertQuoteV5ToQuoteV4(quote)
+	if err := abi.CheckQuoteV4(quoteV4); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuote, err)
+	}
+
+	result := &Result{
+		Version:           utils.QuoteVersion5,
+		Quote:             quoteV4,
+		CollateralChecked: v.opts.Collateral != nil,
+	}
+	pckCert, err := PckLeafCertificate(quoteV4)
+	if err == nil {
+		result.PckCertificate = pckCert
+		result.PckExtensions, _ = pcs.PckCertificateExtensions(pckCert)
+	}
+
+	fail := func(err error) (*Result, error) {
+		result.Err = err
+		return result, fmt.Errorf("%w: %v", ErrVerificationFailed, err)
+	}
+	switch err := verify.TdxQuote(quoteV4, v.verifyOptions()); {
+	case err == nil:
+		return fail(errors.New("signature covers the version 4 layout of a version 5 quote"))
+	case !errors.Is(err, verify.ErrHashVerificationFail):
+		return fail(err)
+	}
+	if err := verifyQuoteV5Signatures(quoteV4, signedData, pckCert); err != nil {
+		return fail(err)
+	}
+	if v.opts.Collateral != nil {
+		if err := v.checkQuoteV5Collateral(quoteV4, result.PckExtensions); err != nil {
+			return fail(err)
+		}
+	}
+
+	result.Verified = true
+	return result, nil
+}
+
+// verifyQuoteV5Signatures checks the quote signature over the version 5 header and body, the QE report signature and
+// the binding of the attestation key to the QE report.
+func verifyQuoteV5Signatures(quote *tdxpb.QuoteV4, signedData []byte, pckCert *x509.Certificate) error {
+	signedQuote := quote.GetSignedData()
+	attestationKey := signedQuote.GetEcdsaAttestationKey()
+	if len(attestationKey) != 64 {
+		return fmt.Errorf("attestation key is %d bytes, want 64", len(attestationKey))
+	}
+	key := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(attestationKey[:32]),
+		Y:     new(big.Int).SetBytes(attestationKey[32:]),
+	}
+	if !verifyRawSignature(key, signedData, signedQuote.GetSignature()) {
+		return verify.ErrHashVerificationFail
+	}
+
+	certificationData := signedQuote.GetCertificationData().GetQeReportCertificationData()
+	pckKey, ok := pckCert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return verify.ErrCertPubKeyType
+	}
+	qeReport, err := abi.EnclaveReportToAbiBytes(certificationData.GetQeReport())
+	if err != nil {
+		return fmt.Errorf("could not serialize QE report: %v", err)
+	}
+	if !verifyRawSignature(pckKey, qeReport, certificationData.GetQeReportSignature()) {
+		return errors.New("QE report signature does not verify with the PCK certificate")
+	}
+
+	keyHash := sha256.Sum256(append(bytes.Clone(attestationKey), certificationData.GetQeAuthData().GetData()...))
+	reportData := certificationData.GetQeReport().GetReportData()
+	if !bytes.Equal(reportData, append(keyHash[:], make([]byte, len(reportData)-len(keyHash))...)) {
+		return verify.ErrSHA56VerificationFail
+	}
+	return nil
+}
+
+// verifyRawSignature checks an ECDSA signature encoded as r || s over the SHA-256 digest of the message.
+func verifyRawSignature(key *ecdsa.PublicKey, message []byte, signature []byte) bool {
+	if len(signature) != 64 {
+		return false
+	}
+	digest := sha256.Sum256(message)
+	return ecdsa.Verify(key, digest[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:]))
+}
+
+// checkQuoteV5Collateral matches the TD quote body and the QE report against the TCB info and the QE identity, as
+// go-tdx-guest does after checking the quote signature. Their signatures and expiration were checked by go-tdx-guest.
+func (v *Verifier) checkQuoteV5Collateral(quote *tdxpb.QuoteV4, extensions *pcs.PckExtensions) error {
+	if extensions == nil {
+		return verify.ErrMissingPckExt
+	}
+	bundle, err := collateral.ForQuote(v.opts.Collateral, quote)
+	if err != nil {
+		return err
+	}
+	var tcbInfo pcs.TdxTcbInfo
+	if err := json.Unmarshal(bundle.TcbInfo, &tcbInfo); err != nil {
+		return fmt.Errorf("could not parse TCB info: %v", err)
+	}
+	var qeIdentity pcs.QeIdentity
+	if err := json.Unmarshal(bundle.QeIdentity, &qeIdentity); err != nil {
+		return fmt.Errorf("could not parse QE identity: %v", err)
+	}
+
+	if err := checkTdQuoteBodyTcb(quote.GetTdQuoteBody(), tcbInfo.TcbInfo, extensions); err != nil {
+		return fmt.Errorf("TDX TCB info reported by Intel PCS failed TCB status check: %v", err)
+	}
+	if err := checkQeReportIdentity(quote.GetSignedData().GetCertificationData().GetQeReportCertificationData().GetQeReport(), qeIdentity.EnclaveIdentity); err != nil {
+		return fmt.Errorf("QE Identity reported by Intel PCS failed TCB status check: %v", err)
+	}
+	return nil
+}
+
+// checkTdQuoteBodyTcb checks the platform and the TDX module against the TCB info, and requires an up to date TCB
+// level.
+func checkTdQuoteBodyTcb(body *tdxpb.TDQuoteBody, tcbInfo pcs.TcbInfo, extensions *pcs.PckExtensions) error {
+	if extensions.FMSPC != tcbInfo.Fmspc || extensions.PCEID != tcbInfo.PceID {
+		return fmt.Errorf("platform %s/%s does not match TCB info %s/%s", extensions.FMSPC, extensions.PCEID, tcbInfo.Fmspc, tcbInfo.PceID)
+	}
+	if !bytes.Equal(tcbInfo.TdxModule.Mrsigner.Bytes, body.GetMrSignerSeam()) {
+		return errors.New("MRSIGNERSEAM does not match the TDX module of the TCB info")
+	}
+	if !maskedEqual(body.GetSeamAttributes(), tcbInfo.TdxModule.AttributesMask.Bytes, tcbInfo.TdxModule.Attributes.Bytes) {
+		return errors.New("SEAMATTRIBUTES do not match the TDX module of the TCB info")
+	}
+
+	teeTcbSvn := body.GetTeeTcbSvn()
+	var level *pcs.TcbLevel
+	for i, candidate := range tcbInfo.TcbLevels {
+		if svnsAtLeast(extensions.TCB.CPUSvnComponents, candidate.Tcb.SgxTcbcomponents, 0) &&
+			extensions.TCB.PCESvn >= candidate.Tcb.Pcesvn &&
+			svnsAtLeast(teeTcbSvn, candidate.Tcb.TdxTcbcomponents, tdxModuleSvnStart(teeTcbSvn)) {
+			level = &tcbInfo.TcbLevels[i]
+			break
+		}
+	}
+	if level == nil {
+		return errors.New("no matching TCB level found")
+	}
+	if teeTcbSvn[1] > 0 {
+		// The TDX module is evaluated against the identity of its major version
+		id := fmt.Sprintf("TDX_%02x", teeTcbSvn[1])
+		level = nil
+		for _, identity := range tcbInfo.TdxModuleIdentities {
+			if identity.ID != id {
+				continue
+			}
+			for i, candidate := range identity.TcbLevels {
+				if uint32(teeTcbSvn[0]) >= candidate.Tcb.Isvsvn {
+					level = &identity.TcbLevels[i]
+					break
+				}
+			}
+		}
+		if level == nil {
+			return fmt.Errorf("no TCB level of TDX module identity %s matches", id)
+		}
+	}
+	if level.TcbStatus != pcs.TcbComponentStatusUpToDate {
+		return fmt.Errorf("TDX TCB Status is not %q, found %q", pcs.TcbComponentStatusUpToDate, level.TcbStatus)
+	}
+	return nil
+}
+
+// checkQeReportIdentity checks the QE report against the QE identity, and requires an up to date TCB level.
+func checkQeReportIdentity(report *tdxpb.EnclaveReport, identity pcs.EnclaveIdentity) error {
+	if len(identity.Miscselect.Bytes) != 4 || len(identity.MiscselectMask.Bytes) != 4 {
+		return errors.New("invalid MISCSELECT in QE identity")
+	}
+	var miscSelect [4]byte
+	binary.LittleEndian.PutUint32(miscSelect[:], report.GetMiscSelect())
+	if !maskedEqual(miscSelect[:], identity.MiscselectMask.Bytes, identity.Miscselect.Bytes) {
+		return errors.New("MISCSELECT does not match the QE identity")
+	}
+	if !maskedEqual(report.GetAttributes(), identity.AttributesMask.Bytes, identity.Attributes.Bytes) {
+		return errors.New("ATTRIBUTES do not match the QE identity")
+	}
+	if !bytes.Equal(report.GetMrSigner(), identity.Mrsigner.Bytes) || report.GetIsvProdId() != uint32(identity.IsvProdID) {
+		return errors.New("MRSIGNER or ISVPRODID does not match the QE identity")
+	}
+	for _, level := range identity.TcbLevels {
+		if level.Tcb.Isvsvn <= report.GetIsvSvn() {
+			if level.TcbStatus != pcs.TcbComponentStatusUpToDate {
+				return fmt.Errorf("QE TCB Status is not %q, found %q", pcs.TcbComponentStatusUpToDate, level.TcbStatus)
+			}
+			return nil
+		}
+	}
+	return errors.New("no matching QE TCB level found")
+}
+
+// tdxModuleSvnStart returns the first TEE_TCB_SVN component compared with the TCB levels: the TDX module SVNs are
+// evaluated against the TDX module identities when the major version is set.
+func tdxModuleSvnStart(teeTcbSvn []byte) int {
+	if len(teeTcbSvn) > 1 && teeTcbSvn[1] > 0 {
+		return 2
+	}
+	return 0
+}
+
+// svnsAtLeast reports whether every SVN from start on is at least the SVN of the matching TCB component.
+func svnsAtLeast(svns []byte, components []pcs.TcbComponent, start int) bool {
+	if len(svns) != len(components) {
+		return false
+	}
+	for i := start; i < len(svns); i++ {
+		if svns[i] < components[i].Svn {
+			return false
+		}
+	}
+	return true
+}
+
+// maskedEqual reports whether the value masked by the mask equals the expected value.
+func maskedEqual(value, mask, expected []byte) bool {
+	if len(value) != len(mask) || len(mask) != len(expected) {
+		return false
+	}
+	for i := range value {
+		if value[i]&mask[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
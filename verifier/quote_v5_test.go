@@ -0,0 +1,54 @@
+package verifier
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-tdx-guest/pcs"
+	"github.com/google/go-tdx-guest/testing/testdata"
+
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+)
+
+// TestQuoteV5Collateral checks the sample quote against the sample collateral of go-tdx-guest, which accepts its QE
+// report but finds no TCB level for its TD quote body.
+func TestQuoteV5Collateral(t *testing.T) {
+	quote := utils.ConvertQuoteToQuoteV4(sampleQuote(t))
+	pckCert, err := PckLeafCertificate(quote)
+	if err != nil {
+		t.Fatalf("PckLeafCertificate failed: %v", err)
+	}
+	extensions, err := pcs.PckCertificateExtensions(pckCert)
+	if err != nil {
+		t.Fatalf("PckCertificateExtensions failed: %v", err)
+	}
+	var tcbInfo pcs.TdxTcbInfo
+	if err := json.Unmarshal(testdata.TcbInfoBody, &tcbInfo); err != nil {
+		t.Fatalf("failed to parse TCB info: %v", err)
+	}
+	var qeIdentity pcs.QeIdentity
+	if err := json.Unmarshal(testdata.QeIdentityBody, &qeIdentity); err != nil {
+		t.Fatalf("failed to parse QE identity: %v", err)
+	}
+
+	qeReport := quote.GetSignedData().GetCertificationData().GetQeReportCertificationData().GetQeReport()
+	if err := checkQeReportIdentity(qeReport, qeIdentity.EnclaveIdentity); err != nil {
+		t.Errorf("checkQeReportIdentity failed: %v", err)
+	}
+	if err := checkTdQuoteBodyTcb(quote.GetTdQuoteBody(), tcbInfo.TcbInfo, extensions); err == nil || !strings.Contains(err.Error(), "no matching TCB level found") {
+		t.Errorf("checkTdQuoteBodyTcb = %v, want no matching TCB level", err)
+	}
+
+	// A TCB info for another platform is refused before its TCB levels are read
+	otherPlatform := tcbInfo.TcbInfo
+	otherPlatform.Fmspc = "000000000000"
+	if err := checkTdQuoteBodyTcb(quote.GetTdQuoteBody(), otherPlatform, extensions); err == nil || !strings.Contains(err.Error(), "does not match TCB info") {
+		t.Errorf("checkTdQuoteBodyTcb with another platform = %v, want a platform mismatch", err)
+	}
+	otherSigner := qeIdentity.EnclaveIdentity
+	otherSigner.IsvProdID++
+	if err := checkQeReportIdentity(qeReport, otherSigner); err == nil {
+		t.Error("checkQeReportIdentity accepted another ISVPRODID")
+	}
+}
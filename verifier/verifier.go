@@ -0,0 +1,248 @@
+package verifier
+
+import (
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/go-tdx-guest/abi"
+	"github.com/google/go-tdx-guest/pcs"
+	"github.com/google/go-tdx-guest/verify"
+	"github.com/google/go-tdx-guest/verify/trust"
+
+	"github.com/radiusxyz/lightbulb-tdx/collateral"
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+
+	tdxpb "github.com/google/go-tdx-guest/proto/tdx"
+	attestpb "github.com/radiusxyz/lightbulb-tdx/proto/attest"
+)
+
+var (
+	// ErrVerificationFailed is returned when the quote signature chain does not verify.
+	ErrVerificationFailed = errors.New("quote verification failed")
+	// ErrInvalidQuote is returned when the quote cannot be parsed.
+	ErrInvalidQuote = errors.New("invalid quote")
+)
+
+// Options configures how quotes are verified.
+type Options struct {
+	TrustedRoots     *x509.CertPool    // Roots trusted for the PCK chain; nil uses the Intel root embedded in go-tdx-guest
+	Now              time.Time         // Time at which certificates are checked; zero uses time.Now()
+	Collateral       trust.HTTPSGetter // Serves TCB info, QE identity and CRLs; nil skips collateral checks
+	CheckRevocations bool              // Checks the PCK chain against the CRLs served by Collateral
+}
+
+// Result is the outcome of verifying a quote.
+type Result struct {
+	Verified          bool               // Whether the signature chain verified
+	Err               error              // Reason of the failure if not verified
+	Version           uint32             // Version of the verified quote layout, 4 or 5
+	Quote             *tdxpb.QuoteV4     // The verified quote; for a version 5 quote, its TDX 1.0 fields as a version 4 quote
+	PckCertificate    *x509.Certificate  // PCK leaf certificate from the quote
+	PckExtensions     *pcs.PckExtensions // SGX extensions of the PCK leaf certificate
+	CollateralChecked bool               // Whether collateral was checked in addition to the signature chain
+}
+
+// Verifier verifies TDX quotes offline.
+type Verifier struct {
+	opts Options
+}
+
+// NewVerifier creates a new Verifier.
+func NewVerifier(opts Options) *Verifier {
+	return &Verifier{opts: opts}
+}
+
+// DefaultVerifier creates a new Verifier trusting the root certificate at TDX_TRUSTED_ROOT_PATH, if set. If a
+// collateral source is configured (see collateral.DefaultConfig), quotes are also checked against its collateral,
+// including revocations.
+func DefaultVerifier() (*Verifier, error) {
+	var opts Options
+	if path := os.Getenv("TDX_TRUSTED_ROOT_PATH"); path != "" {
+		roots, err := LoadTrustedRoots(path)
+		if err != nil {
+			return nil, err
+		}
+		opts.TrustedRoots = roots
+	}
+
+	source, err := collateral.DefaultSource()
+	if err != nil {
+		return nil, err
+	}
+	if source != nil {
+		opts.Collateral = source
+		opts.CheckRevocations = true
+	}
+	return NewVerifier(opts), nil
+}
+
+// Collateral returns the collateral source of the Verifier, or nil if collateral is not checked.
+func (v *Verifier) Collateral() trust.HTTPSGetter {
+	if v == nil {
+		return nil
+	}
+	return v.opts.Collateral
+}
+
+// WithTrustedRoots returns a copy of the Verifier trusting the given roots.
+func (v *Verifier) WithTrustedRoots(roots *x509.CertPool) *Verifier {
+	opts := v.opts
+	opts.TrustedRoots = roots
+	return NewVerifier(opts)
+}
+
+// WithCollateral returns a copy of the Verifier checking quotes against the collateral served by the getter, e.g. a
+// collateral.Bundle received with the quote.
+func (v *Verifier) WithCollateral(getter trust.HTTPSGetter) *Verifier {
+	opts := v.opts
+	opts.Collateral = getter
+	opts.CheckRevocations = true
+	return NewVerifier(opts)
+}
+
+// VerifyQuote verifies a version 4 or 5 Quote object.
+func (v *Verifier) VerifyQuote(quote *attestpb.Quote) (*Result, error) {
+	if quote == nil {
+		return nil, fmt.Errorf("%w: quote is nil", ErrInvalidQuote)
+	}
+	switch version := quote.GetHeader().GetVersion(); version {
+	case utils.QuoteVersion4:
+		return v.VerifyQuoteV4(utils.ConvertQuoteToQuoteV4(quote))
+	case utils.QuoteVersion5:
+		// The signature covers the raw layout of the header and the body
+		raw, err := utils.ConvertQuoteToRawQuote(quote)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuote, err)
+		}
+		return v.verifyRawQuoteV5(raw)
+	default:
+		return nil, fmt.Errorf("%w: unsupported quote version %d", ErrInvalidQuote, version)
+	}
+}
+
+// VerifyRawQuote verifies the raw bytes of a version 4 or 5 quote.
+func (v *Verifier) VerifyRawQuote(raw []byte) (*Result, error) {
+	if len(raw) >= 2 && binary.LittleEndian.Uint16(raw) == utils.QuoteVersion5 {
+		return v.verifyRawQuoteV5(raw)
+	}
+	quote, err := abi.QuoteToProto(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuote, err)
+	}
+	quoteV4, ok := quote.(*tdxpb.QuoteV4)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected quote type: %T", ErrInvalidQuote, quote)
+	}
+	return v.VerifyQuoteV4(quoteV4)
+}
+
+// VerifyQuoteV4 checks the PCK chain, the QE report signature, the attestation key binding and the quote signature.
+//
+// A quote that does not verify yields a Result with Verified set to false and an error wrapping ErrVerificationFailed.
+func (v *Verifier) VerifyQuoteV4(quote *tdxpb.QuoteV4) (*Result, error) {
+	if err := abi.CheckQuoteV4(quote); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuote, err)
+	}
+
+	result := &Result{
+		Version:           utils.QuoteVersion4,
+		Quote:             quote,
+		CollateralChecked: v.opts.Collateral != nil,
+	}
+
+	// Report the PCK certificate even if the chain does not verify
+	pckCert, err := PckLeafCertificate(quote)
+	if err == nil {
+		result.PckCertificate = pckCert
+		result.PckExtensions, _ = pcs.PckCertificateExtensions(pckCert)
+	}
+
+	if err := verify.TdxQuote(quote, v.verifyOptions()); err != nil {
+		result.Err = err
+		return result, fmt.Errorf("%w: %v", ErrVerificationFailed, err)
+	}
+
+	result.Verified = true
+	return result, nil
+}
+
+func (v *Verifier) verifyOptions() *verify.Options {
+	opts := &verify.Options{
+		TrustedRoots: v.opts.TrustedRoots,
+		Now:          v.opts.Now,
+	}
+	if v.opts.Collateral != nil {
+		opts.GetCollateral = true
+		opts.CheckRevocations = v.opts.CheckRevocations
+		opts.Getter = v.opts.Collateral
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	return opts
+}
+
+// PckLeafCertificate extracts the PCK leaf certificate from the PEM chain embedded in the quote.
+func PckLeafCertificate(quote *tdxpb.QuoteV4) (*x509.Certificate, error) {
+	chain := quote.GetSignedData().GetCertificationData().GetQeReportCertificationData().GetPckCertificateChainData().GetPckCertChain()
+	block, _ := pem.Decode(chain)
+	if block == nil {
+		return nil, errors.New("no PEM certificate in PCK certificate chain")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// ParseTrustedRoots parses PEM encoded root certificates into a CertPool.
+func ParseTrustedRoots(pemBytes []byte) (*x509.CertPool, error) {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pemBytes) {
+		return nil, errors.New("no valid PEM certificate found")
+	}
+	return roots, nil
+}
+
+// LoadTrustedRoots reads PEM encoded root certificates from a file.
+func LoadTrustedRoots(path string) (*x509.CertPool, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trusted root file: %w", err)
+	}
+	roots, err := ParseTrustedRoots(pemBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trusted root file %s: %w", path, err)
+	}
+	return roots, nil
+}
+
+// ConvertResultToProtobuf converts a Result to a VerifyQuoteResponse.
+func ConvertResultToProtobuf(result *Result) *attestpb.VerifyQuoteResponse {
+	resp := &attestpb.VerifyQuoteResponse{
+		Verified:          result.Verified,
+		CollateralChecked: result.CollateralChecked,
+	}
+	if result.Err != nil {
+		resp.FailureReason = result.Err.Error()
+	}
+	if result.Quote != nil {
+		resp.TdQuoteBody = utils.ConvertQuoteV4ToQuote(result.Quote).GetTdQuoteBody()
+	}
+	if cert := result.PckCertificate; cert != nil {
+		resp.PckCertificate = &attestpb.PCKCertificateInfo{
+			Subject:      cert.Subject.String(),
+			Issuer:       cert.Issuer.String(),
+			SerialNumber: cert.SerialNumber.Bytes(),
+			NotBefore:    cert.NotBefore.Unix(),
+			NotAfter:     cert.NotAfter.Unix(),
+		}
+		if ext := result.PckExtensions; ext != nil {
+			resp.PckCertificate.Fmspc = ext.FMSPC
+			resp.PckCertificate.PceId = ext.PCEID
+		}
+	}
+	return resp
+}
@@ -0,0 +1,89 @@
+package verifier
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-tdx-guest/testing/testdata"
+
+	"github.com/radiusxyz/lightbulb-tdx/utils"
+)
+
+var sampleQuoteTime = time.Date(2023, time.July, 1, 1, 0, 0, 0, time.UTC)
+
+func TestVerifyRawQuote(t *testing.T) {
+	v := NewVerifier(Options{Now: sampleQuoteTime})
+
+	result, err := v.VerifyRawQuote(testdata.RawQuote)
+	if err != nil {
+		t.Fatalf("VerifyRawQuote failed: %v", err)
+	}
+	if !result.Verified {
+		t.Errorf("result not verified")
+	}
+	if result.PckExtensions == nil || result.PckExtensions.FMSPC == "" {
+		t.Errorf("missing PCK extensions")
+	}
+}
+
+func TestVerifyQuoteTampered(t *testing.T) {
+	v := NewVerifier(Options{Now: sampleQuoteTime})
+
+	result, err := v.VerifyRawQuote(testdata.RawQuote)
+	if err != nil {
+		t.Fatalf("VerifyRawQuote failed: %v", err)
+	}
+
+	quote := utils.ConvertQuoteV4ToQuote(result.Quote)
+	reportData := append([]byte{}, quote.GetTdQuoteBody().ReportData...)
+	reportData[0] ^= 0xff
+	quote.GetTdQuoteBody().ReportData = reportData
+
+	result, err = v.VerifyQuote(quote)
+	if !errors.Is(err, ErrVerificationFailed) {
+		t.Fatalf("err = %v, want ErrVerificationFailed", err)
+	}
+	if result.Verified || result.Err == nil {
+		t.Errorf("tampered quote reported as verified")
+	}
+}
+
+func TestVerifyQuoteExpired(t *testing.T) {
+	v := NewVerifier(Options{Now: sampleQuoteTime.AddDate(30, 0, 0)})
+
+	if _, err := v.VerifyRawQuote(testdata.RawQuote); !errors.Is(err, ErrVerificationFailed) {
+		t.Errorf("err = %v, want ErrVerificationFailed", err)
+	}
+}
+
+func TestVerifyRawQuoteInvalid(t *testing.T) {
+	v := NewVerifier(Options{})
+
+	if _, err := v.VerifyRawQuote([]byte{0x04, 0x00}); !errors.Is(err, ErrInvalidQuote) {
+		t.Errorf("err = %v, want ErrInvalidQuote", err)
+	}
+}
+
+func TestDefaultVerifierCollateral(t *testing.T) {
+	t.Setenv("TDX_TRUSTED_ROOT_PATH", "")
+	t.Setenv("COLLATERAL_PCCS_URL", "")
+	t.Setenv("COLLATERAL_CACHE_DIR", "")
+
+	v, err := DefaultVerifier()
+	if err != nil {
+		t.Fatalf("DefaultVerifier failed: %v", err)
+	}
+	if v.Collateral() != nil {
+		t.Errorf("collateral checked without collateral source")
+	}
+
+	t.Setenv("COLLATERAL_CACHE_DIR", t.TempDir())
+	v, err = DefaultVerifier()
+	if err != nil {
+		t.Fatalf("DefaultVerifier failed: %v", err)
+	}
+	if v.Collateral() == nil {
+		t.Errorf("collateral not checked with COLLATERAL_CACHE_DIR set")
+	}
+}